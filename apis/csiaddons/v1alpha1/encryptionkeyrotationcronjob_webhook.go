@@ -0,0 +1,76 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var krcjLog = logf.Log.WithName("encryptionkeyrotationcronjob-webhook")
+
+func (r *EncryptionKeyRotationCronJob) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-csiaddons-openshift-io-v1alpha1-encryptionkeyrotationcronjob,mutating=false,failurePolicy=fail,sideEffects=None,groups=csiaddons.openshift.io,resources=encryptionkeyrotationcronjobs,verbs=update,versions=v1alpha1,name=vencryptionkeyrotationcronjob.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &EncryptionKeyRotationCronJob{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *EncryptionKeyRotationCronJob) ValidateCreate() (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *EncryptionKeyRotationCronJob) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	krcjLog.Info("validate update", "name", r.Name)
+
+	oldEncryptionKeyRotationCronJob, ok := old.(*EncryptionKeyRotationCronJob)
+	if !ok {
+		return nil, errors.New("error casting EncryptionKeyRotationCronJob object")
+	}
+
+	var allErrs field.ErrorList
+
+	if r.Spec.JobSpec.Spec.Target.PersistentVolumeClaim != oldEncryptionKeyRotationCronJob.Spec.JobSpec.Spec.Target.PersistentVolumeClaim {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "jobTemplate", "spec", "target", "persistentVolumeClaim"), r.Spec.JobSpec.Spec.Target.PersistentVolumeClaim, "persistentVolumeClaim cannot be changed"))
+	}
+
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: "csiaddons.openshift.io", Kind: "EncryptionKeyRotationCronJob"},
+			r.Name, allErrs)
+	}
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *EncryptionKeyRotationCronJob) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
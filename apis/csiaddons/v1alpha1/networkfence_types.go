@@ -20,6 +20,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// NetworkFenceRequestedByAnnotation contains the name of the user who
+// requested the operation described by the current Spec of the NetworkFence.
+// It is set by the mutating webhook.
+const NetworkFenceRequestedByAnnotation = "csiaddons.openshift.io/requested-by"
+
 type FenceState string
 
 const (
@@ -38,8 +43,52 @@ const (
 
 	// FencingOperationResultFailed represents the Failed operation state.
 	FencingOperationResultFailed FencingOperationResult = "Failed"
+
+	// FencingOperationResultExpired represents the state where the fence
+	// outlived its TTL and the CIDRs have been unfenced.
+	FencingOperationResultExpired FencingOperationResult = "Expired"
+
+	// FencingOperationResultPermanentlyFailed represents the state where
+	// the operation failed and will not be retried until the Spec changes.
+	FencingOperationResultPermanentlyFailed FencingOperationResult = "PermanentlyFailed"
+)
+
+// FenceTargetType is the type of a client identity, by which a client can be
+// fenced instead of its network address.
+type FenceTargetType string
+
+const (
+	// FenceTargetTypeClientID identifies a client by its storage client ID.
+	FenceTargetTypeClientID FenceTargetType = "ClientID"
+
+	// FenceTargetTypeNodeID identifies a client by the CSI node identifier.
+	FenceTargetTypeNodeID FenceTargetType = "NodeID"
+
+	// FenceTargetTypeISCSIInitiator identifies a client by its iSCSI
+	// initiator name (IQN).
+	FenceTargetTypeISCSIInitiator FenceTargetType = "ISCSIInitiator"
+
+	// FenceTargetTypeNVMeHostNQN identifies a client by its NVMe host NQN.
+	FenceTargetTypeNVMeHostNQN FenceTargetType = "NVMeHostNQN"
+
+	// FenceTargetTypeClientAddress identifies a client by its storage
+	// specific address, e.g. the Ceph client address.
+	FenceTargetTypeClientAddress FenceTargetType = "ClientAddress"
 )
 
+// FenceTarget identifies a client to be fenced by its identity.
+type FenceTarget struct {
+	// Type contains the type of the client identity.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=ClientID;NodeID;ISCSIInitiator;NVMeHostNQN;ClientAddress
+	Type FenceTargetType `json:"type"`
+
+	// Value contains the client identity.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Value string `json:"value"`
+}
+
 // SecretSpec defines the secrets to be used for the network fencing operation.
 type SecretSpec struct {
 	// Name specifies the name of the secret.
@@ -64,14 +113,101 @@ type NetworkFenceSpec struct {
 	FenceState FenceState `json:"fenceState"`
 
 	// Cidrs contains a list of CIDR blocks, which are required to be fenced.
-	// +kubebuilder:validation:Required
-	Cidrs []string `json:"cidrs"`
+	// Either Cidrs or Targets must be specified.
+	// +optional
+	Cidrs []string `json:"cidrs,omitempty"`
+
+	// Targets contains a list of client identities, which are required to
+	// be fenced. Either Cidrs or Targets must be specified. The operation
+	// fails permanently when the driver does not support fencing by client
+	// identity.
+	// +optional
+	Targets []FenceTarget `json:"targets,omitempty"`
 
 	// Secret is a kubernetes secret, which is required to perform the fence/unfence operation.
 	Secret SecretSpec `json:"secret,omitempty"`
 
 	// Parameters is used to pass additional parameters to the CSI driver.
 	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// TTLSeconds specifies the duration in seconds for which the CIDRs stay
+	// fenced. Once the duration has elapsed, the CIDRs are unfenced and the
+	// NetworkFence is marked as Expired. If not specified, the fence does
+	// not expire.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TTLSeconds *int64 `json:"ttlSeconds,omitempty"`
+
+	// Timeout specifies the timeout in seconds for the grpc request sent to the
+	// CSI driver. If not specified, defaults to global networkfence timeout.
+	// Minimum allowed value is 60.
+	// +optional
+	// +kubebuilder:validation:Minimum=60
+	Timeout *int64 `json:"timeout,omitempty"`
+
+	// BackOffLimit specifies the number of retries allowed before marking the
+	// fencing operation as permanently failed. If not specified, defaults to 10.
+	// Maximum allowed value is 60 and minimum allowed value is 0.
+	// +optional
+	// +kubebuilder:validation:Maximum=60
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default:=10
+	BackoffLimit int32 `json:"backOffLimit"`
+}
+
+// CIDRStatus defines the observed state of a CIDR block.
+type CIDRStatus struct {
+	// Cidr contains the CIDR block.
+	Cidr string `json:"cidr"`
+
+	// State is the fence state which was last applied successfully
+	// to the CIDR block.
+	State FenceState `json:"state,omitempty"`
+
+	// Result indicates the result of the last operation on the CIDR block.
+	Result FencingOperationResult `json:"result,omitempty"`
+
+	// Message contains any message from the last operation on the CIDR block.
+	Message string `json:"message,omitempty"`
+}
+
+// FenceHistoryEntry records a fence or unfence operation sent to the driver.
+type FenceHistoryEntry struct {
+	// Operation contains the fence state that was requested from the driver.
+	Operation FenceState `json:"operation"`
+
+	// Cidrs contains the CIDR blocks sent to the driver.
+	Cidrs []string `json:"cidrs,omitempty"`
+
+	// Targets contains the client identities sent to the driver.
+	Targets []FenceTarget `json:"targets,omitempty"`
+
+	// RequestedBy contains the user who requested the operation.
+	RequestedBy string `json:"requestedBy,omitempty"`
+
+	// Time is the time at which the operation was applied.
+	Time metav1.Time `json:"time"`
+
+	// Result indicates the result of the operation.
+	Result FencingOperationResult `json:"result"`
+
+	// Message contains the response of the driver.
+	Message string `json:"message,omitempty"`
+}
+
+// FenceTargetStatus defines the observed state of a fence target.
+type FenceTargetStatus struct {
+	FenceTarget `json:",inline"`
+
+	// State is the fence state which was last applied successfully
+	// to the target.
+	State FenceState `json:"state,omitempty"`
+
+	// Result indicates the result of the last operation on the target.
+	Result FencingOperationResult `json:"result,omitempty"`
+
+	// Message contains any message from the last operation on the target.
+	Message string `json:"message,omitempty"`
 }
 
 // NetworkFenceStatus defines the observed state of NetworkFence
@@ -82,8 +218,43 @@ type NetworkFenceStatus struct {
 	// Message contains any message from the NetworkFence operation.
 	Message string `json:"message,omitempty"`
 
+	// FenceTime is the time at which the CIDRs were fenced.
+	FenceTime *metav1.Time `json:"fenceTime,omitempty"`
+
+	// ExpiryTime is the time at which the fence expires, it is only set
+	// when TTLSeconds is specified.
+	ExpiryTime *metav1.Time `json:"expiryTime,omitempty"`
+
+	// Cidrs contains the state of each CIDR block mentioned in the Spec.
+	Cidrs []CIDRStatus `json:"cidrs,omitempty"`
+
+	// Targets contains the state of each target mentioned in the Spec.
+	Targets []FenceTargetStatus `json:"targets,omitempty"`
+
 	// Conditions are the list of conditions and their status.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Retries indicates the number of times the failed operation is retried.
+	Retries int32 `json:"retries,omitempty"`
+
+	// NextRetryTime is the time at which the failed operation is retried.
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+
+	// SecretResourceVersion is the resourceVersion of the secret that was
+	// last used. A permanently failed operation is retried once the secret
+	// has changed.
+	SecretResourceVersion string `json:"secretResourceVersion,omitempty"`
+
+	// ObservedGeneration is the last generation of the Spec which
+	// was reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// History contains the most recent fence and unfence operations sent
+	// to the driver, oldest first. At most 50 operations are kept, older
+	// operations are dropped from the history. Every operation is recorded
+	// as an Event on the NetworkFence as well.
+	// +kubebuilder:validation:MaxItems=50
+	History []FenceHistoryEntry `json:"history,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -93,6 +264,8 @@ type NetworkFenceStatus struct {
 //+kubebuilder:printcolumn:name="FenceState",type="string",JSONPath=".spec.fenceState"
 //+kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name=Age,type=date
 //+kubebuilder:printcolumn:JSONPath=".status.result",name=Result,type=string
+//+kubebuilder:printcolumn:JSONPath=".status.retries",name=Retries,type=integer,priority=1
+//+kubebuilder:printcolumn:JSONPath=".status.expiryTime",name=ExpiryTime,type=date,priority=1
 //+kubebuilder:resource:path=networkfences,scope=Cluster,singular=networkfence
 
 // NetworkFence is the Schema for the networkfences API
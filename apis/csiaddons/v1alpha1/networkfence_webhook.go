@@ -17,9 +17,12 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"reflect"
 
+	admissionv1 "k8s.io/api/admission/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -36,9 +39,56 @@ var nfLog = logf.Log.WithName("networkfence-webhook")
 func (n *NetworkFence) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(n).
+		WithDefaulter(&networkFenceRequester{}).
 		Complete()
 }
 
+//+kubebuilder:webhook:path=/mutate-csiaddons-openshift-io-v1alpha1-networkfence,mutating=true,failurePolicy=fail,sideEffects=None,groups=csiaddons.openshift.io,resources=networkfences,verbs=create;update,versions=v1alpha1,name=mnetworkfence.kb.io,admissionReviewVersions=v1
+
+// networkFenceRequester records the user who requested the operation
+// described by the Spec in the NetworkFenceRequestedByAnnotation.
+type networkFenceRequester struct{}
+
+var _ admission.CustomDefaulter = &networkFenceRequester{}
+
+// Default implements admission.CustomDefaulter so a webhook will be registered for the type
+func (r *networkFenceRequester) Default(ctx context.Context, obj runtime.Object) error {
+	n, ok := obj.(*NetworkFence)
+	if !ok {
+		return errors.New("error casting NetworkFence object")
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	requester := req.UserInfo.Username
+	if req.Operation == admissionv1.Update {
+		oldNetworkFence := &NetworkFence{}
+		if err = json.Unmarshal(req.OldObject.Raw, oldNetworkFence); err != nil {
+			return err
+		}
+
+		// keep the recorded user when the spec is not changed.
+		if reflect.DeepEqual(n.Spec, oldNetworkFence.Spec) {
+			requester = oldNetworkFence.Annotations[NetworkFenceRequestedByAnnotation]
+		}
+	}
+
+	nfLog.Info("record requester", "name", n.Name, "requester", requester)
+	if requester == "" {
+		delete(n.Annotations, NetworkFenceRequestedByAnnotation)
+		return nil
+	}
+	if n.Annotations == nil {
+		n.Annotations = map[string]string{}
+	}
+	n.Annotations[NetworkFenceRequestedByAnnotation] = requester
+
+	return nil
+}
+
 //+kubebuilder:webhook:path=/validate-csiaddons-openshift-io-v1alpha1-networkfence,mutating=false,failurePolicy=fail,sideEffects=None,groups=csiaddons.openshift.io,resources=networkfences,verbs=update,versions=v1alpha1,name=vnetworkfence.kb.io,admissionReviewVersions=v1
 
 var _ webhook.Validator = &NetworkFence{}
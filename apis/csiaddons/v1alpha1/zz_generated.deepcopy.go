@@ -22,11 +22,26 @@ limitations under the License.
 package v1alpha1
 
 import (
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CIDRStatus) DeepCopyInto(out *CIDRStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CIDRStatus.
+func (in *CIDRStatus) DeepCopy() *CIDRStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CIDRStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CSIAddonsNode) DeepCopyInto(out *CSIAddonsNode) {
 	*out = *in
@@ -132,6 +147,423 @@ func (in *CSIAddonsNodeStatus) DeepCopy() *CSIAddonsNodeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionKeyRotationCronJob) DeepCopyInto(out *EncryptionKeyRotationCronJob) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionKeyRotationCronJob.
+func (in *EncryptionKeyRotationCronJob) DeepCopy() *EncryptionKeyRotationCronJob {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionKeyRotationCronJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EncryptionKeyRotationCronJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionKeyRotationCronJobList) DeepCopyInto(out *EncryptionKeyRotationCronJobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EncryptionKeyRotationCronJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionKeyRotationCronJobList.
+func (in *EncryptionKeyRotationCronJobList) DeepCopy() *EncryptionKeyRotationCronJobList {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionKeyRotationCronJobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EncryptionKeyRotationCronJobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionKeyRotationCronJobSpec) DeepCopyInto(out *EncryptionKeyRotationCronJobSpec) {
+	*out = *in
+	if in.StartingDeadlineSeconds != nil {
+		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Suspend != nil {
+		in, out := &in.Suspend, &out.Suspend
+		*out = new(bool)
+		**out = **in
+	}
+	in.JobSpec.DeepCopyInto(&out.JobSpec)
+	if in.SuccessfulJobsHistoryLimit != nil {
+		in, out := &in.SuccessfulJobsHistoryLimit, &out.SuccessfulJobsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedJobsHistoryLimit != nil {
+		in, out := &in.FailedJobsHistoryLimit, &out.FailedJobsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionKeyRotationCronJobSpec.
+func (in *EncryptionKeyRotationCronJobSpec) DeepCopy() *EncryptionKeyRotationCronJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionKeyRotationCronJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionKeyRotationCronJobStatus) DeepCopyInto(out *EncryptionKeyRotationCronJobStatus) {
+	*out = *in
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = new(v1.ObjectReference)
+		**out = **in
+	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionKeyRotationCronJobStatus.
+func (in *EncryptionKeyRotationCronJobStatus) DeepCopy() *EncryptionKeyRotationCronJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionKeyRotationCronJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionKeyRotationJob) DeepCopyInto(out *EncryptionKeyRotationJob) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionKeyRotationJob.
+func (in *EncryptionKeyRotationJob) DeepCopy() *EncryptionKeyRotationJob {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionKeyRotationJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EncryptionKeyRotationJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionKeyRotationJobList) DeepCopyInto(out *EncryptionKeyRotationJobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EncryptionKeyRotationJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionKeyRotationJobList.
+func (in *EncryptionKeyRotationJobList) DeepCopy() *EncryptionKeyRotationJobList {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionKeyRotationJobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EncryptionKeyRotationJobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionKeyRotationJobSpec) DeepCopyInto(out *EncryptionKeyRotationJobSpec) {
+	*out = *in
+	out.Target = in.Target
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionKeyRotationJobSpec.
+func (in *EncryptionKeyRotationJobSpec) DeepCopy() *EncryptionKeyRotationJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionKeyRotationJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionKeyRotationJobStatus) DeepCopyInto(out *EncryptionKeyRotationJobStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionKeyRotationJobStatus.
+func (in *EncryptionKeyRotationJobStatus) DeepCopy() *EncryptionKeyRotationJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionKeyRotationJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionKeyRotationJobTemplateSpec) DeepCopyInto(out *EncryptionKeyRotationJobTemplateSpec) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionKeyRotationJobTemplateSpec.
+func (in *EncryptionKeyRotationJobTemplateSpec) DeepCopy() *EncryptionKeyRotationJobTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionKeyRotationJobTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FenceHistoryEntry) DeepCopyInto(out *FenceHistoryEntry) {
+	*out = *in
+	if in.Cidrs != nil {
+		in, out := &in.Cidrs, &out.Cidrs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]FenceTarget, len(*in))
+		copy(*out, *in)
+	}
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FenceHistoryEntry.
+func (in *FenceHistoryEntry) DeepCopy() *FenceHistoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(FenceHistoryEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FenceTarget) DeepCopyInto(out *FenceTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FenceTarget.
+func (in *FenceTarget) DeepCopy() *FenceTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(FenceTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FenceTargetStatus) DeepCopyInto(out *FenceTargetStatus) {
+	*out = *in
+	out.FenceTarget = in.FenceTarget
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FenceTargetStatus.
+func (in *FenceTargetStatus) DeepCopy() *FenceTargetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FenceTargetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemMaintenanceJob) DeepCopyInto(out *FilesystemMaintenanceJob) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemMaintenanceJob.
+func (in *FilesystemMaintenanceJob) DeepCopy() *FilesystemMaintenanceJob {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemMaintenanceJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FilesystemMaintenanceJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemMaintenanceJobList) DeepCopyInto(out *FilesystemMaintenanceJobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FilesystemMaintenanceJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemMaintenanceJobList.
+func (in *FilesystemMaintenanceJobList) DeepCopy() *FilesystemMaintenanceJobList {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemMaintenanceJobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FilesystemMaintenanceJobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemMaintenanceJobSpec) DeepCopyInto(out *FilesystemMaintenanceJobSpec) {
+	*out = *in
+	out.Target = in.Target
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemMaintenanceJobSpec.
+func (in *FilesystemMaintenanceJobSpec) DeepCopy() *FilesystemMaintenanceJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemMaintenanceJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemMaintenanceJobStatus) DeepCopyInto(out *FilesystemMaintenanceJobStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemMaintenanceJobStatus.
+func (in *FilesystemMaintenanceJobStatus) DeepCopy() *FilesystemMaintenanceJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemMaintenanceJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkFence) DeepCopyInto(out *NetworkFence) {
 	*out = *in
@@ -199,6 +631,11 @@ func (in *NetworkFenceSpec) DeepCopyInto(out *NetworkFenceSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]FenceTarget, len(*in))
+		copy(*out, *in)
+	}
 	out.Secret = in.Secret
 	if in.Parameters != nil {
 		in, out := &in.Parameters, &out.Parameters
@@ -207,6 +644,16 @@ func (in *NetworkFenceSpec) DeepCopyInto(out *NetworkFenceSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.TTLSeconds != nil {
+		in, out := &in.TTLSeconds, &out.TTLSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkFenceSpec.
@@ -222,9 +669,38 @@ func (in *NetworkFenceSpec) DeepCopy() *NetworkFenceSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkFenceStatus) DeepCopyInto(out *NetworkFenceStatus) {
 	*out = *in
+	if in.FenceTime != nil {
+		in, out := &in.FenceTime, &out.FenceTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpiryTime != nil {
+		in, out := &in.ExpiryTime, &out.ExpiryTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Cidrs != nil {
+		in, out := &in.Cidrs, &out.Cidrs
+		*out = make([]CIDRStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]FenceTargetStatus, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NextRetryTime != nil {
+		in, out := &in.NextRetryTime, &out.NextRetryTime
+		*out = (*in).DeepCopy()
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]FenceHistoryEntry, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -341,7 +817,7 @@ func (in *ReclaimSpaceCronJobStatus) DeepCopyInto(out *ReclaimSpaceCronJobStatus
 	*out = *in
 	if in.Active != nil {
 		in, out := &in.Active, &out.Active
-		*out = new(corev1.ObjectReference)
+		*out = new(v1.ObjectReference)
 		**out = **in
 	}
 	if in.LastScheduleTime != nil {
@@ -454,7 +930,7 @@ func (in *ReclaimSpaceJobStatus) DeepCopyInto(out *ReclaimSpaceJobStatus) {
 	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -23,6 +23,10 @@ import (
 
 const (
 	VolumeReplicationNameAnnotation = "replication.storage.openshift.io/volume-replication-name"
+
+	// VolumeReplicationClassField is the field by which the VolumeReplication
+	// controller indexes the VolumeReplications in the cache.
+	VolumeReplicationClassField = "spec.volumeReplicationClass"
 )
 
 // ReplicationState represents the replication operations to be performed on the volume.
@@ -40,6 +44,24 @@ const (
 	Resync ReplicationState = "resync"
 )
 
+// ForcePromotionPolicy controls whether a volume may be force promoted.
+// +kubebuilder:validation:Enum=never;auto;always
+type ForcePromotionPolicy string
+
+const (
+	// ForcePromotionNever never force promotes the volume. A promotion that
+	// fails because the volume is not ready to be promoted is reported as a
+	// failure.
+	ForcePromotionNever ForcePromotionPolicy = "never"
+
+	// ForcePromotionAuto force promotes the volume when a promotion fails
+	// because the volume is not ready to be promoted.
+	ForcePromotionAuto ForcePromotionPolicy = "auto"
+
+	// ForcePromotionAlways always force promotes the volume.
+	ForcePromotionAlways ForcePromotionPolicy = "always"
+)
+
 // State captures the latest state of the replication operation.
 type State string
 
@@ -65,7 +87,10 @@ type VolumeReplicationSpec struct {
 	// +kubebuilder:validation:Required
 	ReplicationState ReplicationState `json:"replicationState"`
 
-	// DataSource represents the object associated with the volume
+	// DataSource represents the object associated with the volume.
+	// Supported kinds are "PersistentVolumeClaim", "Pod" and "StatefulSet".
+	// For a Pod or StatefulSet, each of its PersistentVolumeClaims is
+	// replicated by a member VolumeReplication.
 	// +kubebuilder:validation:Required
 	DataSource corev1.TypedLocalObjectReference `json:"dataSource"`
 
@@ -77,6 +102,44 @@ type VolumeReplicationSpec struct {
 	// replicationHandle represents an existing (but new) replication id
 	// +kubebuilder:validation:Optional
 	ReplicationHandle string `json:"replicationHandle"`
+
+	// ForcePromotion controls whether the volume may be force promoted when
+	// ReplicationState is "primary". Supported values are "never", "auto"
+	// and "always". Force promoting a volume while the peer is still primary
+	// can cause a split-brain.
+	// +kubebuilder:default:=auto
+	// +kubebuilder:validation:Optional
+	ForcePromotion ForcePromotionPolicy `json:"forcePromotion,omitempty"`
+}
+
+// ResyncProgress describes the progress of a resync, as reported by the driver.
+type ResyncProgress struct {
+	// PercentComplete is the percentage of the resync that is complete.
+	// +optional
+	PercentComplete *int32 `json:"percentComplete,omitempty"`
+	// BytesRemaining is the number of bytes that still need to be synced.
+	// +optional
+	BytesRemaining *int64 `json:"bytesRemaining,omitempty"`
+	// LastUpdateTime is the time at which the progress was reported.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+	// EstimatedCompletionTime is the time at which the resync is expected
+	// to complete, based on the progress since the previous report.
+	// +optional
+	EstimatedCompletionTime *metav1.Time `json:"estimatedCompletionTime,omitempty"`
+}
+
+// VolumeReplicationMemberStatus describes the VolumeReplication that
+// replicates one of the PersistentVolumeClaims of a Pod or StatefulSet data
+// source.
+type VolumeReplicationMemberStatus struct {
+	// Name is the name of the member VolumeReplication.
+	Name string `json:"name"`
+	// PersistentVolumeClaim is the name of the replicated
+	// PersistentVolumeClaim.
+	PersistentVolumeClaim string `json:"persistentVolumeClaim"`
+	// State is the replication state of the member.
+	// +optional
+	State State `json:"state,omitempty"`
 }
 
 // VolumeReplicationStatus defines the observed state of VolumeReplication.
@@ -87,12 +150,29 @@ type VolumeReplicationStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 	// observedGeneration is the last generation change the operator has dealt with
 	// +optional
-	ObservedGeneration int64            `json:"observedGeneration,omitempty"`
-	LastStartTime      *metav1.Time     `json:"lastStartTime,omitempty"`
-	LastCompletionTime *metav1.Time     `json:"lastCompletionTime,omitempty"`
-	LastSyncTime       *metav1.Time     `json:"lastSyncTime,omitempty"`
-	LastSyncBytes      *int64           `json:"lastSyncBytes,omitempty"`
-	LastSyncDuration   *metav1.Duration `json:"lastSyncDuration,omitempty"`
+	ObservedGeneration int64        `json:"observedGeneration,omitempty"`
+	LastStartTime      *metav1.Time `json:"lastStartTime,omitempty"`
+	LastCompletionTime *metav1.Time `json:"lastCompletionTime,omitempty"`
+	// LastSyncTime is the time of the last sync. For a primary volume it
+	// is the last sync to the peer, for a secondary volume the last sync
+	// received from the peer.
+	LastSyncTime     *metav1.Time     `json:"lastSyncTime,omitempty"`
+	LastSyncBytes    *int64           `json:"lastSyncBytes,omitempty"`
+	LastSyncDuration *metav1.Duration `json:"lastSyncDuration,omitempty"`
+	// LastForcePromotionTime is the time at which the volume was last force
+	// promoted.
+	LastForcePromotionTime *metav1.Time `json:"lastForcePromotionTime,omitempty"`
+	// ResyncProgress is the progress of the ongoing resync, if reported by
+	// the driver.
+	ResyncProgress *ResyncProgress `json:"resyncProgress,omitempty"`
+	// Schedule is the schedule of the mirroring snapshots from the
+	// VolumeReplicationClass.
+	// +optional
+	Schedule *ReplicationSchedule `json:"schedule,omitempty"`
+	// Members are the VolumeReplications of the PersistentVolumeClaims of a
+	// Pod or StatefulSet data source.
+	// +optional
+	Members []VolumeReplicationMemberStatus `json:"members,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -17,12 +17,14 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
 	"errors"
 	"reflect"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -34,6 +36,8 @@ import (
 var vrLog = logf.Log.WithName("volumereplication-webhook")
 
 func (v *VolumeReplication) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	webhookClient = mgr.GetAPIReader()
+
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(v).
 		Complete()
@@ -64,9 +68,10 @@ func (v *VolumeReplication) ValidateUpdate(old runtime.Object) (admission.Warnin
 		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("dataSource"), v.Spec.DataSource, "dataSource cannot be changed"))
 	}
 
-	if oldReplication.Spec.VolumeReplicationClass != v.Spec.VolumeReplicationClass {
+	if oldReplication.Spec.VolumeReplicationClass != v.Spec.VolumeReplicationClass &&
+		!isReplacementClass(oldReplication.Spec.VolumeReplicationClass, v.Spec.VolumeReplicationClass) {
 		vrLog.Info("invalid request to change the volumeReplicationClass", "exiting volumeReplicationClass", oldReplication.Spec.VolumeReplicationClass, "new volumeReplicationClass", v.Spec.VolumeReplicationClass)
-		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("volumeReplicationClass"), v.Spec.VolumeReplicationClass, "volumeReplicationClass cannot be changed"))
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("volumeReplicationClass"), v.Spec.VolumeReplicationClass, "volumeReplicationClass cannot be changed, except to the class that replaces it"))
 	}
 
 	if len(allErrs) != 0 {
@@ -82,3 +87,21 @@ func (v *VolumeReplication) ValidateUpdate(old runtime.Object) (admission.Warnin
 func (v *VolumeReplication) ValidateDelete() (admission.Warnings, error) {
 	return nil, nil
 }
+
+// isReplacementClass returns true if the VolumeReplicationClass is replaced by
+// the replacement, so that the VolumeReplication can be migrated to it.
+func isReplacementClass(vrcName, replacement string) bool {
+	if webhookClient == nil {
+		return false
+	}
+
+	vrc := &VolumeReplicationClass{}
+	err := webhookClient.Get(context.TODO(), types.NamespacedName{Name: vrcName}, vrc)
+	if err != nil {
+		vrLog.Error(err, "failed to get VolumeReplicationClass", "name", vrcName)
+
+		return false
+	}
+
+	return vrc.Annotations[VolumeReplicationClassReplacedByAnnotation] == replacement
+}
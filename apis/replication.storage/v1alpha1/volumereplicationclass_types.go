@@ -20,6 +20,24 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// ReplicationMode is the mode in which the storage system replicates the
+// volumes.
+// +kubebuilder:validation:Enum=sync;async;snapshot
+type ReplicationMode string
+
+const (
+	// SyncReplicationMode replicates each write before it is acknowledged.
+	SyncReplicationMode ReplicationMode = "sync"
+
+	// AsyncReplicationMode replicates the writes after they are
+	// acknowledged.
+	AsyncReplicationMode ReplicationMode = "async"
+
+	// SnapshotReplicationMode replicates the differences between periodic
+	// snapshots of the volume.
+	SnapshotReplicationMode ReplicationMode = "snapshot"
+)
+
 // VolumeReplicationClassSpec specifies parameters that an underlying storage system uses
 // when creating a volume replica. A specific VolumeReplicationClass is used by specifying
 // its name in a VolumeReplication object.
@@ -31,15 +49,56 @@ type VolumeReplicationClassSpec struct {
 	// creating volume replicas
 	// +kubebuilder:validation:Optional
 	Parameters map[string]string `json:"parameters,omitempty"`
+	// Mode is the replication mode, one of "sync", "async" or "snapshot".
+	// The driver must support the mode. It is passed to the driver as the
+	// replicationMode parameter.
+	// +kubebuilder:validation:Optional
+	Mode ReplicationMode `json:"mode,omitempty"`
+	// Schedule is the schedule of the mirroring snapshots of the
+	// replicated volumes. It is passed to the driver as the
+	// schedulingInterval, schedulingStartTime and schedulingRetentionCount
+	// parameters.
+	// +kubebuilder:validation:Optional
+	Schedule *ReplicationSchedule `json:"schedule,omitempty"`
+}
+
+// ReplicationSchedule is the schedule at which the storage system takes
+// mirroring snapshots of a replicated volume.
+type ReplicationSchedule struct {
+	// Interval between two mirroring snapshots, in minutes or hours, like
+	// "15m" or "1h".
+	// +kubebuilder:validation:Required
+	Interval metav1.Duration `json:"interval"`
+	// StartTime is the time from which the interval is counted.
+	// +kubebuilder:validation:Optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// RetentionCount is the number of mirroring snapshots to keep.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	RetentionCount *int32 `json:"retentionCount,omitempty"`
 }
 
 // VolumeReplicationClassStatus defines the observed state of VolumeReplicationClass.
-type VolumeReplicationClassStatus struct{}
+type VolumeReplicationClassStatus struct {
+	// Conditions are the results of the validation of the parameters and
+	// secrets, and of the connection to the driver.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// VolumeReplications is the number of VolumeReplications that use the
+	// VolumeReplicationClass.
+	// +optional
+	VolumeReplications int32 `json:"volumeReplications"`
+	// ObservedGeneration is the last generation that was validated.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,shortName=vrc
 // +kubebuilder:printcolumn:JSONPath=".spec.provisioner",name=provisioner,type=string
+// +kubebuilder:printcolumn:JSONPath=".spec.mode",name=mode,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.volumeReplications",name=volumeReplications,type=integer
 
 // VolumeReplicationClass is the Schema for the volumereplicationclasses API.
 type VolumeReplicationClass struct {
@@ -17,14 +17,18 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"reflect"
+	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -33,18 +37,60 @@ import (
 // log is for logging in this package.
 var vrcLog = logf.Log.WithName("volumereplicationclass-webhook")
 
+// webhookClient is used by the webhooks to look up the objects that the
+// validated object refers to.
+var webhookClient client.Reader
+
+// webhookCache is used by the webhooks to list the objects that refer to the
+// validated object, with the indexes of the controllers.
+var webhookCache client.Reader
+
 func (v *VolumeReplicationClass) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	webhookClient = mgr.GetAPIReader()
+	webhookCache = mgr.GetClient()
+
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(v).
 		Complete()
 }
 
-//+kubebuilder:webhook:path=/validate-replication-storage-openshift-io-v1alpha1-volumereplicationclass,mutating=false,failurePolicy=fail,sideEffects=None,groups=replication.storage.openshift.io,resources=volumereplicationclasses,verbs=update,versions=v1alpha1,name=vvolumereplicationclass.kb.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-replication-storage-openshift-io-v1alpha1-volumereplicationclass,mutating=false,failurePolicy=fail,sideEffects=None,groups=replication.storage.openshift.io,resources=volumereplicationclasses,verbs=create;update;delete,versions=v1alpha1,name=vvolumereplicationclass.kb.io,admissionReviewVersions=v1
 
 var _ webhook.Validator = &VolumeReplicationClass{}
 
+const (
+	// ScheduleIntervalParameter, ScheduleStartTimeParameter and
+	// ScheduleRetentionCountParameter are the parameters in which the
+	// Schedule is passed to the driver.
+	ScheduleIntervalParameter       = "schedulingInterval"
+	ScheduleStartTimeParameter      = "schedulingStartTime"
+	ScheduleRetentionCountParameter = "schedulingRetentionCount"
+
+	// VolumeReplicationClassReplacedByAnnotation names the
+	// VolumeReplicationClass to which the VolumeReplications of the
+	// annotated class are migrated.
+	VolumeReplicationClassReplacedByAnnotation = "replication.storage.openshift.io/replaced-by"
+
+	// ReplicationModeParameter is the parameter in which the Mode is passed
+	// to the driver.
+	ReplicationModeParameter = "replicationMode"
+
+	// minScheduleInterval is the shortest interval of a Schedule.
+	minScheduleInterval = time.Minute
+)
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (v *VolumeReplicationClass) ValidateCreate() (admission.Warnings, error) {
+	vrcLog.Info("validate create", "name", v.Name)
+
+	allErrs := v.validateSchedule()
+	allErrs = append(allErrs, v.validateMode()...)
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: "replication.storage.openshift.io", Kind: "VolumeReplicationClass"},
+			v.Name, allErrs)
+	}
+
 	return nil, nil
 }
 
@@ -68,6 +114,16 @@ func (v *VolumeReplicationClass) ValidateUpdate(old runtime.Object) (admission.W
 		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("parameters"), v.Spec.Parameters, "parameters cannot be changed"))
 	}
 
+	if oldReplicationClass.Spec.Mode != v.Spec.Mode {
+		vrcLog.Info("invalid request to change the mode", "exiting mode", oldReplicationClass.Spec.Mode, "new mode", v.Spec.Mode)
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("mode"), v.Spec.Mode, "mode cannot be changed"))
+	}
+
+	if !reflect.DeepEqual(oldReplicationClass.Spec.Schedule, v.Spec.Schedule) {
+		vrcLog.Info("invalid request to change the schedule", "exiting schedule", oldReplicationClass.Spec.Schedule, "new schedule", v.Spec.Schedule)
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("schedule"), v.Spec.Schedule, "schedule cannot be changed"))
+	}
+
 	if len(allErrs) == 0 {
 		return nil, nil
 	}
@@ -80,5 +136,81 @@ func (v *VolumeReplicationClass) ValidateUpdate(old runtime.Object) (admission.W
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (v *VolumeReplicationClass) ValidateDelete() (admission.Warnings, error) {
-	return nil, nil
+	vrcLog.Info("validate delete", "name", v.Name)
+
+	if webhookCache == nil {
+		return nil, nil
+	}
+
+	vrs := &VolumeReplicationList{}
+	err := webhookCache.List(context.TODO(), vrs, client.MatchingFields{VolumeReplicationClassField: v.Name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list VolumeReplications: %w", err)
+	}
+
+	inUse := len(vrs.Items)
+	if inUse == 0 {
+		return nil, nil
+	}
+
+	// the finalizer of the controller still protects the class when the
+	// webhook is not deployed, or the cache is behind.
+	return nil, apierrors.NewForbidden(
+		schema.GroupResource{Group: "replication.storage.openshift.io", Resource: "volumereplicationclasses"},
+		v.Name, fmt.Errorf("it is used by %d VolumeReplication(s), delete them or migrate them "+
+			"with the %s annotation first", inUse, VolumeReplicationClassReplacedByAnnotation))
+}
+
+// validateSchedule checks that the schedule can be passed to the driver, and
+// that it does not conflict with the scheduling parameters.
+func (v *VolumeReplicationClass) validateSchedule() field.ErrorList {
+	var allErrs field.ErrorList
+
+	schedule := v.Spec.Schedule
+	if schedule == nil {
+		return nil
+	}
+
+	schedulePath := field.NewPath("spec").Child("schedule")
+	interval := schedule.Interval.Duration
+	if interval < minScheduleInterval || interval%time.Minute != 0 {
+		allErrs = append(allErrs, field.Invalid(schedulePath.Child("interval"), schedule.Interval.String(),
+			"interval must be a whole number of minutes, and at least 1m"))
+	}
+
+	if schedule.RetentionCount != nil && *schedule.RetentionCount < 1 {
+		allErrs = append(allErrs, field.Invalid(schedulePath.Child("retentionCount"), *schedule.RetentionCount,
+			"retentionCount must be at least 1"))
+	}
+
+	for _, key := range []string{ScheduleIntervalParameter, ScheduleStartTimeParameter, ScheduleRetentionCountParameter} {
+		if _, ok := v.Spec.Parameters[key]; ok {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("parameters").Key(key),
+				"parameter cannot be set together with the schedule"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateMode checks that the mode does not conflict with the
+// replicationMode parameter.
+func (v *VolumeReplicationClass) validateMode() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if v.Spec.Mode == "" {
+		return nil
+	}
+
+	if _, ok := v.Spec.Parameters[ReplicationModeParameter]; ok {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("parameters").Key(ReplicationModeParameter),
+			"parameter cannot be set together with the mode"))
+	}
+
+	if v.Spec.Schedule != nil && v.Spec.Mode != SnapshotReplicationMode {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("schedule"), v.Spec.Schedule,
+			"schedule can only be set for the snapshot mode"))
+	}
+
+	return allErrs
 }
@@ -0,0 +1,60 @@
+/*
+Copyright 2022 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestVolumeReplicationClassValidateDelete sets the webhookCache, it can
+// not run in parallel with the other tests of the webhooks.
+func TestVolumeReplicationClassValidateDelete(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, AddToScheme(scheme))
+	vr := &VolumeReplication{
+		ObjectMeta: metav1.ObjectMeta{Name: "vr", Namespace: "default"},
+		Spec: VolumeReplicationSpec{
+			VolumeReplicationClass: "in-use",
+			DataSource:             corev1.TypedLocalObjectReference{Kind: "PersistentVolumeClaim", Name: "pvc"},
+		},
+	}
+	webhookCache = fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(vr).
+		WithIndex(&VolumeReplication{}, VolumeReplicationClassField, func(obj client.Object) []string {
+			return []string{obj.(*VolumeReplication).Spec.VolumeReplicationClass}
+		}).
+		Build()
+	t.Cleanup(func() { webhookCache = nil })
+
+	unused := &VolumeReplicationClass{ObjectMeta: metav1.ObjectMeta{Name: "unused"}}
+	_, err := unused.ValidateDelete()
+	assert.NoError(t, err)
+
+	inUse := &VolumeReplicationClass{ObjectMeta: metav1.ObjectMeta{Name: "in-use"}}
+	_, err = inUse.ValidateDelete()
+	assert.True(t, apierrors.IsForbidden(err))
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2022 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FailoverAction represents the workflow to be performed by a VolumeReplicationFailover.
+// +kubebuilder:validation:Enum=Relocate;Failover
+type FailoverAction string
+
+const (
+	// Relocate demotes the source, waits for the final sync and promotes
+	// the target. It is used for planned switch-overs.
+	Relocate FailoverAction = "Relocate"
+
+	// Failover promotes the target without demoting the source. It is used
+	// when the source is not reachable.
+	Failover FailoverAction = "Failover"
+)
+
+// TargetCluster represents the cluster in which the target of a
+// VolumeReplicationFailover is resolved.
+// +kubebuilder:validation:Enum=Local;Peer
+type TargetCluster string
+
+const (
+	// LocalCluster resolves the target in the cluster of the
+	// VolumeReplicationFailover.
+	LocalCluster TargetCluster = "Local"
+
+	// PeerCluster resolves the target in the peer cluster that is
+	// configured in the VolumeReplicationClass of the source.
+	PeerCluster TargetCluster = "Peer"
+)
+
+// FailoverPhase represents the phase of a VolumeReplicationFailover.
+type FailoverPhase string
+
+const (
+	// FailoverPending means that the workflow has not started yet.
+	FailoverPending FailoverPhase = "Pending"
+
+	// FailoverDemoting means that the source is being demoted.
+	FailoverDemoting FailoverPhase = "Demoting"
+
+	// FailoverWaitingForSync means that the source has been demoted and
+	// the final sync is being confirmed.
+	FailoverWaitingForSync FailoverPhase = "WaitingForSync"
+
+	// FailoverPromoting means that the target is being promoted.
+	FailoverPromoting FailoverPhase = "Promoting"
+
+	// FailoverCompleted means that the target has been promoted.
+	FailoverCompleted FailoverPhase = "Completed"
+
+	// FailoverRollingBack means that a phase failed and the source is being
+	// promoted again.
+	FailoverRollingBack FailoverPhase = "RollingBack"
+
+	// FailoverRolledBack means that the source has been promoted again.
+	FailoverRolledBack FailoverPhase = "RolledBack"
+
+	// FailoverFailed means that the workflow failed and could not be rolled back.
+	FailoverFailed FailoverPhase = "Failed"
+)
+
+// VolumeReplicationFailoverSpec defines the desired state of VolumeReplicationFailover.
+type VolumeReplicationFailoverSpec struct {
+	// Action is the workflow to be performed, either "Relocate" or "Failover".
+	// +kubebuilder:default:=Relocate
+	Action FailoverAction `json:"action,omitempty"`
+
+	// Source is the name of the VolumeReplication that is currently primary.
+	// It is required for the "Relocate" action.
+	// +optional
+	Source string `json:"source,omitempty"`
+
+	// Target is the name of the VolumeReplication that is to be promoted.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Target string `json:"target"`
+
+	// TargetCluster is the cluster in which the target is resolved, either
+	// "Local" or "Peer". "Peer" requires the source.
+	// +kubebuilder:default:=Local
+	TargetCluster TargetCluster `json:"targetCluster,omitempty"`
+
+	// Timeout is the time in seconds each phase is allowed to take.
+	// +optional
+	// +kubebuilder:validation:Minimum=60
+	// +kubebuilder:default:=300
+	Timeout *int64 `json:"timeout,omitempty"`
+
+	// Rollback promotes the source again when a phase of the "Relocate"
+	// action fails.
+	// +kubebuilder:default:=true
+	Rollback bool `json:"rollback"`
+}
+
+// VolumeReplicationFailoverStatus defines the observed state of VolumeReplicationFailover.
+type VolumeReplicationFailoverStatus struct {
+	// Phase is the current phase of the workflow.
+	Phase FailoverPhase `json:"phase,omitempty"`
+
+	// Message contains the details of the current phase.
+	Message string `json:"message,omitempty"`
+
+	// StartTime is the time at which the workflow started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// PhaseStartTime is the time at which the current phase started.
+	PhaseStartTime *metav1.Time `json:"phaseStartTime,omitempty"`
+
+	// DemotionTime is the time at which the source was demoted.
+	DemotionTime *metav1.Time `json:"demotionTime,omitempty"`
+
+	// LastSyncTime is the last sync time reported for the source after
+	// it was demoted.
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// CompletionTime is the time at which the workflow completed.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name=Age,type=date
+// +kubebuilder:printcolumn:JSONPath=".spec.action",name=Action,type=string
+// +kubebuilder:printcolumn:JSONPath=".spec.source",name=Source,type=string
+// +kubebuilder:printcolumn:JSONPath=".spec.target",name=Target,type=string
+// +kubebuilder:printcolumn:JSONPath=".spec.targetCluster",name=TargetCluster,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.phase",name=Phase,type=string
+// +kubebuilder:resource:shortName=vrf
+
+// VolumeReplicationFailover is the Schema for the volumereplicationfailovers API.
+type VolumeReplicationFailover struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:Required
+	Spec VolumeReplicationFailoverSpec `json:"spec"`
+
+	Status VolumeReplicationFailoverStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VolumeReplicationFailoverList contains a list of VolumeReplicationFailover.
+type VolumeReplicationFailoverList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VolumeReplicationFailover `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VolumeReplicationFailover{}, &VolumeReplicationFailoverList{})
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2022 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var vrfLog = logf.Log.WithName("volumereplicationfailover-webhook")
+
+func (v *VolumeReplicationFailover) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(v).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-replication-storage-openshift-io-v1alpha1-volumereplicationfailover,mutating=false,failurePolicy=fail,sideEffects=None,groups=replication.storage.openshift.io,resources=volumereplicationfailovers,verbs=create;update,versions=v1alpha1,name=vvolumereplicationfailover.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &VolumeReplicationFailover{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (v *VolumeReplicationFailover) ValidateCreate() (admission.Warnings, error) {
+	vrfLog.Info("validate create", "name", v.Name)
+
+	allErrs := v.validateSpec()
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: "replication.storage.openshift.io", Kind: "VolumeReplicationFailover"},
+			v.Name, allErrs)
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (v *VolumeReplicationFailover) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	vrfLog.Info("validate update", "name", v.Name)
+
+	oldFailover, ok := old.(*VolumeReplicationFailover)
+	if !ok {
+		return nil, errors.New("error casting old VolumeReplicationFailover object")
+	}
+
+	var allErrs field.ErrorList
+
+	if !reflect.DeepEqual(oldFailover.Spec, v.Spec) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), "spec cannot be changed"))
+	}
+
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: "replication.storage.openshift.io", Kind: "VolumeReplicationFailover"},
+			v.Name, allErrs)
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (v *VolumeReplicationFailover) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateSpec checks that the source and target are usable for the action.
+func (v *VolumeReplicationFailover) validateSpec() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if v.Spec.Action != Failover && v.Spec.Source == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("source"), "source is required for the Relocate action"))
+	}
+
+	if v.Spec.TargetCluster == PeerCluster && v.Spec.Source == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("source"), "source is required to resolve the target in the peer cluster"))
+	}
+
+	// the target in the peer cluster usually has the same name as the
+	// source.
+	if v.Spec.TargetCluster != PeerCluster && v.Spec.Source != "" && v.Spec.Source == v.Spec.Target {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("target"), v.Spec.Target, "target cannot be the same as source"))
+	}
+
+	return allErrs
+}
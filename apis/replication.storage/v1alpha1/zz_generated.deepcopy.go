@@ -26,6 +26,61 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationSchedule) DeepCopyInto(out *ReplicationSchedule) {
+	*out = *in
+	out.Interval = in.Interval
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RetentionCount != nil {
+		in, out := &in.RetentionCount, &out.RetentionCount
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSchedule.
+func (in *ReplicationSchedule) DeepCopy() *ReplicationSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResyncProgress) DeepCopyInto(out *ResyncProgress) {
+	*out = *in
+	if in.PercentComplete != nil {
+		in, out := &in.PercentComplete, &out.PercentComplete
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BytesRemaining != nil {
+		in, out := &in.BytesRemaining, &out.BytesRemaining
+		*out = new(int64)
+		**out = **in
+	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	if in.EstimatedCompletionTime != nil {
+		in, out := &in.EstimatedCompletionTime, &out.EstimatedCompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResyncProgress.
+func (in *ResyncProgress) DeepCopy() *ResyncProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(ResyncProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeReplication) DeepCopyInto(out *VolumeReplication) {
 	*out = *in
@@ -59,7 +114,7 @@ func (in *VolumeReplicationClass) DeepCopyInto(out *VolumeReplicationClass) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeReplicationClass.
@@ -122,6 +177,11 @@ func (in *VolumeReplicationClassSpec) DeepCopyInto(out *VolumeReplicationClassSp
 			(*out)[key] = val
 		}
 	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(ReplicationSchedule)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeReplicationClassSpec.
@@ -137,6 +197,13 @@ func (in *VolumeReplicationClassSpec) DeepCopy() *VolumeReplicationClassSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeReplicationClassStatus) DeepCopyInto(out *VolumeReplicationClassStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeReplicationClassStatus.
@@ -149,6 +216,120 @@ func (in *VolumeReplicationClassStatus) DeepCopy() *VolumeReplicationClassStatus
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeReplicationFailover) DeepCopyInto(out *VolumeReplicationFailover) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeReplicationFailover.
+func (in *VolumeReplicationFailover) DeepCopy() *VolumeReplicationFailover {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeReplicationFailover)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeReplicationFailover) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeReplicationFailoverList) DeepCopyInto(out *VolumeReplicationFailoverList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VolumeReplicationFailover, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeReplicationFailoverList.
+func (in *VolumeReplicationFailoverList) DeepCopy() *VolumeReplicationFailoverList {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeReplicationFailoverList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeReplicationFailoverList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeReplicationFailoverSpec) DeepCopyInto(out *VolumeReplicationFailoverSpec) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeReplicationFailoverSpec.
+func (in *VolumeReplicationFailoverSpec) DeepCopy() *VolumeReplicationFailoverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeReplicationFailoverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeReplicationFailoverStatus) DeepCopyInto(out *VolumeReplicationFailoverStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PhaseStartTime != nil {
+		in, out := &in.PhaseStartTime, &out.PhaseStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.DemotionTime != nil {
+		in, out := &in.DemotionTime, &out.DemotionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeReplicationFailoverStatus.
+func (in *VolumeReplicationFailoverStatus) DeepCopy() *VolumeReplicationFailoverStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeReplicationFailoverStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeReplicationList) DeepCopyInto(out *VolumeReplicationList) {
 	*out = *in
@@ -181,6 +362,21 @@ func (in *VolumeReplicationList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeReplicationMemberStatus) DeepCopyInto(out *VolumeReplicationMemberStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeReplicationMemberStatus.
+func (in *VolumeReplicationMemberStatus) DeepCopy() *VolumeReplicationMemberStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeReplicationMemberStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeReplicationSpec) DeepCopyInto(out *VolumeReplicationSpec) {
 	*out = *in
@@ -229,6 +425,25 @@ func (in *VolumeReplicationStatus) DeepCopyInto(out *VolumeReplicationStatus) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.LastForcePromotionTime != nil {
+		in, out := &in.LastForcePromotionTime, &out.LastForcePromotionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ResyncProgress != nil {
+		in, out := &in.ResyncProgress, &out.ResyncProgress
+		*out = new(ResyncProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(ReplicationSchedule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]VolumeReplicationMemberStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeReplicationStatus.
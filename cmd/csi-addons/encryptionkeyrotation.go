@@ -0,0 +1,77 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	kube "github.com/csi-addons/kubernetes-csi-addons/internal/kubernetes"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/proto"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/sidecar/service"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// NodeEncryptionKeyRotate executes the NodeEncryptionKeyRotate operation.
+type NodeEncryptionKeyRotate struct {
+	// inherit Connect() and Close() from type grpcClient
+	grpcClient
+
+	persistentVolume string
+	stagingPath      string
+	podsPath         string
+}
+
+var _ = registerOperation("NodeEncryptionKeyRotate", &NodeEncryptionKeyRotate{})
+
+func (nkr *NodeEncryptionKeyRotate) Init(c *command) error {
+	nkr.persistentVolume = c.persistentVolume
+	if nkr.persistentVolume == "" {
+		return fmt.Errorf("persistentvolume name is not set")
+	}
+
+	if c.stagingPath == "" {
+		return fmt.Errorf("stagingpath is not set")
+	}
+	nkr.stagingPath = c.stagingPath
+
+	if c.podsPath == "" {
+		return fmt.Errorf("podspath is not set")
+	}
+	nkr.podsPath = c.podsPath
+
+	return nil
+}
+
+func (nkr *NodeEncryptionKeyRotate) Execute() error {
+	k := kube.NewCache(getKubernetesClient(), wait.NeverStop)
+
+	ks := service.NewEncryptionKeyRotationServer(nkr.Client, k, nkr.stagingPath, nkr.podsPath)
+
+	req := &proto.EncryptionKeyRotationRequest{
+		PvName: nkr.persistentVolume,
+	}
+
+	_, err := ks.NodeEncryptionKeyRotate(context.TODO(), req)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("rotated the encryption key of %q\n", nkr.persistentVolume)
+
+	return nil
+}
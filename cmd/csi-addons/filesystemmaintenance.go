@@ -0,0 +1,91 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	kube "github.com/csi-addons/kubernetes-csi-addons/internal/kubernetes"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/proto"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/sidecar/service"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// NodeFilesystemMaintenance executes the NodeFilesystemMaintenance operation.
+type NodeFilesystemMaintenance struct {
+	// inherit Connect() and Close() from type grpcClient
+	grpcClient
+
+	persistentVolume string
+	stagingPath      string
+	podsPath         string
+	operation        proto.FilesystemMaintenanceRequest_Operation
+}
+
+var _ = registerOperation("NodeFilesystemMaintenance", &NodeFilesystemMaintenance{})
+
+func (nfm *NodeFilesystemMaintenance) Init(c *command) error {
+	nfm.persistentVolume = c.persistentVolume
+	if nfm.persistentVolume == "" {
+		return fmt.Errorf("persistentvolume name is not set")
+	}
+
+	if c.stagingPath == "" {
+		return fmt.Errorf("stagingpath is not set")
+	}
+	nfm.stagingPath = c.stagingPath
+
+	if c.podsPath == "" {
+		return fmt.Errorf("podspath is not set")
+	}
+	nfm.podsPath = c.podsPath
+
+	op, ok := proto.FilesystemMaintenanceRequest_Operation_value[strings.ToUpper(c.maintenance)]
+	if !ok || op == int32(proto.FilesystemMaintenanceRequest_UNKNOWN) {
+		return fmt.Errorf("maintenance operation %q is not valid", c.maintenance)
+	}
+	nfm.operation = proto.FilesystemMaintenanceRequest_Operation(op)
+
+	return nil
+}
+
+func (nfm *NodeFilesystemMaintenance) Execute() error {
+	k := kube.NewCache(getKubernetesClient(), wait.NeverStop)
+
+	fs := service.NewFilesystemMaintenanceServer(nfm.Client, k, nfm.stagingPath, nfm.podsPath)
+
+	req := &proto.FilesystemMaintenanceRequest{
+		PvName:    nfm.persistentVolume,
+		Operation: nfm.operation,
+	}
+
+	res, err := fs.NodeFilesystemMaintenance(context.TODO(), req)
+	if err != nil {
+		return err
+	}
+
+	if res.GetErrorsFound() {
+		fmt.Printf("found errors in the filesystem of %q: %s\n", nfm.persistentVolume, res.GetMessage())
+	} else {
+		fmt.Printf("completed %s of %q: %s\n", nfm.operation, nfm.persistentVolume, res.GetMessage())
+	}
+
+	return nil
+}
@@ -32,17 +32,21 @@ import (
 const (
 	endpoint    = "unix:///tmp/csi-addons.sock"
 	stagingPath = "/var/lib/kubelet/plugins/kubernetes.io/csi/"
+	podsPath    = "/var/lib/kubelet/pods/"
 )
 
 // command contains the parsed arguments that were passed while running the
 // executable.
 type command struct {
 	endpoint         string
+	csiEndpoint      string
 	stagingPath      string
+	podsPath         string
 	operation        string
 	persistentVolume string
 	drivername       string
 	legacy           bool
+	maintenance      string
 }
 
 // cmd is the single instance of the command struct, used inside main().
@@ -52,11 +56,14 @@ func init() {
 	var showVersion bool
 
 	flag.StringVar(&cmd.endpoint, "endpoint", endpoint, "CSI-Addons endpoint")
+	flag.StringVar(&cmd.csiEndpoint, "csiendpoint", "", "CSI endpoint of the driver")
 	flag.StringVar(&cmd.stagingPath, "stagingpath", stagingPath, "staging path")
+	flag.StringVar(&cmd.podsPath, "podspath", podsPath, "path of the kubelet pods directory")
 	flag.StringVar(&cmd.operation, "operation", "", "csi-addons operation")
 	flag.StringVar(&cmd.persistentVolume, "persistentvolume", "", "name of the PersistentVolume")
 	flag.StringVar(&cmd.drivername, "drivername", "", "name of the CSI driver")
 	flag.BoolVar(&cmd.legacy, "legacy", false, "use legacy format for old Kubernetes versions")
+	flag.StringVar(&cmd.maintenance, "maintenance", "", "filesystem maintenance operation (check or defragment)")
 	flag.BoolVar(&showVersion, "version", false, "print Version details")
 
 	// output to show when --help is passed
@@ -21,8 +21,11 @@ import (
 	"crypto/sha256"
 	"fmt"
 
+	kube "github.com/csi-addons/kubernetes-csi-addons/internal/kubernetes"
 	"github.com/csi-addons/kubernetes-csi-addons/internal/proto"
 	"github.com/csi-addons/kubernetes-csi-addons/internal/sidecar/service"
+
+	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 // ControllerReclaimSpace executes the ControllerReclaimSpace operation.
@@ -46,9 +49,9 @@ func (crs *ControllerReclaimSpace) Init(c *command) error {
 }
 
 func (crs *ControllerReclaimSpace) Execute() error {
-	k := getKubernetesClient()
+	k := kube.NewCache(getKubernetesClient(), wait.NeverStop)
 
-	rss := service.NewReclaimSpaceServer(crs.Client, k, "" /* staging path not used */)
+	rss := service.NewReclaimSpaceServer(crs.Client, k, "" /* staging path not used */, "" /* pods path not used */)
 
 	req := &proto.ReclaimSpaceRequest{
 		PvName: crs.persistentVolume,
@@ -100,9 +103,9 @@ func (nrs *NodeReclaimSpace) Init(c *command) error {
 }
 
 func (nrs *NodeReclaimSpace) Execute() error {
-	k := getKubernetesClient()
+	k := kube.NewCache(getKubernetesClient(), wait.NeverStop)
 
-	rss := service.NewReclaimSpaceServer(nrs.Client, k, nrs.stagingTargetPath)
+	rss := service.NewReclaimSpaceServer(nrs.Client, k, nrs.stagingTargetPath, "" /* publish path is not searched */)
 
 	req := &proto.ReclaimSpaceRequest{
 		PvName: nrs.persistentVolume,
@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	kube "github.com/csi-addons/kubernetes-csi-addons/internal/kubernetes"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/proto"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/sidecar/service"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// NodeGetVolumeHealth executes the NodeGetVolumeHealth operation.
+type NodeGetVolumeHealth struct {
+	// inherit Connect() and Close() from type grpcClient
+	grpcClient
+
+	persistentVolume string
+	stagingPath      string
+	podsPath         string
+	csiEndpoint      string
+}
+
+var _ = registerOperation("NodeGetVolumeHealth", &NodeGetVolumeHealth{})
+
+func (nvh *NodeGetVolumeHealth) Init(c *command) error {
+	nvh.persistentVolume = c.persistentVolume
+	if nvh.persistentVolume == "" {
+		return fmt.Errorf("persistentvolume name is not set")
+	}
+
+	if c.stagingPath == "" {
+		return fmt.Errorf("stagingpath is not set")
+	}
+	nvh.stagingPath = c.stagingPath
+
+	if c.podsPath == "" {
+		return fmt.Errorf("podspath is not set")
+	}
+	nvh.podsPath = c.podsPath
+
+	// the condition of the volume is reported by the CSI Node service,
+	// on the CSI endpoint of the driver.
+	if c.csiEndpoint == "" {
+		return fmt.Errorf("csiendpoint is not set")
+	}
+	nvh.csiEndpoint = c.csiEndpoint
+
+	return nil
+}
+
+func (nvh *NodeGetVolumeHealth) Execute() error {
+	k := kube.NewCache(getKubernetesClient(), wait.NeverStop)
+
+	csiConn, err := grpc.Dial(nvh.csiEndpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed to connect to %q: %w", nvh.csiEndpoint, err)
+	}
+	defer csiConn.Close()
+
+	vhs := service.NewVolumeHealthServer(csiConn, k, nvh.stagingPath, nvh.podsPath)
+
+	req := &proto.VolumeHealthRequest{
+		PvName: nvh.persistentVolume,
+	}
+
+	res, err := vhs.NodeGetVolumeHealth(context.TODO(), req)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("health of %q: %+v\n", nvh.persistentVolume, res)
+
+	return nil
+}
@@ -35,11 +35,14 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	"go.uber.org/zap/zapcore"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -79,6 +82,9 @@ func main() {
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.DurationVar(&cfg.ReclaimSpaceTimeout, "reclaim-space-timeout", cfg.ReclaimSpaceTimeout, "Timeout for reclaimspace operation")
+	flag.DurationVar(&cfg.NetworkFenceTimeout, "network-fence-timeout", cfg.NetworkFenceTimeout, "Timeout for networkfence operation")
+	flag.DurationVar(&cfg.EncryptionKeyRotationTimeout, "encryption-key-rotation-timeout", cfg.EncryptionKeyRotationTimeout, "Timeout for encryptionkeyrotation operation")
+	flag.DurationVar(&cfg.FilesystemMaintenanceTimeout, "filesystem-maintenance-timeout", cfg.FilesystemMaintenanceTimeout, "Timeout for filesystem maintenance operation")
 	flag.IntVar(&cfg.MaxConcurrentReconciles, "max-concurrent-reconciles", cfg.MaxConcurrentReconciles, "Maximum number of concurrent reconciles")
 	flag.StringVar(&cfg.Namespace, "namespace", cfg.Namespace, "Namespace where the CSIAddons pod is deployed")
 	flag.BoolVar(&enableAdmissionWebhooks, "enable-admission-webhooks", true, "Enable the admission webhooks")
@@ -117,6 +123,14 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "e8cd140a.openshift.io",
+		// the controllers only watch the metadata of Secrets, Pods and
+		// StatefulSets, so that all of them in the cluster are not cached.
+		// The objects they refer to are read from the API server.
+		Client: client.Options{
+			Cache: &client.CacheOptions{
+				DisableFor: []client.Object{&corev1.Secret{}, &corev1.Pod{}, &appsv1.StatefulSet{}},
+			},
+		},
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -150,7 +164,10 @@ func main() {
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
 		Connpool: connPool,
-		Timeout:  time.Minute * 3,
+		Timeout:  cfg.NetworkFenceTimeout,
+		Recorder: mgr.GetEventRecorderFor("networkfence-controller"),
+
+		TrustRequesterAnnotation: enableAdmissionWebhooks,
 	}).SetupWithManager(mgr, ctrlOptions); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "NetworkFence")
 		os.Exit(1)
@@ -170,15 +187,75 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "PersistentVolumeClaim")
 		os.Exit(1)
 	}
+	if err = (&controllers.VolumeHealthReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		ConnPool: connPool,
+		Timeout:  defaultTimeout,
+		Interval: cfg.VolumeHealthInterval,
+		Recorder: mgr.GetEventRecorderFor("volumehealth-controller"),
+	}).SetupWithManager(mgr, ctrlOptions); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "VolumeHealth")
+		os.Exit(1)
+	}
+	if err = (&controllers.EncryptionKeyRotationJobReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		ConnPool: connPool,
+		Timeout:  cfg.EncryptionKeyRotationTimeout,
+	}).SetupWithManager(mgr, ctrlOptions); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "EncryptionKeyRotationJob")
+		os.Exit(1)
+	}
+	if err = (&controllers.EncryptionKeyRotationCronJobReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr, ctrlOptions); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "EncryptionKeyRotationCronJob")
+		os.Exit(1)
+	}
+	if err = (&controllers.FilesystemMaintenanceJobReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		ConnPool: connPool,
+		Timeout:  cfg.FilesystemMaintenanceTimeout,
+	}).SetupWithManager(mgr, ctrlOptions); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "FilesystemMaintenanceJob")
+		os.Exit(1)
+	}
 	if err = (&replicationController.VolumeReplicationReconciler{
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
 		Connpool: connPool,
 		Timeout:  defaultTimeout,
+		Recorder: mgr.GetEventRecorderFor("volumereplication-controller"),
+		RequeueIntervals: replicationController.RequeueIntervals{
+			Demote: cfg.ReplicationDemoteRequeueInterval,
+			Resync: cfg.ReplicationResyncRequeueInterval,
+			Info:   cfg.ReplicationInfoRequeueInterval,
+			Max:    cfg.ReplicationMaxRequeueInterval,
+		},
 	}).SetupWithManager(mgr, ctrlOptions); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "VolumeReplication")
 		os.Exit(1)
 	}
+	if err = (&replicationController.VolumeReplicationClassReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Connpool: connPool,
+	}).SetupWithManager(mgr, ctrlOptions); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "VolumeReplicationClass")
+		os.Exit(1)
+	}
+	if err = (&replicationController.VolumeReplicationFailoverReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Connpool: connPool,
+		Timeout:  defaultTimeout,
+	}).SetupWithManager(mgr, ctrlOptions); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "VolumeReplicationFailover")
+		os.Exit(1)
+	}
 
 	if enableAdmissionWebhooks {
 		if err = (&replicationstoragev1alpha1.VolumeReplicationClass{}).SetupWebhookWithManager(mgr); err != nil {
@@ -191,6 +268,11 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err = (&replicationstoragev1alpha1.VolumeReplicationFailover{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "VolumeReplicationFailover")
+			os.Exit(1)
+		}
+
 		if err = (&csiaddonsv1alpha1.ReclaimSpaceJob{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "ReclaimSpaceJob")
 			os.Exit(1)
@@ -201,6 +283,21 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err = (&csiaddonsv1alpha1.EncryptionKeyRotationJob{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "EncryptionKeyRotationJob")
+			os.Exit(1)
+		}
+
+		if err = (&csiaddonsv1alpha1.EncryptionKeyRotationCronJob{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "EncryptionKeyRotationCronJob")
+			os.Exit(1)
+		}
+
+		if err = (&csiaddonsv1alpha1.FilesystemMaintenanceJob{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "FilesystemMaintenanceJob")
+			os.Exit(1)
+		}
+
 		if err = (&csiaddonsv1alpha1.NetworkFence{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "NetworkFence")
 			os.Exit(1)
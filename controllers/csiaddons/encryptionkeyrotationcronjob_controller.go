@@ -0,0 +1,322 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	csiaddonsv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/csiaddons/v1alpha1"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ref "k8s.io/client-go/tools/reference"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// EncryptionKeyRotationCronJobReconciler reconciles a EncryptionKeyRotationCronJob object
+type EncryptionKeyRotationCronJobReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=csiaddons.openshift.io,resources=encryptionkeyrotationcronjobs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=csiaddons.openshift.io,resources=encryptionkeyrotationcronjobs/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=csiaddons.openshift.io,resources=encryptionkeyrotationcronjobs/finalizers,verbs=update
+//+kubebuilder:rbac:groups=csiaddons.openshift.io,resources=encryptionkeyrotationjobs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=csiaddons.openshift.io,resources=encryptionkeyrotationjobs/status,verbs=get
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+// Reconcile will be called when there is any event related to
+// encryptionKeyRotationCronJob and also when there is an event related to
+// child encryptionKeyRotationJobs due to controllerOwnerRef.
+func (r *EncryptionKeyRotationCronJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Fetch EncryptionKeyRotationCronJob instance
+	krCronJob := &csiaddonsv1alpha1.EncryptionKeyRotationCronJob{}
+	err := r.Client.Get(ctx, req.NamespacedName, krCronJob)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			logger.Info("EncryptionKeyRotationCronJob resource not found")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// set history limit defaults, if not specified.
+	failedJobsHistoryLimit := defaultFailedJobsHistoryLimit
+	if krCronJob.Spec.FailedJobsHistoryLimit != nil {
+		failedJobsHistoryLimit = *krCronJob.Spec.FailedJobsHistoryLimit
+	}
+	successfulJobsHistoryLimit := defaultSuccessfulJobsHistoryLimit
+	if krCronJob.Spec.SuccessfulJobsHistoryLimit != nil {
+		successfulJobsHistoryLimit = *krCronJob.Spec.SuccessfulJobsHistoryLimit
+	}
+
+	var childJobs csiaddonsv1alpha1.EncryptionKeyRotationJobList
+	err = r.List(ctx, &childJobs, client.InNamespace(req.Namespace), client.MatchingFields{jobOwnerKey: req.Name})
+	if err != nil {
+		logger.Error(err, "Failed to list child EncryptionKeyRotationJobs")
+		return ctrl.Result{}, err
+	}
+
+	// find the active,failed and successful list of jobs and mostRecent,lastSuccessful time.
+	childJobsInfo := parseKRJobList(&logger, &childJobs)
+
+	if childJobsInfo.mostRecentTime != nil {
+		krCronJob.Status.LastScheduleTime = &metav1.Time{Time: *childJobsInfo.mostRecentTime}
+	} else {
+		krCronJob.Status.LastScheduleTime = nil
+	}
+	if childJobsInfo.lastSuccessfulTime != nil {
+		krCronJob.Status.LastSuccessfulTime = &metav1.Time{Time: *childJobsInfo.lastSuccessfulTime}
+	}
+	krCronJob.Status.Active = nil
+	if childJobsInfo.activeJob != nil {
+		jobRef, err := ref.GetReference(r.Scheme, childJobsInfo.activeJob)
+		if err != nil {
+			logger.Error(err, "Failed to make reference to active job", "job", childJobsInfo.activeJob)
+		}
+		krCronJob.Status.Active = jobRef
+	}
+	if err := r.Status().Update(ctx, krCronJob); err != nil {
+		logger.Error(err, "Failed to update status")
+		return ctrl.Result{}, err
+	}
+
+	// delete jobs older than history limit.
+	r.deleteOldJobs(ctx, &logger, childJobsInfo.successfulJobs, successfulJobsHistoryLimit)
+	r.deleteOldJobs(ctx, &logger, childJobsInfo.failedJobs, failedJobsHistoryLimit)
+
+	if krCronJob.Spec.Suspend != nil && *krCronJob.Spec.Suspend {
+		logger.Info("EncryptionKeyRotationCronJob suspended, skipping scheduling job")
+		return ctrl.Result{}, nil
+	}
+
+	// figure out the next times that we need to create jobs at (or anything we missed).
+	missedRun, nextRun, err := getNextSchedule(
+		krCronJob.Spec.Schedule,
+		krCronJob.Spec.StartingDeadlineSeconds,
+		krCronJob.Status.LastScheduleTime,
+		krCronJob.CreationTimestamp,
+		time.Now())
+	if err != nil {
+		logger.Error(err, "Failed to Parse out CronJob schedule", "schedule", krCronJob.Spec.Schedule)
+		// invalid schedule, do not requeue.
+		return ctrl.Result{}, nil
+	}
+
+	scheduledResult := ctrl.Result{RequeueAfter: time.Until(nextRun)}
+	logger = logger.WithValues("now", time.Now(), "nextRun", nextRun)
+
+	// If we've missed a run, and we're still within the deadline to start it, we'll need to run a job.
+	if missedRun.IsZero() {
+		logger.Info("No upcoming scheduled times, requeue with delay till next run")
+		return scheduledResult, nil
+	}
+
+	// make sure we're not too late to start the run
+	logger = logger.WithValues("currentRun", missedRun)
+	tooLate := false
+	if krCronJob.Spec.StartingDeadlineSeconds != nil {
+		tooLate = missedRun.Add(time.Duration(*krCronJob.Spec.StartingDeadlineSeconds) * time.Second).Before(time.Now())
+	}
+	if tooLate {
+		logger.Info("Missed starting deadline for last run, requeue with delay till next run")
+		return scheduledResult, nil
+	}
+
+	// replace existing ones, if replace concurrent policy is set.
+	if krCronJob.Spec.ConcurrencyPolicy == csiaddonsv1alpha1.ReplaceConcurrent && childJobsInfo.activeJob != nil {
+		err = r.Delete(ctx, childJobsInfo.activeJob, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if client.IgnoreNotFound(err) != nil {
+			logger.Error(err, "Failed to delete active encryptionKeyRotationJob",
+				"encryptionKeyRotationJob", childJobsInfo.activeJob.Name)
+			return ctrl.Result{}, err
+		}
+		childJobsInfo.activeJob = nil
+	}
+
+	// default is to forbid concurrent execution
+	if childJobsInfo.activeJob != nil {
+		logger.Info("Concurrency policy blocks concurrent runs, skipping", "activeJob", childJobsInfo.activeJob.Name)
+		return scheduledResult, nil
+	}
+
+	krJob, err := r.constructKRJobForCronJob(krCronJob, missedRun)
+	if err != nil {
+		logger.Error(err, "Failed to construct job from template")
+		// Do not requeue until spec is changed or till next scheduled time.
+		return scheduledResult, nil
+	}
+	if err := r.Create(ctx, krJob); err != nil {
+		logger.Error(err, "Failed to create encryptionKeyRotationJob for encryptionKeyRotationCronJob")
+		return ctrl.Result{}, err
+	}
+	logger.Info("Successfully created encryptionKeyRotationJob for encryptionKeyRotationCronJob run",
+		"encryptionKeyRotationJob", krJob.Name)
+
+	// Reconcile will be triggered if job starts or finishes, cronjob is modified, etc.
+	// Requeue till next run.
+	return scheduledResult, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *EncryptionKeyRotationCronJobReconciler) SetupWithManager(mgr ctrl.Manager, ctrlOptions controller.Options) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &csiaddonsv1alpha1.EncryptionKeyRotationJob{}, jobOwnerKey, func(rawObj client.Object) []string {
+		// extract the owner from job object.
+		job, ok := rawObj.(*csiaddonsv1alpha1.EncryptionKeyRotationJob)
+		if !ok {
+			return nil
+		}
+		owner := metav1.GetControllerOf(job)
+		if owner == nil {
+			return nil
+		}
+		if owner.APIVersion != apiGVStr || owner.Kind != "EncryptionKeyRotationCronJob" {
+			return nil
+		}
+
+		return []string{owner.Name}
+	}); err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&csiaddonsv1alpha1.EncryptionKeyRotationCronJob{}).
+		Owns(&csiaddonsv1alpha1.EncryptionKeyRotationJob{}).
+		WithOptions(ctrlOptions).
+		Complete(r)
+}
+
+// constructKRJobForCronJob constructs encryptionkeyrotationjob.
+func (r *EncryptionKeyRotationCronJobReconciler) constructKRJobForCronJob(
+	krCronJob *csiaddonsv1alpha1.EncryptionKeyRotationCronJob,
+	scheduledTime time.Time) (*csiaddonsv1alpha1.EncryptionKeyRotationJob, error) {
+	// We want job names for a given nominal start time to have a deterministic name to avoid the same job being created twice
+	name := fmt.Sprintf("%s-%d", krCronJob.Name, scheduledTime.Unix())
+
+	job := &csiaddonsv1alpha1.EncryptionKeyRotationJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      make(map[string]string),
+			Annotations: make(map[string]string),
+			Name:        name,
+			Namespace:   krCronJob.Namespace,
+		},
+		Spec: *krCronJob.Spec.JobSpec.Spec.DeepCopy(),
+	}
+	for k, v := range krCronJob.Spec.JobSpec.Annotations {
+		job.Annotations[k] = v
+	}
+	job.Annotations[scheduledTimeAnnotation] = scheduledTime.Format(time.RFC3339)
+	for k, v := range krCronJob.Spec.JobSpec.Labels {
+		job.Labels[k] = v
+	}
+	if err := ctrl.SetControllerReference(krCronJob, job, r.Scheme); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// deleteOldJobs sorts given jobList by StartTime and deletes jobs older than given historyLimit.
+// Errors if any are only logged.
+func (r *EncryptionKeyRotationCronJobReconciler) deleteOldJobs(
+	ctx context.Context,
+	logger *logr.Logger,
+	jobsList []*csiaddonsv1alpha1.EncryptionKeyRotationJob,
+	historyLimit int32) {
+
+	sort.Slice(jobsList, func(i, j int) bool {
+		if jobsList[i].Status.StartTime == nil {
+			return jobsList[j].Status.StartTime != nil
+		}
+		return jobsList[i].Status.StartTime.Before(jobsList[j].Status.StartTime)
+	})
+
+	for i, job := range jobsList {
+		if int32(i) >= int32(len(jobsList))-historyLimit {
+			break
+		}
+		err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if client.IgnoreNotFound(err) != nil {
+			logger.Error(err, "Failed to delete old job",
+				"encryptionKeyRotationJobName", job.Name,
+				"state", job.Status.Result)
+		} else {
+			logger.Info("Successfully deleted old job",
+				"encryptionKeyRotationJobName", job.Name,
+				"state", job.Status.Result)
+		}
+	}
+}
+
+// krChildJobsInfo contains active, successful and failed
+// job details with mostRecentTime and lastSuccessfulTime.
+type krChildJobsInfo struct {
+	activeJob          *csiaddonsv1alpha1.EncryptionKeyRotationJob
+	successfulJobs     []*csiaddonsv1alpha1.EncryptionKeyRotationJob
+	failedJobs         []*csiaddonsv1alpha1.EncryptionKeyRotationJob
+	mostRecentTime     *time.Time
+	lastSuccessfulTime *time.Time
+}
+
+// parseKRJobList parses jobList and returns krChildJobsInfo struct with required details.
+func parseKRJobList(logger *logr.Logger, childJobs *csiaddonsv1alpha1.EncryptionKeyRotationJobList) krChildJobsInfo {
+	info := krChildJobsInfo{}
+
+	for i := range childJobs.Items {
+		job := &childJobs.Items[i]
+		switch job.Status.Result {
+		case "": // ongoing
+			info.activeJob = job
+		case csiaddonsv1alpha1.OperationResultFailed:
+			info.failedJobs = append(info.failedJobs, job)
+		case csiaddonsv1alpha1.OperationResultSucceeded:
+			info.successfulJobs = append(info.successfulJobs, job)
+			completionTime := job.Status.CompletionTime
+			if completionTime != nil &&
+				(info.lastSuccessfulTime == nil || info.lastSuccessfulTime.Before(completionTime.Time)) {
+				info.lastSuccessfulTime = &completionTime.Time
+			}
+		}
+
+		// reconstitute scheduled time from annotation.
+		scheduledTimeForJob, err := getScheduledTimeForJob(job)
+		if err != nil {
+			logger.Error(err, "Failed to parse schedule time for child EncryptionKeyRotationJob",
+				"EncryptionKeyRotationJob", job.Name)
+			continue
+		}
+		if scheduledTimeForJob != nil &&
+			(info.mostRecentTime == nil || info.mostRecentTime.Before(*scheduledTimeForJob)) {
+			info.mostRecentTime = scheduledTimeForJob
+		}
+	}
+
+	return info
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	csiaddonsv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/csiaddons/v1alpha1"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestParseKRJobList(t *testing.T) {
+	t.Parallel()
+	logger := logr.Discard()
+	earlier := time.Now().Add(-time.Hour).Truncate(time.Second)
+	later := time.Now().Truncate(time.Second)
+
+	newJob := func(name string, result csiaddonsv1alpha1.OperationResult, scheduled time.Time) csiaddonsv1alpha1.EncryptionKeyRotationJob {
+		job := csiaddonsv1alpha1.EncryptionKeyRotationJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Annotations: map[string]string{scheduledTimeAnnotation: scheduled.Format(time.RFC3339)},
+			},
+		}
+		job.Status.Result = result
+		if result != "" {
+			job.Status.CompletionTime = &metav1.Time{Time: scheduled}
+		}
+
+		return job
+	}
+
+	info := parseKRJobList(&logger, &csiaddonsv1alpha1.EncryptionKeyRotationJobList{
+		Items: []csiaddonsv1alpha1.EncryptionKeyRotationJob{
+			newJob("succeeded-1", csiaddonsv1alpha1.OperationResultSucceeded, earlier),
+			newJob("failed", csiaddonsv1alpha1.OperationResultFailed, earlier),
+			newJob("active", "", later),
+		},
+	})
+
+	require.NotNil(t, info.activeJob)
+	assert.Equal(t, "active", info.activeJob.Name)
+	assert.Len(t, info.successfulJobs, 1)
+	assert.Len(t, info.failedJobs, 1)
+	require.NotNil(t, info.mostRecentTime)
+	assert.True(t, later.Equal(*info.mostRecentTime))
+	require.NotNil(t, info.lastSuccessfulTime)
+	assert.True(t, earlier.Equal(*info.lastSuccessfulTime))
+}
+
+func TestConstructKRJobForCronJob(t *testing.T) {
+	t.Parallel()
+	scheme := runtime.NewScheme()
+	require.NoError(t, csiaddonsv1alpha1.AddToScheme(scheme))
+	r := &EncryptionKeyRotationCronJobReconciler{Scheme: scheme}
+
+	krCronJob := &csiaddonsv1alpha1.EncryptionKeyRotationCronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "cronjob", Namespace: "default", UID: "uid"},
+		Spec: csiaddonsv1alpha1.EncryptionKeyRotationCronJobSpec{
+			JobSpec: csiaddonsv1alpha1.EncryptionKeyRotationJobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test"}},
+				Spec: csiaddonsv1alpha1.EncryptionKeyRotationJobSpec{
+					Target: csiaddonsv1alpha1.TargetSpec{PersistentVolumeClaim: "pvc-1"},
+				},
+			},
+		},
+	}
+	scheduledTime := time.Unix(1700000000, 0)
+
+	job, err := r.constructKRJobForCronJob(krCronJob, scheduledTime)
+	require.NoError(t, err)
+	assert.Equal(t, "cronjob-1700000000", job.Name)
+	assert.Equal(t, "default", job.Namespace)
+	assert.Equal(t, "pvc-1", job.Spec.Target.PersistentVolumeClaim)
+	assert.Equal(t, "test", job.Labels["app"])
+	assert.Equal(t, scheduledTime.Format(time.RFC3339), job.Annotations[scheduledTimeAnnotation])
+	owner := metav1.GetControllerOf(job)
+	require.NotNil(t, owner)
+	assert.Equal(t, "EncryptionKeyRotationCronJob", owner.Kind)
+}
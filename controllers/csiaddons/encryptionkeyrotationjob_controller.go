@@ -0,0 +1,148 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	csiaddonsv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/csiaddons/v1alpha1"
+	extensions "github.com/csi-addons/kubernetes-csi-addons/extensions/v1alpha1"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/connection"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/proto"
+
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// EncryptionKeyRotationJobReconciler reconciles a EncryptionKeyRotationJob object.
+type EncryptionKeyRotationJobReconciler struct {
+	client.Client
+	// Scheme defines methods for serializing and deserializing API objects.
+	Scheme *runtime.Scheme
+	// ConnectionPool consists of map of Connection objects.
+	ConnPool *connection.ConnectionPool
+	// Timeout for the Reconcile operation.
+	Timeout time.Duration
+}
+
+//+kubebuilder:rbac:groups=csiaddons.openshift.io,resources=encryptionkeyrotationjobs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=csiaddons.openshift.io,resources=encryptionkeyrotationjobs/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=csiaddons.openshift.io,resources=encryptionkeyrotationjobs/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=persistentvolumes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=storage.k8s.io,resources=volumeattachments,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *EncryptionKeyRotationJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Fetch EncryptionKeyRotationJob instance.
+	krJob := &csiaddonsv1alpha1.EncryptionKeyRotationJob{}
+	err := r.Client.Get(ctx, req.NamespacedName, krJob)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			logger.Info("EncryptionKeyRotationJob resource not found")
+
+			return ctrl.Result{}, nil
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	return r.nodeJobRunner().reconcile(ctx, &logger, newEncryptionKeyRotationNodeJob(krJob),
+		validateEncryptionKeyRotationJobSpec(krJob), nodeEncryptionKeyRotate)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *EncryptionKeyRotationJobReconciler) SetupWithManager(mgr ctrl.Manager, ctrlOptions controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&csiaddonsv1alpha1.EncryptionKeyRotationJob{}).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		WithOptions(ctrlOptions).
+		Complete(r)
+}
+
+// nodeJobRunner returns the runner of the EncryptionKeyRotationJobs.
+func (r *EncryptionKeyRotationJobReconciler) nodeJobRunner() *nodeJobRunner {
+	return &nodeJobRunner{
+		client:      r.Client,
+		connPool:    r.ConnPool,
+		timeout:     r.Timeout,
+		serviceType: extensions.Capability_Service_ENCRYPTION_KEY_ROTATION,
+	}
+}
+
+// newEncryptionKeyRotationNodeJob returns the nodeJob of the
+// EncryptionKeyRotationJob.
+func newEncryptionKeyRotationNodeJob(krJob *csiaddonsv1alpha1.EncryptionKeyRotationJob) *nodeJob {
+	return &nodeJob{
+		object:               krJob,
+		kind:                 "EncryptionKeyRotationJob",
+		pvcName:              krJob.Spec.Target.PersistentVolumeClaim,
+		backoffLimit:         &krJob.Spec.BackoffLimit,
+		retryDeadlineSeconds: &krJob.Spec.RetryDeadlineSeconds,
+		timeout:              krJob.Spec.Timeout,
+		result:               &krJob.Status.Result,
+		message:              &krJob.Status.Message,
+		conditions:           &krJob.Status.Conditions,
+		retries:              &krJob.Status.Retries,
+		startTime:            &krJob.Status.StartTime,
+		completionTime:       &krJob.Status.CompletionTime,
+	}
+}
+
+// nodeEncryptionKeyRotate makes the node encryption key rotation request.
+func nodeEncryptionKeyRotate(
+	ctx context.Context,
+	logger *logr.Logger,
+	conn grpc.ClientConnInterface,
+	target *targetDetails) (csiaddonsv1alpha1.OperationResult, string, error) {
+	logger.Info("Making node encryption key rotation request")
+	req := &proto.EncryptionKeyRotationRequest{
+		PvName: target.pvName,
+	}
+	newCtx, cancel := context.WithTimeout(ctx, target.timeout)
+	defer cancel()
+	_, err := proto.NewEncryptionKeyRotationClient(conn).NodeEncryptionKeyRotate(newCtx, req)
+	if err != nil {
+		return "", "", err
+	}
+	logger.Info("Successfully completed encryption key rotation operation")
+
+	return csiaddonsv1alpha1.OperationResultSucceeded, "Encryption key rotation operation successfully completed.", nil
+}
+
+// validateEncryptionKeyRotationJobSpec validates EncryptionKeyRotationJob.Spec.
+func validateEncryptionKeyRotationJobSpec(
+	krJob *csiaddonsv1alpha1.EncryptionKeyRotationJob) error {
+	if krJob.Spec.Target.PersistentVolumeClaim == "" {
+		return errors.New("required parameter 'PersistentVolumeClaim' in EncryptionKeyRotationJob.Spec.Target is empty")
+	}
+
+	return nil
+}
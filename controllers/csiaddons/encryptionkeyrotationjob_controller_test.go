@@ -0,0 +1,200 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	csiaddonsv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/csiaddons/v1alpha1"
+	extensions "github.com/csi-addons/kubernetes-csi-addons/extensions/v1alpha1"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	scv1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeEncryptionKeyRotationServer is a sidecar that fails the rotation of
+// the volumes in failures, other volumes are rotated.
+type fakeEncryptionKeyRotationServer struct {
+	proto.UnimplementedEncryptionKeyRotationServer
+	failures map[string]error
+}
+
+func (f *fakeEncryptionKeyRotationServer) NodeEncryptionKeyRotate(
+	_ context.Context,
+	req *proto.EncryptionKeyRotationRequest) (*proto.EncryptionKeyRotationResponse, error) {
+	if err, ok := f.failures[req.GetPvName()]; ok {
+		return nil, err
+	}
+
+	return &proto.EncryptionKeyRotationResponse{}, nil
+}
+
+func TestValidateEncryptionKeyRotationJobSpec(t *testing.T) {
+	t.Parallel()
+	krJob := &csiaddonsv1alpha1.EncryptionKeyRotationJob{}
+	assert.Error(t, validateEncryptionKeyRotationJobSpec(krJob))
+
+	krJob.Spec.Target.PersistentVolumeClaim = "pvc-1"
+	assert.NoError(t, validateEncryptionKeyRotationJobSpec(krJob))
+}
+
+func TestEncryptionKeyRotationJobReconcile(t *testing.T) {
+	t.Parallel()
+	driver := "test.csi.io"
+	nodeID := "node-1"
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, csiaddonsv1alpha1.AddToScheme(scheme))
+
+	newObjects := func(name string) (*corev1.PersistentVolumeClaim, *corev1.PersistentVolume, *scv1.VolumeAttachment) {
+		pvName := "pv-" + name
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: pvName},
+			Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+		}
+		pv := &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: pvName},
+			Spec: corev1.PersistentVolumeSpec{
+				PersistentVolumeSource: corev1.PersistentVolumeSource{
+					CSI: &corev1.CSIPersistentVolumeSource{Driver: driver, VolumeHandle: "handle-" + name},
+				},
+			},
+		}
+		va := &scv1.VolumeAttachment{
+			ObjectMeta: metav1.ObjectMeta{Name: "va-" + name},
+			Spec: scv1.VolumeAttachmentSpec{
+				Attacher: driver,
+				NodeName: nodeID,
+				Source:   scv1.VolumeAttachmentSource{PersistentVolumeName: &pvName},
+			},
+			Status: scv1.VolumeAttachmentStatus{Attached: true},
+		}
+
+		return pvc, pv, va
+	}
+
+	tests := []struct {
+		name        string
+		pvcName     string
+		attached    bool
+		retries     int32
+		wantResult  csiaddonsv1alpha1.OperationResult
+		wantErr     bool
+		wantRetries int32
+	}{
+		{
+			name:       "key rotated",
+			pvcName:    "rotated",
+			attached:   true,
+			wantResult: csiaddonsv1alpha1.OperationResultSucceeded,
+		},
+		{
+			name:     "rotation failed",
+			pvcName:  "failed",
+			attached: true,
+			wantErr:  true,
+		},
+		{
+			name:     "volume not attached",
+			pvcName:  "rotated",
+			attached: false,
+			wantErr:  true,
+		},
+		{
+			name:        "maximum retry limit reached",
+			pvcName:     "failed",
+			attached:    true,
+			retries:     defaultBackoffLimit - 1,
+			wantResult:  csiaddonsv1alpha1.OperationResultFailed,
+			wantRetries: defaultBackoffLimit,
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			pvc, pv, va := newObjects(newtt.pvcName)
+			va.Status.Attached = newtt.attached
+			krJob := &csiaddonsv1alpha1.EncryptionKeyRotationJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "job",
+					Namespace:         "default",
+					CreationTimestamp: metav1.Now(),
+				},
+				Spec: csiaddonsv1alpha1.EncryptionKeyRotationJobSpec{
+					Target: csiaddonsv1alpha1.TargetSpec{PersistentVolumeClaim: pvc.Name},
+				},
+			}
+			if newtt.retries != 0 {
+				krJob.Status.StartTime = &metav1.Time{Time: time.Now()}
+				krJob.Status.Retries = newtt.retries
+			}
+
+			r := &EncryptionKeyRotationJobReconciler{
+				Client: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(krJob, pvc, pv, va).
+					WithStatusSubresource(krJob).
+					Build(),
+				ConnPool: newFakeSidecarConnPool(t, driver, nodeID, extensions.Capability_Service_ENCRYPTION_KEY_ROTATION, func(s *grpc.Server) {
+					proto.RegisterEncryptionKeyRotationServer(s, &fakeEncryptionKeyRotationServer{
+						failures: map[string]error{
+							"pv-failed": status.Error(codes.Internal, "failed to rotate the key"),
+						},
+					})
+				}),
+				Timeout: time.Minute,
+			}
+
+			key := types.NamespacedName{Name: krJob.Name, Namespace: krJob.Namespace}
+			_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+			if newtt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			updated := &csiaddonsv1alpha1.EncryptionKeyRotationJob{}
+			require.NoError(t, r.Client.Get(context.Background(), key, updated))
+			assert.Equal(t, newtt.wantResult, updated.Status.Result)
+			assert.Equal(t, newtt.wantRetries, updated.Status.Retries)
+			assert.NotNil(t, updated.Status.StartTime)
+			if newtt.wantResult == csiaddonsv1alpha1.OperationResultSucceeded {
+				assert.Empty(t, updated.Status.Conditions)
+			} else {
+				require.Len(t, updated.Status.Conditions, 1)
+				assert.Equal(t, conditionFailed, updated.Status.Conditions[0].Type)
+			}
+		})
+	}
+}
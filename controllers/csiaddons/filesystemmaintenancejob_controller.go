@@ -0,0 +1,199 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	csiaddonsv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/csiaddons/v1alpha1"
+	extensions "github.com/csi-addons/kubernetes-csi-addons/extensions/v1alpha1"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/connection"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/proto"
+
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// FilesystemMaintenanceJobReconciler reconciles a FilesystemMaintenanceJob object.
+type FilesystemMaintenanceJobReconciler struct {
+	client.Client
+	// Scheme defines methods for serializing and deserializing API objects.
+	Scheme *runtime.Scheme
+	// ConnectionPool consists of map of Connection objects.
+	ConnPool *connection.ConnectionPool
+	// Timeout for the Reconcile operation.
+	Timeout time.Duration
+}
+
+//+kubebuilder:rbac:groups=csiaddons.openshift.io,resources=filesystemmaintenancejobs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=csiaddons.openshift.io,resources=filesystemmaintenancejobs/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=csiaddons.openshift.io,resources=filesystemmaintenancejobs/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=persistentvolumes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=storage.k8s.io,resources=volumeattachments,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *FilesystemMaintenanceJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Fetch FilesystemMaintenanceJob instance.
+	fmJob := &csiaddonsv1alpha1.FilesystemMaintenanceJob{}
+	err := r.Client.Get(ctx, req.NamespacedName, fmJob)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			logger.Info("FilesystemMaintenanceJob resource not found")
+
+			return ctrl.Result{}, nil
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	logger = logger.WithValues("Operation", fmJob.Spec.Operation)
+
+	return r.nodeJobRunner().reconcile(ctx, &logger, newFilesystemMaintenanceNodeJob(fmJob),
+		validateFilesystemMaintenanceJobSpec(fmJob), nodeFilesystemMaintenance(fmJob.Spec.Operation))
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *FilesystemMaintenanceJobReconciler) SetupWithManager(mgr ctrl.Manager, ctrlOptions controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&csiaddonsv1alpha1.FilesystemMaintenanceJob{}).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		WithOptions(ctrlOptions).
+		Complete(r)
+}
+
+// nodeJobRunner returns the runner of the FilesystemMaintenanceJobs.
+func (r *FilesystemMaintenanceJobReconciler) nodeJobRunner() *nodeJobRunner {
+	return &nodeJobRunner{
+		client:      r.Client,
+		connPool:    r.ConnPool,
+		timeout:     r.Timeout,
+		serviceType: extensions.Capability_Service_FILESYSTEM_MAINTENANCE,
+	}
+}
+
+// newFilesystemMaintenanceNodeJob returns the nodeJob of the
+// FilesystemMaintenanceJob.
+func newFilesystemMaintenanceNodeJob(fmJob *csiaddonsv1alpha1.FilesystemMaintenanceJob) *nodeJob {
+	return &nodeJob{
+		object:               fmJob,
+		kind:                 "FilesystemMaintenanceJob",
+		pvcName:              fmJob.Spec.Target.PersistentVolumeClaim,
+		backoffLimit:         &fmJob.Spec.BackoffLimit,
+		retryDeadlineSeconds: &fmJob.Spec.RetryDeadlineSeconds,
+		timeout:              fmJob.Spec.Timeout,
+		result:               &fmJob.Status.Result,
+		message:              &fmJob.Status.Message,
+		conditions:           &fmJob.Status.Conditions,
+		retries:              &fmJob.Status.Retries,
+		startTime:            &fmJob.Status.StartTime,
+		completionTime:       &fmJob.Status.CompletionTime,
+	}
+}
+
+// nodeFilesystemMaintenance returns the operation that makes the node
+// filesystem maintenance request.
+func nodeFilesystemMaintenance(
+	operation csiaddonsv1alpha1.FilesystemMaintenanceOperation) nodeJobOperation {
+	return func(
+		ctx context.Context,
+		logger *logr.Logger,
+		conn grpc.ClientConnInterface,
+		target *targetDetails) (csiaddonsv1alpha1.OperationResult, string, error) {
+		logger.Info("Making node filesystem maintenance request")
+		req := &proto.FilesystemMaintenanceRequest{
+			PvName:    target.pvName,
+			Operation: getFilesystemMaintenanceOperation(operation),
+		}
+		newCtx, cancel := context.WithTimeout(ctx, target.timeout)
+		defer cancel()
+		resp, err := proto.NewFilesystemMaintenanceClient(conn).NodeFilesystemMaintenance(newCtx, req)
+		if err != nil {
+			// Unimplemented suggests that the driver does not support
+			// the operation, retrying does not help.
+			if status.Code(err) == codes.Unimplemented {
+				logger.Info(fmt.Sprintf("NodeFilesystemMaintenance is not implemented by driver: %v", err))
+
+				return csiaddonsv1alpha1.OperationResultFailed,
+					fmt.Sprintf("%s operation is not supported by the driver", operation), nil
+			}
+
+			return "", "", err
+		}
+
+		// errors in the filesystem are the result of a check, they are
+		// reported to the user and not retried.
+		if resp.GetErrorsFound() {
+			logger.Info("Filesystem maintenance operation found errors", "Message", resp.GetMessage())
+
+			return csiaddonsv1alpha1.OperationResultFailed,
+				fmt.Sprintf("Errors found in the filesystem: %s", resp.GetMessage()), nil
+		}
+		logger.Info("Successfully completed filesystem maintenance operation")
+
+		message := fmt.Sprintf("%s operation successfully completed.", operation)
+		if resp.GetMessage() != "" {
+			message = fmt.Sprintf("%s operation successfully completed: %s", operation, resp.GetMessage())
+		}
+
+		return csiaddonsv1alpha1.OperationResultSucceeded, message, nil
+	}
+}
+
+// getFilesystemMaintenanceOperation returns the operation of the request for
+// the operation in the FilesystemMaintenanceJob.Spec.
+func getFilesystemMaintenanceOperation(
+	operation csiaddonsv1alpha1.FilesystemMaintenanceOperation) proto.FilesystemMaintenanceRequest_Operation {
+	switch operation {
+	case csiaddonsv1alpha1.FilesystemMaintenanceDefragment:
+		return proto.FilesystemMaintenanceRequest_DEFRAGMENT
+	case csiaddonsv1alpha1.FilesystemMaintenanceCheck:
+		return proto.FilesystemMaintenanceRequest_CHECK
+	default:
+		return proto.FilesystemMaintenanceRequest_UNKNOWN
+	}
+}
+
+// validateFilesystemMaintenanceJobSpec validates FilesystemMaintenanceJob.Spec.
+func validateFilesystemMaintenanceJobSpec(
+	fmJob *csiaddonsv1alpha1.FilesystemMaintenanceJob) error {
+	if fmJob.Spec.Target.PersistentVolumeClaim == "" {
+		return errors.New("required parameter 'PersistentVolumeClaim' in FilesystemMaintenanceJob.Spec.Target is empty")
+	}
+
+	if getFilesystemMaintenanceOperation(fmJob.Spec.Operation) == proto.FilesystemMaintenanceRequest_UNKNOWN {
+		return fmt.Errorf("unknown operation %q in FilesystemMaintenanceJob.Spec", fmJob.Spec.Operation)
+	}
+
+	return nil
+}
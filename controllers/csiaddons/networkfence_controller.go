@@ -20,18 +20,30 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	csiaddonsv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/csiaddons/v1alpha1"
+	extensions "github.com/csi-addons/kubernetes-csi-addons/extensions/v1alpha1"
 	conn "github.com/csi-addons/kubernetes-csi-addons/internal/connection"
 	"github.com/csi-addons/kubernetes-csi-addons/internal/proto"
 	"github.com/csi-addons/kubernetes-csi-addons/internal/util"
@@ -47,10 +59,51 @@ type NetworkFenceReconciler struct {
 	Connpool *conn.ConnectionPool
 	// Timeout for the Reconcile operation.
 	Timeout time.Duration
+	// Recorder is used to record events for the fencing operations.
+	Recorder record.EventRecorder
+	// TrustRequesterAnnotation is set when the mutating admission webhook
+	// records the requester in the NetworkFenceRequestedByAnnotation. The
+	// annotation can be set by any user otherwise, and is ignored.
+	TrustRequesterAnnotation bool
 }
 
 const (
 	networkFenceFinalizer = "csiaddons.openshift.io/network-fence"
+
+	// condition types of the NetworkFence.
+	conditionFenced   = "Fenced"
+	conditionUnfenced = "Unfenced"
+	conditionDegraded = "Degraded"
+	// conditionSecretValid reports whether the secret of the NetworkFence
+	// exists and contains data.
+	conditionSecretValid = "SecretValid"
+
+	// condition reasons of the NetworkFence.
+	reasonFenced          = "Fenced"
+	reasonNotFenced       = "NotFenced"
+	reasonUnfenced        = "Unfenced"
+	reasonNotUnfenced     = "NotUnfenced"
+	reasonHealthy         = "Healthy"
+	reasonOperationFailed = "OperationFailed"
+	reasonSecretValid     = "SecretValid"
+	reasonSecretNotFound  = "SecretNotFound"
+	reasonSecretInvalid   = "SecretInvalid"
+
+	// networkFenceSecretKey indexes the NetworkFences by the namespaced
+	// name of their secret.
+	networkFenceSecretKey = "spec.secret"
+
+	// default values for the retries of failed operations.
+	defaultFenceBackoffLimit = 10
+	fenceRetryBaseDelay      = time.Second * 10
+	fenceRetryMaxDelay       = time.Minute * 5
+
+	// maxFenceHistory is the number of operations kept in the status, the
+	// Events of the NetworkFence record every operation.
+	maxFenceHistory = 50
+	// expiryRequester is recorded as the requester of the operations
+	// that unfence expired fences.
+	expiryRequester = "networkfence-controller (ttl expired)"
 )
 
 // validateNetworkFenceSpec validates the NetworkFence spec and checks if values are neither nil nor empty.
@@ -61,16 +114,32 @@ func validateNetworkFenceSpec(nwFence *csiaddonsv1alpha1.NetworkFence) error {
 	if nwFence.Spec.Driver == "" {
 		return errors.New("required parameter driver is not specified")
 	}
-	if nwFence.Spec.Cidrs == nil {
-		return errors.New("required parameter cidrs is not specified")
+	if len(nwFence.Spec.Cidrs) == 0 && len(nwFence.Spec.Targets) == 0 {
+		return errors.New("required parameter cidrs or targets is not specified")
 	}
 
 	return nil
 }
 
+// validateFenceTargets checks that the driver fences clients by their
+// identity when the NetworkFence has targets. The fence service of the
+// specification only supports CIDR blocks, a driver that does not know
+// about targets could return success for a request without CIDR blocks.
+func validateFenceTargets(nwFence *csiaddonsv1alpha1.NetworkFence, caps []*identity.Capability) error {
+	if len(nwFence.Spec.Targets) == 0 ||
+		extensions.HasNetworkFenceCapability(caps, extensions.Capability_NetworkFence_FENCE_TARGETS) {
+		return nil
+	}
+
+	return fmt.Errorf("driver %q does not support fencing by client identity, "+
+		"the targets can not be fenced", nwFence.Spec.Driver)
+}
+
 //+kubebuilder:rbac:groups=csiaddons.openshift.io,resources=networkfences,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=csiaddons.openshift.io,resources=networkfences/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=csiaddons.openshift.io,resources=networkfences/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -107,9 +176,10 @@ func (r *NetworkFenceReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, nil
 	}
 
-	logger = logger.WithValues("DriverName", nwFence.Spec.Driver, "CIDRs", nwFence.Spec.Cidrs)
+	logger = logger.WithValues("DriverName", nwFence.Spec.Driver, "CIDRs", nwFence.Spec.Cidrs,
+		"Targets", nwFence.Spec.Targets)
 
-	client, err := r.getNetworkFenceClient(nwFence.Spec.Driver, "")
+	client, capabilities, err := r.getNetworkFenceClient(nwFence.Spec.Driver, "")
 	if err != nil {
 		logger.Error(err, "Failed to get NetworkFenceClient")
 		return ctrl.Result{}, err
@@ -120,6 +190,7 @@ func (r *NetworkFenceReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		logger:           logger,
 		instance:         nwFence,
 		controllerClient: client,
+		requestedBy:      getRequester(nwFence, r.TrustRequesterAnnotation),
 	}
 
 	// check if the networkfence object is getting deleted and handle it.
@@ -137,29 +208,89 @@ func (r *NetworkFenceReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, nil
 	}
 
+	// an expired fence has been unfenced already, the secret is not needed
+	// anymore and must not overwrite the Expired result.
+	if nf.isExpired() && nwFence.Status.Result == csiaddonsv1alpha1.FencingOperationResultExpired {
+		logger.Info("NetworkFence has expired, skipping reconciliation")
+		return ctrl.Result{}, nil
+	}
+
+	secretCondition, secretVersion, err := r.checkSecret(ctx, nwFence)
+	if err != nil {
+		logger.Error(err, "failed to get NetworkFence secret")
+		return ctrl.Result{}, err
+	}
+
+	// reset the retries when the spec or the secret has changed, a
+	// permanently failed operation is not retried until then.
+	if nwFence.Status.ObservedGeneration != nwFence.Generation ||
+		nwFence.Status.SecretResourceVersion != secretVersion {
+		nwFence.Status.Retries = 0
+		nwFence.Status.NextRetryTime = nil
+	} else if nwFence.Status.Result == csiaddonsv1alpha1.FencingOperationResultPermanentlyFailed {
+		logger.Info("NetworkFence operation has permanently failed, skipping reconciliation")
+		return ctrl.Result{}, nil
+	}
+	nwFence.Status.SecretResourceVersion = secretVersion
+
+	err = validateFenceTargets(nwFence, capabilities)
+	if err != nil {
+		logger.Error(err, "NetworkFence targets are not supported")
+		err = nf.updateStatus(ctx, csiaddonsv1alpha1.FencingOperationResultPermanentlyFailed, err.Error())
+
+		return ctrl.Result{}, err
+	}
+
+	if secretCondition == nil {
+		meta.RemoveStatusCondition(&nwFence.Status.Conditions, conditionSecretValid)
+	} else {
+		meta.SetStatusCondition(&nwFence.Status.Conditions, *secretCondition)
+	}
+
+	// the driver can not perform the operation without the secret, the
+	// NetworkFence is reconciled again once the secret is created or updated.
+	if secretCondition != nil && secretCondition.Status != metav1.ConditionTrue {
+		logger.Info("NetworkFence secret is not valid, skipping reconciliation", "Reason", secretCondition.Reason)
+		err = nf.updateStatus(ctx, csiaddonsv1alpha1.FencingOperationResultFailed, secretCondition.Message)
+
+		return ctrl.Result{}, err
+	}
+
 	if nwFence.Spec.FenceState == csiaddonsv1alpha1.Fenced {
 		nf.logger.Info("FenceClusterNetwork Request", "namespaced name", req.NamespacedName.String())
 	} else {
 		nf.logger.Info("UnFenceClusterNetwork Request", "namespaced name", req.NamespacedName.String())
 	}
 
+	// unfence the CIDRs once the TTL of the fence has elapsed.
+	if nf.isExpired() {
+		err = nf.expireFence(ctx)
+		if err != nil {
+			logger.Error(err, "failed to unfence expired cluster network")
+			return nf.handleFailure(ctx, err)
+		}
+
+		return ctrl.Result{}, nil
+	}
+
 	err = nf.processFencing(ctx)
 	if err != nil {
 		logger.Error(err, "failed to fence cluster network")
-		updateStatusErr := nf.updateStatus(ctx, csiaddonsv1alpha1.FencingOperationResultFailed, err.Error())
-		if updateStatusErr != nil {
-			logger.Error(updateStatusErr, "failed to update status")
-		}
-
-		return ctrl.Result{}, err
+		return nf.handleFailure(ctx, err)
 	}
 
+	nf.updateFenceTime()
 	err = nf.updateStatus(ctx, csiaddonsv1alpha1.FencingOperationResultSucceeded, "fencing operation successful")
 	if err != nil {
 		logger.Error(err, "failed to update status")
 		return ctrl.Result{}, err
 	}
 
+	// requeue to unfence the CIDRs when the fence expires.
+	if expiryTime := nwFence.Status.ExpiryTime; expiryTime != nil {
+		return ctrl.Result{Requeue: true, RequeueAfter: time.Until(expiryTime.Time)}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -171,12 +302,21 @@ type NetworkFenceInstance struct {
 	controllerClient proto.NetworkFenceClient
 	logger           logr.Logger
 	instance         *csiaddonsv1alpha1.NetworkFence
+	// requestedBy contains the user who requested the operation.
+	requestedBy string
 }
 
 func (nf *NetworkFenceInstance) updateStatus(ctx context.Context,
 	result csiaddonsv1alpha1.FencingOperationResult, message string) error {
 	nf.instance.Status.Result = result
 	nf.instance.Status.Message = message
+	nf.instance.Status.ObservedGeneration = nf.instance.Generation
+	if result != csiaddonsv1alpha1.FencingOperationResultFailed &&
+		result != csiaddonsv1alpha1.FencingOperationResultPermanentlyFailed {
+		nf.instance.Status.Retries = 0
+		nf.instance.Status.NextRetryTime = nil
+	}
+	setNetworkFenceConditions(nf.instance)
 	if err := nf.reconciler.Client.Status().Update(ctx, nf.instance); err != nil {
 		nf.logger.Error(err, "failed to update status")
 
@@ -186,15 +326,227 @@ func (nf *NetworkFenceInstance) updateStatus(ctx context.Context,
 	return nil
 }
 
+// handleFailure records the failed operation in the status and requeues the
+// request with an exponential backoff. The operation is marked as permanently
+// failed when retrying can not resolve the error, or when the backoff limit
+// is reached.
+func (nf *NetworkFenceInstance) handleFailure(ctx context.Context, opErr error) (ctrl.Result, error) {
+	backoffLimit := nf.instance.Spec.BackoffLimit
+	if backoffLimit == 0 {
+		backoffLimit = defaultFenceBackoffLimit
+	}
+
+	if isPermanentFencingError(opErr) || nf.instance.Status.Retries >= backoffLimit {
+		nf.logger.Info("NetworkFence operation has permanently failed", "Retries", nf.instance.Status.Retries)
+		nf.instance.Status.NextRetryTime = nil
+		err := nf.updateStatus(ctx, csiaddonsv1alpha1.FencingOperationResultPermanentlyFailed,
+			fmt.Sprintf("operation failed after %d retries: %v", nf.instance.Status.Retries, opErr))
+
+		return ctrl.Result{}, err
+	}
+
+	nf.instance.Status.Retries++
+	backoff := getRetryBackoff(nf.instance.Status.Retries)
+	nf.instance.Status.NextRetryTime = &metav1.Time{Time: time.Now().Add(backoff)}
+	nf.logger.Info("retrying NetworkFence operation", "Retries", nf.instance.Status.Retries, "Backoff", backoff)
+	err := nf.updateStatus(ctx, csiaddonsv1alpha1.FencingOperationResultFailed, opErr.Error())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: backoff}, nil
+}
+
+// getRetryBackoff returns the delay before the given retry, the delay
+// doubles with each retry and is capped at fenceRetryMaxDelay.
+func getRetryBackoff(retries int32) time.Duration {
+	backoff := fenceRetryBaseDelay
+	for i := int32(1); i < retries && backoff < fenceRetryMaxDelay; i++ {
+		backoff *= 2
+	}
+	if backoff > fenceRetryMaxDelay {
+		backoff = fenceRetryMaxDelay
+	}
+
+	return backoff
+}
+
+// isPermanentFencingError returns true if retrying the operation can
+// not resolve the error.
+func isPermanentFencingError(err error) bool {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.Unimplemented:
+		return true
+	}
+
+	return false
+}
+
+// getTimeout returns the timeout for the grpc requests sent to the driver.
+func (nf *NetworkFenceInstance) getTimeout() time.Duration {
+	if nf.instance.Spec.Timeout != nil {
+		return time.Second * time.Duration(*nf.instance.Spec.Timeout)
+	}
+
+	return nf.reconciler.Timeout
+}
+
+// getExpiryTime returns the time at which the fence expires. It returns nil
+// if the CIDRs are not fenced or no TTL is set.
+func getExpiryTime(nwFence *csiaddonsv1alpha1.NetworkFence) *metav1.Time {
+	if nwFence.Spec.FenceState != csiaddonsv1alpha1.Fenced ||
+		nwFence.Spec.TTLSeconds == nil ||
+		nwFence.Status.FenceTime == nil {
+		return nil
+	}
+
+	ttl := time.Second * time.Duration(*nwFence.Spec.TTLSeconds)
+
+	return &metav1.Time{Time: nwFence.Status.FenceTime.Add(ttl)}
+}
+
+// isExpired returns true if the TTL of the fence has elapsed.
+func (nf *NetworkFenceInstance) isExpired() bool {
+	expiryTime := getExpiryTime(nf.instance)
+
+	return expiryTime != nil && !time.Now().Before(expiryTime.Time)
+}
+
+// updateFenceTime records the time at which the CIDRs were fenced and the
+// resulting expiry time, the fence time is reset once the CIDRs are unfenced.
+func (nf *NetworkFenceInstance) updateFenceTime() {
+	if nf.instance.Spec.FenceState != csiaddonsv1alpha1.Fenced {
+		nf.instance.Status.FenceTime = nil
+	} else if nf.instance.Status.FenceTime == nil {
+		nf.instance.Status.FenceTime = &metav1.Time{Time: time.Now()}
+	}
+
+	nf.instance.Status.ExpiryTime = getExpiryTime(nf.instance)
+}
+
+// expireFence unfences the CIDRs of a fence whose TTL has elapsed and
+// marks the NetworkFence as Expired.
+func (nf *NetworkFenceInstance) expireFence(ctx context.Context) error {
+	nf.logger.Info("NetworkFence has expired, unfencing cluster network",
+		"ExpiryTime", nf.instance.Status.ExpiryTime)
+
+	nf.requestedBy = expiryRequester
+	err := nf.processFencingRequest(ctx, csiaddonsv1alpha1.Unfenced)
+	if err != nil {
+		return err
+	}
+
+	return nf.updateStatus(ctx, csiaddonsv1alpha1.FencingOperationResultExpired,
+		"fence expired, cidrs have been unfenced")
+}
+
+// checkSecret returns the SecretValid condition and the resourceVersion of
+// the secret of the NetworkFence. No condition is returned when the
+// NetworkFence does not have a secret.
+func (r *NetworkFenceReconciler) checkSecret(ctx context.Context,
+	nwFence *csiaddonsv1alpha1.NetworkFence) (*metav1.Condition, string, error) {
+	ref := getNetworkFenceSecretRef(nwFence)
+	if ref.Name == "" {
+		return nil, "", nil
+	}
+
+	cond := &metav1.Condition{
+		Type:               conditionSecretValid,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: nwFence.Generation,
+	}
+
+	secret := &corev1.Secret{}
+	err := r.Client.Get(ctx, ref, secret)
+	if apierrors.IsNotFound(err) {
+		cond.Reason = reasonSecretNotFound
+		cond.Message = fmt.Sprintf("secret %q not found", ref)
+
+		return cond, "", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(secret.Data) == 0 {
+		cond.Reason = reasonSecretInvalid
+		cond.Message = fmt.Sprintf("secret %q does not contain any data", ref)
+
+		return cond, secret.ResourceVersion, nil
+	}
+
+	cond.Status = metav1.ConditionTrue
+	cond.Reason = reasonSecretValid
+	cond.Message = fmt.Sprintf("secret %q is valid", ref)
+
+	return cond, secret.ResourceVersion, nil
+}
+
+// getNetworkFenceSecretRef returns the namespaced name of the secret of the
+// NetworkFence, the name is empty if the NetworkFence does not have a secret.
+func getNetworkFenceSecretRef(nwFence *csiaddonsv1alpha1.NetworkFence) types.NamespacedName {
+	return types.NamespacedName{
+		Name:      nwFence.Spec.Secret.Name,
+		Namespace: nwFence.Spec.Secret.Namespace,
+	}
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *NetworkFenceReconciler) SetupWithManager(mgr ctrl.Manager, ctrlOptions controller.Options) error {
+	err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&csiaddonsv1alpha1.NetworkFence{},
+		networkFenceSecretKey,
+		func(rawObj client.Object) []string {
+			nwFence, ok := rawObj.(*csiaddonsv1alpha1.NetworkFence)
+			if !ok {
+				return nil
+			}
+			ref := getNetworkFenceSecretRef(nwFence)
+			if ref.Name == "" {
+				return nil
+			}
+
+			return []string{ref.String()}
+		})
+	if err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&csiaddonsv1alpha1.NetworkFence{}).
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		For(&csiaddonsv1alpha1.NetworkFence{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// the secret is read by the driver on every request, the
+		// NetworkFences are reconciled when it is created, rotated or
+		// deleted. Only the metadata is watched, so that the contents of
+		// all Secrets are not cached.
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findNetworkFencesForSecret),
+			builder.OnlyMetadata,
+		).
 		WithOptions(ctrlOptions).
 		Complete(r)
 }
 
+// findNetworkFencesForSecret returns the NetworkFences that use the secret.
+func (r *NetworkFenceReconciler) findNetworkFencesForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	nwFences := &csiaddonsv1alpha1.NetworkFenceList{}
+	err := r.Client.List(ctx, nwFences,
+		client.MatchingFields{networkFenceSecretKey: client.ObjectKeyFromObject(obj).String()})
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to list NetworkFences", "Secret", obj.GetName())
+
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(nwFences.Items))
+	for _, nwFence := range nwFences.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: nwFence.Name}})
+	}
+
+	return requests
+}
+
 // processFencing adds a finalizer and handles the fencing request.
 func (nf *NetworkFenceInstance) processFencing(ctx context.Context) error {
 
@@ -204,31 +556,396 @@ func (nf *NetworkFenceInstance) processFencing(ctx context.Context) error {
 		return err
 	}
 
-	return nf.processFencingRequest(ctx)
+	return nf.processFencingRequest(ctx, nf.instance.Spec.FenceState)
 }
 
-// processFencingRequest creates the fencing request based on
-// the spec and then calls appropriate function to either
-// fence or unfence based on the spec.
-func (nf *NetworkFenceInstance) processFencingRequest(ctx context.Context) error {
-	// send FenceClusterNetwork request.
-	request := &proto.NetworkFenceRequest{
+// processFencingRequest moves the CIDRs and targets which are not yet in the
+// given state to that state and records the result for each of them in the
+// status. When the request for multiple CIDRs and targets fails, a request is
+// sent for each of them separately, so that only the failed ones are retried
+// on the next reconcile.
+func (nf *NetworkFenceInstance) processFencingRequest(ctx context.Context, state csiaddonsv1alpha1.FenceState) error {
+	syncCIDRStatus(nf.instance)
+	syncTargetStatus(nf.instance)
+
+	cidrs := getPendingCIDRs(nf.instance, state)
+	targets := getPendingTargets(nf.instance, state)
+	if len(cidrs)+len(targets) == 0 {
+		nf.logger.Info("all CIDRs and targets are already in the requested state", "FenceState", state)
+		return nil
+	}
+
+	err := nf.sendFencingRequest(ctx, state, cidrs, targets)
+	if err == nil || len(cidrs)+len(targets) == 1 {
+		setCIDRStatus(nf.instance, cidrs, state, err)
+		setTargetStatus(nf.instance, targets, state, err)
+		return err
+	}
+
+	nf.logger.Info("retrying the request for each CIDR and target", "FenceState", state)
+	failed := []string{}
+	for _, cidr := range cidrs {
+		err = nf.sendFencingRequest(ctx, state, []string{cidr}, nil)
+		setCIDRStatus(nf.instance, []string{cidr}, state, err)
+		if err != nil {
+			failed = append(failed, cidr)
+		}
+	}
+	for _, target := range targets {
+		targets := []csiaddonsv1alpha1.FenceTarget{target}
+		err = nf.sendFencingRequest(ctx, state, nil, targets)
+		setTargetStatus(nf.instance, targets, state, err)
+		if err != nil {
+			failed = append(failed, targetString(target))
+		}
+	}
+
+	if len(failed) != 0 {
+		return fmt.Errorf("failed to move %v to %s state", failed, state)
+	}
+
+	return nil
+}
+
+// sendFencingRequest calls appropriate function to either fence or
+// unfence the given CIDRs and targets.
+func (nf *NetworkFenceInstance) sendFencingRequest(ctx context.Context,
+	state csiaddonsv1alpha1.FenceState, cidrs []string, targets []csiaddonsv1alpha1.FenceTarget) error {
+	request := nf.getNetworkFenceRequest(cidrs, targets)
+
+	var err error
+	if state == csiaddonsv1alpha1.Fenced {
+		err = nf.fenceClusterNetwork(ctx, request)
+	} else {
+		err = nf.unfenceClusterNetwork(ctx, request)
+	}
+	nf.recordOperation(state, cidrs, targets, err)
+
+	return err
+}
+
+// recordOperation appends the operation to the history in the status and
+// records an event for it.
+func (nf *NetworkFenceInstance) recordOperation(state csiaddonsv1alpha1.FenceState,
+	cidrs []string, targets []csiaddonsv1alpha1.FenceTarget, err error) {
+	entry := csiaddonsv1alpha1.FenceHistoryEntry{
+		Operation:   state,
+		Cidrs:       cidrs,
+		Targets:     targets,
+		RequestedBy: nf.requestedBy,
+		Time:        metav1.Now(),
+		Result:      csiaddonsv1alpha1.FencingOperationResultSucceeded,
+		Message:     "operation successful",
+	}
+	if err != nil {
+		entry.Result = csiaddonsv1alpha1.FencingOperationResultFailed
+		entry.Message = util.GetErrorMessage(err)
+	}
+	appendFenceHistory(nf.instance, entry)
+
+	operation := "Fence"
+	if state == csiaddonsv1alpha1.Unfenced {
+		operation = "Unfence"
+	}
+	description := describeFenceTargets(cidrs, targets)
+	if err != nil {
+		nf.reconciler.Recorder.Eventf(nf.instance, corev1.EventTypeWarning, operation+"Failed",
+			"%s of %s requested by %q failed: %s", operation, description, nf.requestedBy, entry.Message)
+		return
+	}
+	nf.reconciler.Recorder.Eventf(nf.instance, corev1.EventTypeNormal, operation+"Succeeded",
+		"%s of %s requested by %q succeeded", operation, description, nf.requestedBy)
+}
+
+// describeFenceTargets returns a description of the CIDRs and targets
+// for use in events.
+func describeFenceTargets(cidrs []string, targets []csiaddonsv1alpha1.FenceTarget) string {
+	descriptions := []string{}
+	if len(cidrs) != 0 {
+		descriptions = append(descriptions, fmt.Sprintf("CIDRs %v", cidrs))
+	}
+	if len(targets) != 0 {
+		targetStrings := make([]string, 0, len(targets))
+		for _, target := range targets {
+			targetStrings = append(targetStrings, targetString(target))
+		}
+		descriptions = append(descriptions, fmt.Sprintf("targets %v", targetStrings))
+	}
+
+	return strings.Join(descriptions, " and ")
+}
+
+// targetString returns the target in the <type>:<value> format.
+func targetString(target csiaddonsv1alpha1.FenceTarget) string {
+	return fmt.Sprintf("%s:%s", target.Type, target.Value)
+}
+
+// appendFenceHistory appends the entry to the history in the status, the
+// oldest entries are dropped once maxFenceHistory is reached.
+func appendFenceHistory(nwFence *csiaddonsv1alpha1.NetworkFence, entry csiaddonsv1alpha1.FenceHistoryEntry) {
+	history := append(nwFence.Status.History, entry)
+	if len(history) > maxFenceHistory {
+		history = history[len(history)-maxFenceHistory:]
+	}
+
+	nwFence.Status.History = history
+}
+
+// getRequester returns the user who requested the operation described by
+// the spec. The user is recorded by the mutating webhook, the annotation is
+// only used when trustAnnotation is set as any user can set it otherwise.
+// Without the annotation, the field manager that last updated the spec is
+// returned.
+func getRequester(nwFence *csiaddonsv1alpha1.NetworkFence, trustAnnotation bool) string {
+	if requester := nwFence.Annotations[csiaddonsv1alpha1.NetworkFenceRequestedByAnnotation]; trustAnnotation && requester != "" {
+		return requester
+	}
+
+	var latest *metav1.ManagedFieldsEntry
+	for i := range nwFence.ManagedFields {
+		entry := &nwFence.ManagedFields[i]
+		if entry.Subresource != "" || entry.FieldsV1 == nil ||
+			!strings.Contains(string(entry.FieldsV1.Raw), `"f:spec"`) {
+			continue
+		}
+		if latest == nil || (entry.Time != nil && (latest.Time == nil || latest.Time.Before(entry.Time))) {
+			latest = entry
+		}
+	}
+	if latest != nil {
+		return "manager:" + latest.Manager
+	}
+
+	return "unknown"
+}
+
+// getNetworkFenceRequest creates the fencing request for the given
+// CIDRs and targets based on the spec.
+func (nf *NetworkFenceInstance) getNetworkFenceRequest(
+	cidrs []string, targets []csiaddonsv1alpha1.FenceTarget) *proto.NetworkFenceRequest {
+	fenceTargets := make([]*proto.FenceTarget, 0, len(targets))
+	for _, target := range targets {
+		fenceTargets = append(fenceTargets, &proto.FenceTarget{
+			Type:  fenceTargetTypes[target.Type],
+			Value: target.Value,
+		})
+	}
+
+	return &proto.NetworkFenceRequest{
 		Parameters:      nf.instance.Spec.Parameters,
 		SecretName:      nf.instance.Spec.Secret.Name,
 		SecretNamespace: nf.instance.Spec.Secret.Namespace,
-		Cidrs:           nf.instance.Spec.Cidrs,
+		Cidrs:           cidrs,
+		Targets:         fenceTargets,
+	}
+}
+
+// fenceTargetTypes maps the FenceTargetType to the type used in the
+// NetworkFenceRequest.
+var fenceTargetTypes = map[csiaddonsv1alpha1.FenceTargetType]proto.FenceTarget_Type{
+	csiaddonsv1alpha1.FenceTargetTypeClientID:       proto.FenceTarget_CLIENT_ID,
+	csiaddonsv1alpha1.FenceTargetTypeNodeID:         proto.FenceTarget_NODE_ID,
+	csiaddonsv1alpha1.FenceTargetTypeISCSIInitiator: proto.FenceTarget_ISCSI_INITIATOR,
+	csiaddonsv1alpha1.FenceTargetTypeNVMeHostNQN:    proto.FenceTarget_NVME_HOST_NQN,
+	csiaddonsv1alpha1.FenceTargetTypeClientAddress:  proto.FenceTarget_CLIENT_ADDRESS,
+}
+
+// syncCIDRStatus makes sure the status contains an entry for each
+// CIDR in the spec, in the same order as the spec.
+func syncCIDRStatus(nwFence *csiaddonsv1alpha1.NetworkFence) {
+	cidrStatus := make([]csiaddonsv1alpha1.CIDRStatus, 0, len(nwFence.Spec.Cidrs))
+	for _, cidr := range nwFence.Spec.Cidrs {
+		s := csiaddonsv1alpha1.CIDRStatus{Cidr: cidr}
+		if existing := findCIDRStatus(nwFence.Status.Cidrs, cidr); existing != nil {
+			s = *existing
+		}
+		cidrStatus = append(cidrStatus, s)
+	}
+
+	nwFence.Status.Cidrs = cidrStatus
+}
+
+// findCIDRStatus returns the status of the given CIDR, or nil if not found.
+func findCIDRStatus(cidrStatus []csiaddonsv1alpha1.CIDRStatus, cidr string) *csiaddonsv1alpha1.CIDRStatus {
+	for i := range cidrStatus {
+		if cidrStatus[i].Cidr == cidr {
+			return &cidrStatus[i]
+		}
+	}
+
+	return nil
+}
+
+// getPendingCIDRs returns the CIDRs which are not in the given state.
+func getPendingCIDRs(nwFence *csiaddonsv1alpha1.NetworkFence, state csiaddonsv1alpha1.FenceState) []string {
+	cidrs := []string{}
+	for _, cidr := range nwFence.Spec.Cidrs {
+		s := findCIDRStatus(nwFence.Status.Cidrs, cidr)
+		if s == nil || s.State != state {
+			cidrs = append(cidrs, cidr)
+		}
+	}
+
+	return cidrs
+}
+
+// setCIDRStatus records the result of moving the given CIDRs to the
+// given state.
+func setCIDRStatus(nwFence *csiaddonsv1alpha1.NetworkFence,
+	cidrs []string, state csiaddonsv1alpha1.FenceState, err error) {
+	for _, cidr := range cidrs {
+		s := findCIDRStatus(nwFence.Status.Cidrs, cidr)
+		if s == nil {
+			continue
+		}
+
+		if err != nil {
+			s.Result = csiaddonsv1alpha1.FencingOperationResultFailed
+			s.Message = util.GetErrorMessage(err)
+			continue
+		}
+
+		s.State = state
+		s.Result = csiaddonsv1alpha1.FencingOperationResultSucceeded
+		s.Message = ""
 	}
+}
 
-	if nf.instance.Spec.FenceState == csiaddonsv1alpha1.Fenced {
-		return nf.fenceClusterNetwork(ctx, request)
+// syncTargetStatus makes sure the status contains an entry for each
+// target in the spec, in the same order as the spec.
+func syncTargetStatus(nwFence *csiaddonsv1alpha1.NetworkFence) {
+	targetStatus := make([]csiaddonsv1alpha1.FenceTargetStatus, 0, len(nwFence.Spec.Targets))
+	for _, target := range nwFence.Spec.Targets {
+		s := csiaddonsv1alpha1.FenceTargetStatus{FenceTarget: target}
+		if existing := findTargetStatus(nwFence.Status.Targets, target); existing != nil {
+			s = *existing
+		}
+		targetStatus = append(targetStatus, s)
+	}
+
+	nwFence.Status.Targets = targetStatus
+}
+
+// findTargetStatus returns the status of the given target, or nil if not found.
+func findTargetStatus(targetStatus []csiaddonsv1alpha1.FenceTargetStatus,
+	target csiaddonsv1alpha1.FenceTarget) *csiaddonsv1alpha1.FenceTargetStatus {
+	for i := range targetStatus {
+		if targetStatus[i].FenceTarget == target {
+			return &targetStatus[i]
+		}
+	}
+
+	return nil
+}
+
+// getPendingTargets returns the targets which are not in the given state.
+func getPendingTargets(nwFence *csiaddonsv1alpha1.NetworkFence,
+	state csiaddonsv1alpha1.FenceState) []csiaddonsv1alpha1.FenceTarget {
+	targets := []csiaddonsv1alpha1.FenceTarget{}
+	for _, target := range nwFence.Spec.Targets {
+		s := findTargetStatus(nwFence.Status.Targets, target)
+		if s == nil || s.State != state {
+			targets = append(targets, target)
+		}
+	}
+
+	return targets
+}
+
+// setTargetStatus records the result of moving the given targets to the
+// given state.
+func setTargetStatus(nwFence *csiaddonsv1alpha1.NetworkFence,
+	targets []csiaddonsv1alpha1.FenceTarget, state csiaddonsv1alpha1.FenceState, err error) {
+	for _, target := range targets {
+		s := findTargetStatus(nwFence.Status.Targets, target)
+		if s == nil {
+			continue
+		}
+
+		if err != nil {
+			s.Result = csiaddonsv1alpha1.FencingOperationResultFailed
+			s.Message = util.GetErrorMessage(err)
+			continue
+		}
+
+		s.State = state
+		s.Result = csiaddonsv1alpha1.FencingOperationResultSucceeded
+		s.Message = ""
+	}
+}
+
+// setNetworkFenceConditions sets the Fenced, Unfenced and Degraded
+// conditions based on the state of the CIDRs and targets.
+func setNetworkFenceConditions(nwFence *csiaddonsv1alpha1.NetworkFence) {
+	var fenced, unfenced int
+	failed := []string{}
+	count := func(state csiaddonsv1alpha1.FenceState, result csiaddonsv1alpha1.FencingOperationResult, name string) {
+		switch state {
+		case csiaddonsv1alpha1.Fenced:
+			fenced++
+		case csiaddonsv1alpha1.Unfenced:
+			unfenced++
+		}
+		if result == csiaddonsv1alpha1.FencingOperationResultFailed {
+			failed = append(failed, name)
+		}
+	}
+	for _, cidr := range nwFence.Spec.Cidrs {
+		if s := findCIDRStatus(nwFence.Status.Cidrs, cidr); s != nil {
+			count(s.State, s.Result, cidr)
+		}
+	}
+	for _, target := range nwFence.Spec.Targets {
+		if s := findTargetStatus(nwFence.Status.Targets, target); s != nil {
+			count(s.State, s.Result, targetString(target))
+		}
+	}
+
+	total := len(nwFence.Spec.Cidrs) + len(nwFence.Spec.Targets)
+	fencedCondition := metav1.Condition{
+		Type:               conditionFenced,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: nwFence.Generation,
+		Reason:             reasonNotFenced,
+		Message:            fmt.Sprintf("%d of %d CIDRs and targets are fenced", fenced, total),
+	}
+	if fenced == total {
+		fencedCondition.Status = metav1.ConditionTrue
+		fencedCondition.Reason = reasonFenced
+	}
+
+	unfencedCondition := metav1.Condition{
+		Type:               conditionUnfenced,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: nwFence.Generation,
+		Reason:             reasonNotUnfenced,
+		Message:            fmt.Sprintf("%d of %d CIDRs and targets are unfenced", unfenced, total),
+	}
+	if unfenced == total {
+		unfencedCondition.Status = metav1.ConditionTrue
+		unfencedCondition.Reason = reasonUnfenced
+	}
+
+	degradedCondition := metav1.Condition{
+		Type:               conditionDegraded,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: nwFence.Generation,
+		Reason:             reasonHealthy,
+	}
+	if len(failed) != 0 {
+		degradedCondition.Status = metav1.ConditionTrue
+		degradedCondition.Reason = reasonOperationFailed
+		degradedCondition.Message = fmt.Sprintf("operation failed for %v", failed)
 	}
 
-	return nf.unfenceClusterNetwork(ctx, request)
+	meta.SetStatusCondition(&nwFence.Status.Conditions, fencedCondition)
+	meta.SetStatusCondition(&nwFence.Status.Conditions, unfencedCondition)
+	meta.SetStatusCondition(&nwFence.Status.Conditions, degradedCondition)
 }
 
 // fenceClusterNetwork sends the fencing request
 func (nf *NetworkFenceInstance) fenceClusterNetwork(ctx context.Context, request *proto.NetworkFenceRequest) error {
-	timeoutContext, cancel := context.WithTimeout(ctx, nf.reconciler.Timeout)
+	timeoutContext, cancel := context.WithTimeout(ctx, nf.getTimeout())
 	defer cancel()
 
 	_, err := nf.controllerClient.FenceClusterNetwork(timeoutContext, request)
@@ -245,7 +962,7 @@ func (nf *NetworkFenceInstance) fenceClusterNetwork(ctx context.Context, request
 
 // unfenceClusterNetwork sends the unfencing request
 func (nf *NetworkFenceInstance) unfenceClusterNetwork(ctx context.Context, request *proto.NetworkFenceRequest) error {
-	timeoutContext, cancel := context.WithTimeout(ctx, nf.reconciler.Timeout)
+	timeoutContext, cancel := context.WithTimeout(ctx, nf.getTimeout())
 	defer cancel()
 
 	_, err := nf.controllerClient.UnFenceClusterNetwork(timeoutContext, request)
@@ -290,8 +1007,10 @@ func (nf *NetworkFenceInstance) removeFinalizerFromNetworkFence(ctx context.Cont
 	return nil
 }
 
-// getNetworkFenceClient returns a NetworkFenceClient for the given driver.
-func (r *NetworkFenceReconciler) getNetworkFenceClient(drivername, nodeID string) (proto.NetworkFenceClient, error) {
+// getNetworkFenceClient returns a NetworkFenceClient for the given driver,
+// and the capabilities of the connection.
+func (r *NetworkFenceReconciler) getNetworkFenceClient(
+	drivername, nodeID string) (proto.NetworkFenceClient, []*identity.Capability, error) {
 	conns := r.Connpool.GetByNodeID(drivername, nodeID)
 
 	// Iterate through the connections and find the one that matches the driver name
@@ -306,10 +1025,10 @@ func (r *NetworkFenceReconciler) getNetworkFenceClient(drivername, nodeID string
 
 			// validate of NETWORK_FENCE capability is enabled by the storage driver.
 			if cap.GetNetworkFence().GetType() == identity.Capability_NetworkFence_NETWORK_FENCE {
-				return proto.NewNetworkFenceClient(v.Client), nil
+				return proto.NewNetworkFenceClient(v.Client), v.Capabilities, nil
 			}
 		}
 	}
 
-	return nil, fmt.Errorf("no connections for driver: %s", drivername)
+	return nil, nil, fmt.Errorf("no connections for driver: %s", drivername)
 }
@@ -0,0 +1,483 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	csiaddonsv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/csiaddons/v1alpha1"
+	extensions "github.com/csi-addons/kubernetes-csi-addons/extensions/v1alpha1"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/connection"
+	"github.com/csi-addons/spec/lib/go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetExpiryTime(t *testing.T) {
+	ttl := int64(60)
+	fenceTime := v1.NewTime(time.Now())
+	expiryTime := v1.NewTime(fenceTime.Add(time.Minute))
+
+	tests := []struct {
+		name    string
+		nwFence *csiaddonsv1alpha1.NetworkFence
+		want    *v1.Time
+	}{
+		{
+			name: "fenced without ttl",
+			nwFence: &csiaddonsv1alpha1.NetworkFence{
+				Spec: csiaddonsv1alpha1.NetworkFenceSpec{
+					FenceState: csiaddonsv1alpha1.Fenced,
+				},
+				Status: csiaddonsv1alpha1.NetworkFenceStatus{
+					FenceTime: &fenceTime,
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "ttl set but not fenced yet",
+			nwFence: &csiaddonsv1alpha1.NetworkFence{
+				Spec: csiaddonsv1alpha1.NetworkFenceSpec{
+					FenceState: csiaddonsv1alpha1.Fenced,
+					TTLSeconds: &ttl,
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "ttl set and unfenced",
+			nwFence: &csiaddonsv1alpha1.NetworkFence{
+				Spec: csiaddonsv1alpha1.NetworkFenceSpec{
+					FenceState: csiaddonsv1alpha1.Unfenced,
+					TTLSeconds: &ttl,
+				},
+				Status: csiaddonsv1alpha1.NetworkFenceStatus{
+					FenceTime: &fenceTime,
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "ttl set and fenced",
+			nwFence: &csiaddonsv1alpha1.NetworkFence{
+				Spec: csiaddonsv1alpha1.NetworkFenceSpec{
+					FenceState: csiaddonsv1alpha1.Fenced,
+					TTLSeconds: &ttl,
+				},
+				Status: csiaddonsv1alpha1.NetworkFenceStatus{
+					FenceTime: &fenceTime,
+				},
+			},
+			want: &expiryTime,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, getExpiryTime(tt.nwFence))
+		})
+	}
+}
+
+func TestGetPendingCIDRs(t *testing.T) {
+	nwFence := &csiaddonsv1alpha1.NetworkFence{
+		Spec: csiaddonsv1alpha1.NetworkFenceSpec{
+			Cidrs: []string{"10.0.0.1/32", "10.0.0.2/32", "10.0.0.3/32"},
+		},
+		Status: csiaddonsv1alpha1.NetworkFenceStatus{
+			Cidrs: []csiaddonsv1alpha1.CIDRStatus{
+				{Cidr: "10.0.0.1/32", State: csiaddonsv1alpha1.Fenced},
+				{Cidr: "10.0.0.2/32", State: csiaddonsv1alpha1.Unfenced},
+				{Cidr: "10.0.0.4/32", State: csiaddonsv1alpha1.Fenced},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"10.0.0.2/32", "10.0.0.3/32"},
+		getPendingCIDRs(nwFence, csiaddonsv1alpha1.Fenced))
+	assert.Equal(t, []string{"10.0.0.1/32", "10.0.0.3/32"},
+		getPendingCIDRs(nwFence, csiaddonsv1alpha1.Unfenced))
+
+	syncCIDRStatus(nwFence)
+	assert.Equal(t, []csiaddonsv1alpha1.CIDRStatus{
+		{Cidr: "10.0.0.1/32", State: csiaddonsv1alpha1.Fenced},
+		{Cidr: "10.0.0.2/32", State: csiaddonsv1alpha1.Unfenced},
+		{Cidr: "10.0.0.3/32"},
+	}, nwFence.Status.Cidrs)
+}
+
+func TestGetPendingTargets(t *testing.T) {
+	iqn := csiaddonsv1alpha1.FenceTarget{
+		Type:  csiaddonsv1alpha1.FenceTargetTypeISCSIInitiator,
+		Value: "iqn.2001-04.com.example:node1",
+	}
+	nqn := csiaddonsv1alpha1.FenceTarget{
+		Type:  csiaddonsv1alpha1.FenceTargetTypeNVMeHostNQN,
+		Value: "nqn.2014-08.org.nvmexpress:uuid:node1",
+	}
+	nwFence := &csiaddonsv1alpha1.NetworkFence{
+		Spec: csiaddonsv1alpha1.NetworkFenceSpec{
+			FenceState: csiaddonsv1alpha1.Fenced,
+			Cidrs:      []string{"10.0.0.1/32"},
+			Targets:    []csiaddonsv1alpha1.FenceTarget{iqn, nqn},
+		},
+	}
+	syncCIDRStatus(nwFence)
+	syncTargetStatus(nwFence)
+	setTargetStatus(nwFence, []csiaddonsv1alpha1.FenceTarget{iqn}, csiaddonsv1alpha1.Fenced, nil)
+	setTargetStatus(nwFence, []csiaddonsv1alpha1.FenceTarget{nqn}, csiaddonsv1alpha1.Fenced, errors.New("failed"))
+	setNetworkFenceConditions(nwFence)
+
+	assert.Equal(t, []csiaddonsv1alpha1.FenceTarget{nqn}, getPendingTargets(nwFence, csiaddonsv1alpha1.Fenced))
+	assert.Equal(t, []csiaddonsv1alpha1.FenceTarget{iqn, nqn}, getPendingTargets(nwFence, csiaddonsv1alpha1.Unfenced))
+	degraded := meta.FindStatusCondition(nwFence.Status.Conditions, conditionDegraded)
+	assert.Equal(t, "operation failed for [NVMeHostNQN:nqn.2014-08.org.nvmexpress:uuid:node1]", degraded.Message)
+	fenced := meta.FindStatusCondition(nwFence.Status.Conditions, conditionFenced)
+	assert.Equal(t, "1 of 3 CIDRs and targets are fenced", fenced.Message)
+}
+
+func TestSetNetworkFenceConditions(t *testing.T) {
+	nwFence := &csiaddonsv1alpha1.NetworkFence{
+		Spec: csiaddonsv1alpha1.NetworkFenceSpec{
+			FenceState: csiaddonsv1alpha1.Fenced,
+			Cidrs:      []string{"10.0.0.1/32", "10.0.0.2/32"},
+		},
+	}
+	syncCIDRStatus(nwFence)
+
+	// partial failure, only the first CIDR is fenced.
+	setCIDRStatus(nwFence, []string{"10.0.0.1/32"}, csiaddonsv1alpha1.Fenced, nil)
+	setCIDRStatus(nwFence, []string{"10.0.0.2/32"}, csiaddonsv1alpha1.Fenced, errors.New("failed"))
+	setNetworkFenceConditions(nwFence)
+
+	assert.Equal(t, csiaddonsv1alpha1.Fenced, nwFence.Status.Cidrs[0].State)
+	assert.Equal(t, csiaddonsv1alpha1.FencingOperationResultFailed, nwFence.Status.Cidrs[1].Result)
+	assert.True(t, meta.IsStatusConditionFalse(nwFence.Status.Conditions, conditionFenced))
+	assert.True(t, meta.IsStatusConditionFalse(nwFence.Status.Conditions, conditionUnfenced))
+	assert.True(t, meta.IsStatusConditionTrue(nwFence.Status.Conditions, conditionDegraded))
+	assert.Equal(t, []string{"10.0.0.2/32"}, getPendingCIDRs(nwFence, csiaddonsv1alpha1.Fenced))
+
+	// retry of the failed CIDR succeeds.
+	setCIDRStatus(nwFence, []string{"10.0.0.2/32"}, csiaddonsv1alpha1.Fenced, nil)
+	setNetworkFenceConditions(nwFence)
+
+	assert.True(t, meta.IsStatusConditionTrue(nwFence.Status.Conditions, conditionFenced))
+	assert.True(t, meta.IsStatusConditionFalse(nwFence.Status.Conditions, conditionUnfenced))
+	assert.True(t, meta.IsStatusConditionFalse(nwFence.Status.Conditions, conditionDegraded))
+}
+
+func TestGetRetryBackoff(t *testing.T) {
+	tests := []struct {
+		retries int32
+		want    time.Duration
+	}{
+		{retries: 1, want: fenceRetryBaseDelay},
+		{retries: 2, want: fenceRetryBaseDelay * 2},
+		{retries: 3, want: fenceRetryBaseDelay * 4},
+		{retries: 10, want: fenceRetryMaxDelay},
+		{retries: 60, want: fenceRetryMaxDelay},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, getRetryBackoff(tt.retries), "retries: %d", tt.retries)
+	}
+}
+
+func TestIsPermanentFencingError(t *testing.T) {
+	assert.True(t, isPermanentFencingError(status.Error(codes.InvalidArgument, "invalid cidr")))
+	assert.True(t, isPermanentFencingError(status.Error(codes.Unimplemented, "not supported")))
+	assert.False(t, isPermanentFencingError(status.Error(codes.DeadlineExceeded, "timeout")))
+	assert.False(t, isPermanentFencingError(errors.New("failed")))
+}
+
+func TestValidateFenceTargets(t *testing.T) {
+	t.Parallel()
+	networkFence := &identity.Capability{
+		Type: &identity.Capability_NetworkFence_{
+			NetworkFence: &identity.Capability_NetworkFence{Type: identity.Capability_NetworkFence_NETWORK_FENCE},
+		},
+	}
+	fenceTargets := extensions.NewNetworkFenceCapability(extensions.Capability_NetworkFence_FENCE_TARGETS)
+	targets := []csiaddonsv1alpha1.FenceTarget{{Type: csiaddonsv1alpha1.FenceTargetTypeNodeID, Value: "node-1"}}
+
+	tests := []struct {
+		name    string
+		cidrs   []string
+		targets []csiaddonsv1alpha1.FenceTarget
+		caps    []*identity.Capability
+		wantErr bool
+	}{
+		{
+			name:  "cidrs only",
+			cidrs: []string{"10.90.89.66/32"},
+			caps:  []*identity.Capability{networkFence},
+		},
+		{
+			name:    "targets with fence targets capability",
+			targets: targets,
+			caps:    []*identity.Capability{networkFence, fenceTargets},
+		},
+		{
+			name:    "targets without fence targets capability",
+			targets: targets,
+			caps:    []*identity.Capability{networkFence},
+			wantErr: true,
+		},
+		{
+			name:    "cidrs and targets without fence targets capability",
+			cidrs:   []string{"10.90.89.66/32"},
+			targets: targets,
+			caps:    []*identity.Capability{networkFence},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			nwFence := &csiaddonsv1alpha1.NetworkFence{
+				Spec: csiaddonsv1alpha1.NetworkFenceSpec{
+					Driver:  "test.csi.io",
+					Cidrs:   newtt.cidrs,
+					Targets: newtt.targets,
+				},
+			}
+
+			err := validateFenceTargets(nwFence, newtt.caps)
+			assert.Equal(t, newtt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestAppendFenceHistory(t *testing.T) {
+	nwFence := &csiaddonsv1alpha1.NetworkFence{}
+	for i := 0; i < maxFenceHistory+5; i++ {
+		appendFenceHistory(nwFence, csiaddonsv1alpha1.FenceHistoryEntry{
+			Operation:   csiaddonsv1alpha1.Fenced,
+			RequestedBy: fmt.Sprintf("user-%d", i),
+		})
+	}
+
+	assert.Len(t, nwFence.Status.History, maxFenceHistory)
+	assert.Equal(t, "user-5", nwFence.Status.History[0].RequestedBy)
+	assert.Equal(t, fmt.Sprintf("user-%d", maxFenceHistory+4),
+		nwFence.Status.History[maxFenceHistory-1].RequestedBy)
+}
+
+func TestGetRequester(t *testing.T) {
+	older := v1.NewTime(time.Now().Add(-time.Hour))
+	newer := v1.NewTime(time.Now())
+	specFields := &v1.FieldsV1{Raw: []byte(`{"f:spec":{"f:fenceState":{}}}`)}
+	statusFields := &v1.FieldsV1{Raw: []byte(`{"f:status":{"f:result":{}}}`)}
+
+	annotatedFence := &csiaddonsv1alpha1.NetworkFence{
+		ObjectMeta: v1.ObjectMeta{
+			Annotations: map[string]string{
+				csiaddonsv1alpha1.NetworkFenceRequestedByAnnotation: "admin",
+			},
+			ManagedFields: []v1.ManagedFieldsEntry{
+				{Manager: "kubectl-edit", Time: &newer, FieldsV1: specFields},
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		nwFence         *csiaddonsv1alpha1.NetworkFence
+		trustAnnotation bool
+		want            string
+	}{
+		{
+			name:            "requester recorded by webhook",
+			nwFence:         annotatedFence,
+			trustAnnotation: true,
+			want:            "admin",
+		},
+		{
+			name:    "annotation ignored without webhook",
+			nwFence: annotatedFence,
+			want:    "manager:kubectl-edit",
+		},
+		{
+			name: "latest manager of the spec",
+			nwFence: &csiaddonsv1alpha1.NetworkFence{
+				ObjectMeta: v1.ObjectMeta{
+					ManagedFields: []v1.ManagedFieldsEntry{
+						{Manager: "kubectl-create", Time: &older, FieldsV1: specFields},
+						{Manager: "kubectl-edit", Time: &newer, FieldsV1: specFields},
+						{Manager: "manager", Time: &newer, FieldsV1: statusFields, Subresource: "status"},
+					},
+				},
+			},
+			want: "manager:kubectl-edit",
+		},
+		{
+			name:    "no requester",
+			nwFence: &csiaddonsv1alpha1.NetworkFence{},
+			want:    "unknown",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, getRequester(tt.nwFence, tt.trustAnnotation))
+		})
+	}
+}
+
+func TestCheckNetworkFenceSecret(t *testing.T) {
+	secretSpec := csiaddonsv1alpha1.SecretSpec{Name: "fence-secret", Namespace: "default"}
+	validSecret := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{Name: "fence-secret", Namespace: "default"},
+		Data:       map[string][]byte{"userKey": []byte("key")},
+	}
+	emptySecret := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{Name: "fence-secret", Namespace: "default"},
+	}
+
+	tests := []struct {
+		name       string
+		secret     *corev1.Secret
+		secretSpec csiaddonsv1alpha1.SecretSpec
+		wantReason string
+	}{
+		{
+			name:       "no secret",
+			secretSpec: csiaddonsv1alpha1.SecretSpec{},
+		},
+		{
+			name:       "secret not found",
+			secretSpec: secretSpec,
+			wantReason: reasonSecretNotFound,
+		},
+		{
+			name:       "secret without data",
+			secret:     emptySecret,
+			secretSpec: secretSpec,
+			wantReason: reasonSecretInvalid,
+		},
+		{
+			name:       "valid secret",
+			secret:     validSecret,
+			secretSpec: secretSpec,
+			wantReason: reasonSecretValid,
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			builder := fake.NewClientBuilder()
+			if newtt.secret != nil {
+				builder = builder.WithObjects(newtt.secret.DeepCopy())
+			}
+			r := &NetworkFenceReconciler{Client: builder.Build()}
+			nwFence := &csiaddonsv1alpha1.NetworkFence{
+				Spec: csiaddonsv1alpha1.NetworkFenceSpec{Secret: newtt.secretSpec},
+			}
+
+			cond, version, err := r.checkSecret(context.TODO(), nwFence)
+			assert.NoError(t, err)
+			if newtt.wantReason == "" {
+				assert.Nil(t, cond)
+				assert.Empty(t, version)
+
+				return
+			}
+			assert.Equal(t, newtt.wantReason, cond.Reason)
+			assert.Equal(t, newtt.wantReason == reasonSecretValid, cond.Status == v1.ConditionTrue)
+			if newtt.secret == nil {
+				assert.Empty(t, version)
+			} else {
+				assert.NotEmpty(t, version)
+			}
+		})
+	}
+}
+
+func TestNetworkFenceReconcileExpired(t *testing.T) {
+	t.Parallel()
+	driver := "test.csi.io"
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, csiaddonsv1alpha1.AddToScheme(scheme))
+
+	// the driver is never called, the connection is not used.
+	conn, err := grpc.Dial("unix://"+filepath.Join(t.TempDir(), "sidecar.sock"),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	pool := connection.NewConnectionPool()
+	pool.Put("node-1", &connection.Connection{
+		Client: conn,
+		Capabilities: []*identity.Capability{{
+			Type: &identity.Capability_NetworkFence_{
+				NetworkFence: &identity.Capability_NetworkFence{Type: identity.Capability_NetworkFence_NETWORK_FENCE},
+			},
+		}},
+		NodeID:     "node-1",
+		DriverName: driver,
+	})
+
+	ttl := int64(60)
+	fenceTime := v1.NewTime(time.Now().Add(-time.Hour))
+	nwFence := &csiaddonsv1alpha1.NetworkFence{
+		ObjectMeta: v1.ObjectMeta{Name: "fence", Generation: 1},
+		Spec: csiaddonsv1alpha1.NetworkFenceSpec{
+			Driver:     driver,
+			FenceState: csiaddonsv1alpha1.Fenced,
+			Cidrs:      []string{"10.0.0.0/24"},
+			TTLSeconds: &ttl,
+			// the secret has been deleted after the fence expired.
+			Secret: csiaddonsv1alpha1.SecretSpec{Name: "fence-secret", Namespace: "default"},
+		},
+		Status: csiaddonsv1alpha1.NetworkFenceStatus{
+			Result:             csiaddonsv1alpha1.FencingOperationResultExpired,
+			ObservedGeneration: 1,
+			FenceTime:          &fenceTime,
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(nwFence).
+		WithStatusSubresource(nwFence).
+		Build()
+	r := &NetworkFenceReconciler{Client: c, Scheme: scheme, Connpool: pool}
+
+	_, err = r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Name: nwFence.Name}})
+	require.NoError(t, err)
+
+	got := &csiaddonsv1alpha1.NetworkFence{}
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: nwFence.Name}, got))
+	assert.Equal(t, csiaddonsv1alpha1.FencingOperationResultExpired, got.Status.Result)
+	assert.Empty(t, got.Status.History)
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	csiaddonsv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/csiaddons/v1alpha1"
+	extensions "github.com/csi-addons/kubernetes-csi-addons/extensions/v1alpha1"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/connection"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/util"
+
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// nodeJob refers to the fields of the spec and status that the jobs, which
+// run an operation on the node where the volume of their target is
+// attached, have in common.
+type nodeJob struct {
+	object client.Object
+	// kind is the kind of the job, used in the messages.
+	kind string
+
+	pvcName              string
+	backoffLimit         *int32
+	retryDeadlineSeconds *int64
+	timeout              *int64
+
+	result         *csiaddonsv1alpha1.OperationResult
+	message        *string
+	conditions     *[]v1.Condition
+	retries        *int32
+	startTime      **v1.Time
+	completionTime **v1.Time
+}
+
+// complete sets the result of the job.
+func (j *nodeJob) complete(result csiaddonsv1alpha1.OperationResult, message string) {
+	*j.result = result
+	*j.message = message
+	*j.completionTime = &v1.Time{Time: time.Now()}
+}
+
+// nodeJobOperation runs the operation of the job with the client of the
+// sidecar on the node where the volume is attached. It returns the result
+// and message of the job, or an error when the operation is retried.
+type nodeJobOperation func(
+	ctx context.Context,
+	logger *logr.Logger,
+	conn grpc.ClientConnInterface,
+	target *targetDetails) (csiaddonsv1alpha1.OperationResult, string, error)
+
+// nodeJobRunner runs the jobs of an operation that is advertised with the
+// serviceType capability by the sidecars. It handles the lifecycle of the
+// jobs, the validation, retries, deadline and status, which is the same
+// for all of them.
+type nodeJobRunner struct {
+	client      client.Client
+	connPool    *connection.ConnectionPool
+	timeout     time.Duration
+	serviceType extensions.Capability_Service_Type
+}
+
+// reconcile runs the operation of the job, unless it has a result already,
+// and updates the status of the job. The spec of the job is not valid when
+// validationErr is set.
+func (nr *nodeJobRunner) reconcile(
+	ctx context.Context,
+	logger *logr.Logger,
+	job *nodeJob,
+	validationErr error,
+	operation nodeJobOperation) (ctrl.Result, error) {
+	if !job.object.GetDeletionTimestamp().IsZero() {
+		logger.Info(job.kind + " resource is being deleted, exiting reconcile")
+		return ctrl.Result{}, nil
+	}
+
+	if *job.result != "" {
+		logger.Info(fmt.Sprintf("%s is already in %q state, exiting reconcile", job.kind, *job.result))
+		// since result is already set, just dequeue.
+		return ctrl.Result{}, nil
+	}
+
+	if validationErr != nil {
+		logger.Error(validationErr, fmt.Sprintf("Failed to validate %s.Spec", job.kind))
+
+		job.complete(csiaddonsv1alpha1.OperationResultFailed,
+			fmt.Sprintf("Failed to validate %s.Spec: %v", job.kind, validationErr))
+		if statusErr := nr.client.Status().Update(ctx, job.object); statusErr != nil {
+			logger.Error(statusErr, "Failed to update status")
+			return ctrl.Result{}, statusErr
+		}
+
+		// invalid parameters, do not requeue.
+		return ctrl.Result{}, nil
+	}
+
+	// set default values if equal to 0.
+	if *job.backoffLimit == 0 {
+		*job.backoffLimit = defaultBackoffLimit
+	}
+	if *job.retryDeadlineSeconds == 0 {
+		*job.retryDeadlineSeconds = defaultRetryDeadlineSeconds
+	}
+
+	err := nr.run(ctx, logger, job, operation)
+
+	if *job.result == "" && *job.retries == *job.backoffLimit {
+		logger.Info("Maximum retry limit reached")
+		job.complete(csiaddonsv1alpha1.OperationResultFailed, "Maximum retry limit reached")
+	}
+
+	if statusErr := nr.client.Status().Update(ctx, job.object); statusErr != nil {
+		logger.Error(statusErr, "Failed to update status")
+
+		return ctrl.Result{}, statusErr
+	}
+
+	if *job.result != "" {
+		// since result is already set, just dequeue.
+		return ctrl.Result{}, nil
+	}
+
+	return ctrl.Result{}, err
+}
+
+// run performs time based validation, fetches the details of the target and
+// runs the operation on the node where the volume is attached.
+func (nr *nodeJobRunner) run(
+	ctx context.Context,
+	logger *logr.Logger,
+	job *nodeJob,
+	operation nodeJobOperation) error {
+	if *job.startTime == nil {
+		// this is the first reconcile, add StartTime
+		*job.startTime = &v1.Time{Time: time.Now()}
+	} else {
+		// not first reconcile, increment retries
+		*job.retries++
+	}
+
+	// check whether currentTime > CreationTime + RetryDeadlineSeconds,
+	// if true, mark it as Time limit reached and fail.
+	deadline := job.object.GetCreationTimestamp().Add(time.Second * time.Duration(*job.retryDeadlineSeconds))
+	if time.Now().After(deadline) {
+		logger.Info("Time limit reached")
+		job.complete(csiaddonsv1alpha1.OperationResultFailed, "Time limit reached")
+
+		return nil
+	}
+
+	target, err := getTargetDetails(ctx, nr.client, logger,
+		job.pvcName, job.object.GetNamespace(), nr.timeout, job.timeout)
+	if err != nil {
+		logger.Error(err, "Failed to get target details")
+		setFailedCondition(job.conditions, "Failed to get target details", job.object.GetGeneration())
+
+		return err
+	}
+
+	// the operation is run by the node plugin where the volume is
+	// attached, as the volume is staged and published there.
+	if target.nodeID == "" {
+		err = fmt.Errorf("volume %q is not attached to a node", target.pvName)
+		setFailedCondition(job.conditions, err.Error(), job.object.GetGeneration())
+
+		return err
+	}
+
+	clientName, conn := nr.getNodeClient(target.driverName, target.nodeID)
+	if conn == nil {
+		err = fmt.Errorf("node Client not found for %q nodeID", target.nodeID)
+		logger.Error(err, "Failed to make node request")
+		setFailedCondition(job.conditions,
+			fmt.Sprintf("Failed to make node request: %v", err), job.object.GetGeneration())
+
+		return err
+	}
+	*logger = logger.WithValues("nodeClient", clientName)
+
+	result, message, err := operation(ctx, logger, conn, target)
+	if err != nil {
+		logger.Error(err, "Failed to make node request")
+		setFailedCondition(job.conditions,
+			fmt.Sprintf("Failed to make node request: %v", util.GetErrorMessage(err)), job.object.GetGeneration())
+
+		return err
+	}
+	job.complete(result, message)
+
+	return nil
+}
+
+// getNodeClient returns the name and client of the connection to the sidecar
+// of the driver on the node, that advertises the serviceType capability.
+func (nr *nodeJobRunner) getNodeClient(driverName, nodeID string) (string, grpc.ClientConnInterface) {
+	conns := nr.connPool.GetByNodeID(driverName, nodeID)
+	for k, v := range conns {
+		if extensions.HasServiceCapability(v.Capabilities, nr.serviceType) {
+			return k, v.Client
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	csiaddonsv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/csiaddons/v1alpha1"
+	extensions "github.com/csi-addons/kubernetes-csi-addons/extensions/v1alpha1"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/connection"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	corev1 "k8s.io/api/core/v1"
+	scv1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNodeJobRunnerReconcile(t *testing.T) {
+	t.Parallel()
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, csiaddonsv1alpha1.AddToScheme(scheme))
+
+	pvName := "pv-1"
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "pvc-1", Namespace: "default"},
+		Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: pvName},
+		Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+	}
+	pv := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: pvName},
+		Spec: corev1.PersistentVolumeSpec{
+			PersistentVolumeSource: corev1.PersistentVolumeSource{
+				CSI: &corev1.CSIPersistentVolumeSource{Driver: "test.csi.io", VolumeHandle: "handle-1"},
+			},
+		},
+	}
+	va := &scv1.VolumeAttachment{
+		ObjectMeta: metav1.ObjectMeta{Name: "va-1"},
+		Spec: scv1.VolumeAttachmentSpec{
+			Attacher: "test.csi.io",
+			NodeName: "node-1",
+			Source:   scv1.VolumeAttachmentSource{PersistentVolumeName: &pvName},
+		},
+		Status: scv1.VolumeAttachmentStatus{Attached: true},
+	}
+
+	tests := []struct {
+		name          string
+		result        csiaddonsv1alpha1.OperationResult
+		created       time.Time
+		validationErr error
+		wantResult    csiaddonsv1alpha1.OperationResult
+		wantMessage   string
+		wantErr       bool
+	}{
+		{
+			name:        "already completed",
+			result:      csiaddonsv1alpha1.OperationResultSucceeded,
+			created:     time.Now(),
+			wantResult:  csiaddonsv1alpha1.OperationResultSucceeded,
+			wantMessage: "",
+		},
+		{
+			name:          "invalid spec",
+			created:       time.Now(),
+			validationErr: errors.New("invalid"),
+			wantResult:    csiaddonsv1alpha1.OperationResultFailed,
+			wantMessage:   "Failed to validate EncryptionKeyRotationJob.Spec: invalid",
+		},
+		{
+			name:        "time limit reached",
+			created:     time.Now().Add(-time.Hour),
+			wantResult:  csiaddonsv1alpha1.OperationResultFailed,
+			wantMessage: "Time limit reached",
+		},
+		{
+			name:    "node client not found",
+			created: time.Now(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			krJob := &csiaddonsv1alpha1.EncryptionKeyRotationJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "job",
+					Namespace:         "default",
+					CreationTimestamp: metav1.NewTime(newtt.created),
+				},
+				Spec: csiaddonsv1alpha1.EncryptionKeyRotationJobSpec{
+					Target: csiaddonsv1alpha1.TargetSpec{PersistentVolumeClaim: pvc.Name},
+				},
+				Status: csiaddonsv1alpha1.EncryptionKeyRotationJobStatus{Result: newtt.result},
+			}
+			nr := &nodeJobRunner{
+				client: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(krJob, pvc.DeepCopy(), pv.DeepCopy(), va.DeepCopy()).
+					WithStatusSubresource(krJob).
+					Build(),
+				connPool:    connection.NewConnectionPool(),
+				timeout:     time.Minute,
+				serviceType: extensions.Capability_Service_ENCRYPTION_KEY_ROTATION,
+			}
+
+			called := false
+			operation := func(_ context.Context, _ *logr.Logger, _ grpc.ClientConnInterface,
+				_ *targetDetails) (csiaddonsv1alpha1.OperationResult, string, error) {
+				called = true
+				return csiaddonsv1alpha1.OperationResultSucceeded, "", nil
+			}
+
+			logger := logr.Discard()
+			_, err := nr.reconcile(context.Background(), &logger,
+				newEncryptionKeyRotationNodeJob(krJob), newtt.validationErr, operation)
+			if newtt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.False(t, called)
+
+			updated := &csiaddonsv1alpha1.EncryptionKeyRotationJob{}
+			require.NoError(t, nr.client.Get(context.Background(), client.ObjectKeyFromObject(krJob), updated))
+			assert.Equal(t, newtt.wantResult, updated.Status.Result)
+			assert.Equal(t, newtt.wantMessage, updated.Status.Message)
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	csiaddonsv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/csiaddons/v1alpha1"
+	extensions "github.com/csi-addons/kubernetes-csi-addons/extensions/v1alpha1"
 	"github.com/csi-addons/kubernetes-csi-addons/internal/connection"
 	"github.com/csi-addons/kubernetes-csi-addons/internal/util"
 
@@ -56,6 +57,9 @@ var (
 	rsCronJobScheduleTimeAnnotation = "reclaimspace." + csiaddonsv1alpha1.GroupVersion.Group + "/schedule"
 	rsCronJobNameAnnotation         = "reclaimspace." + csiaddonsv1alpha1.GroupVersion.Group + "/cronjob"
 	csiAddonsDriverAnnotation       = "reclaimspace." + csiaddonsv1alpha1.GroupVersion.Group + "/drivers"
+	krCronJobScheduleTimeAnnotation = "keyrotation." + csiaddonsv1alpha1.GroupVersion.Group + "/schedule"
+	krCronJobNameAnnotation         = "keyrotation." + csiaddonsv1alpha1.GroupVersion.Group + "/cronjob"
+	krDriverAnnotation              = "keyrotation." + csiaddonsv1alpha1.GroupVersion.Group + "/drivers"
 	ErrConnNotFoundRequeueNeeded    = errors.New("connection not found, requeue needed")
 	ErrScheduleNotFound             = errors.New("schedule not found")
 )
@@ -64,17 +68,59 @@ const (
 	defaultSchedule = "@weekly"
 )
 
+// scheduledOperation contains the annotations that schedule an operation
+// for a PersistentVolumeClaim, and the check for the support of the
+// operation by a driver.
+type scheduledOperation struct {
+	// name of the operation, used in log messages.
+	name string
+	// scheduleAnnotation contains the schedule on the PVC or Namespace.
+	scheduleAnnotation string
+	// cronJobAnnotation contains the name of the child cronjob on the PVC.
+	cronJobAnnotation string
+	// driverAnnotation contains the drivers that support the operation on
+	// the Namespace.
+	driverAnnotation string
+	// supportedByDriver returns true if the driver supports the operation.
+	supportedByDriver func(driverName string) bool
+}
+
+// reclaimSpaceOperation returns the scheduledOperation of space reclamation.
+func (r *PersistentVolumeClaimReconciler) reclaimSpaceOperation() *scheduledOperation {
+	return &scheduledOperation{
+		name:               "spacereclamation",
+		scheduleAnnotation: rsCronJobScheduleTimeAnnotation,
+		cronJobAnnotation:  rsCronJobNameAnnotation,
+		driverAnnotation:   csiAddonsDriverAnnotation,
+		supportedByDriver:  r.supportsReclaimSpace,
+	}
+}
+
+// keyRotationOperation returns the scheduledOperation of encryption key
+// rotation.
+func (r *PersistentVolumeClaimReconciler) keyRotationOperation() *scheduledOperation {
+	return &scheduledOperation{
+		name:               "keyrotation",
+		scheduleAnnotation: krCronJobScheduleTimeAnnotation,
+		cronJobAnnotation:  krCronJobNameAnnotation,
+		driverAnnotation:   krDriverAnnotation,
+		supportedByDriver:  r.supportsEncryptionKeyRotation,
+	}
+}
+
 //+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;patch
 //+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims/finalizers,verbs=update
 //+kubebuilder:rbac:groups=csiaddons.openshift.io,resources=reclaimspacecronjobs,verbs=get;list;watch;create;delete;update
+//+kubebuilder:rbac:groups=csiaddons.openshift.io,resources=encryptionkeyrotationcronjobs,verbs=get;list;watch;create;delete;update
 //+kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.  This is
-// triggered when `reclaimspace.csiaddons.openshift/schedule` annotation is
-// found on newly created PVC or its found on the namespace or if there is a
-// change in value of the annotation. It is also triggered by any changes to
-// the child cronjob.
+// triggered when `reclaimspace.csiaddons.openshift/schedule` or
+// `keyrotation.csiaddons.openshift/schedule` annotation is found on newly
+// created PVC or its found on the namespace or if there is a change in value
+// of the annotation. It is also triggered by any changes to the child
+// cronjobs.
 func (r *PersistentVolumeClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
@@ -98,7 +144,7 @@ func (r *PersistentVolumeClaimReconciler) Reconcile(ctx context.Context, req ctr
 		// requeue the request
 		return ctrl.Result{Requeue: true}, nil
 	}
-	// get the driver name from PV to check if it supports space reclamation.
+	// get the driver name from PV to check if it supports the operations.
 	pv := &corev1.PersistentVolume{}
 
 	err = r.Client.Get(ctx, types.NamespacedName{Name: pvc.Spec.VolumeName}, pv)
@@ -112,55 +158,72 @@ func (r *PersistentVolumeClaimReconciler) Reconcile(ctx context.Context, req ctr
 		return ctrl.Result{}, nil
 	}
 
-	rsCronJob, err := r.findChildCronJob(ctx, &logger, &req)
+	// the operations are reconciled independently, a failure of one
+	// does not block the other.
+	rsLogger := logger.WithValues("Operation", "spacereclamation")
+	rsErr := r.processReclaimSpace(ctx, &rsLogger, &req, pvc, pv.Spec.CSI.Driver)
+	krLogger := logger.WithValues("Operation", "keyrotation")
+	krErr := r.processKeyRotation(ctx, &krLogger, &req, pvc, pv.Spec.CSI.Driver)
+
+	requeue := false
+	if errors.Is(rsErr, ErrConnNotFoundRequeueNeeded) {
+		requeue = true
+		rsErr = nil
+	}
+	if errors.Is(krErr, ErrConnNotFoundRequeueNeeded) {
+		requeue = true
+		krErr = nil
+	}
+	err = errors.Join(rsErr, krErr)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+
+	return ctrl.Result{Requeue: requeue}, nil
+}
+
+// processReclaimSpace creates, updates or deletes the child
+// ReclaimSpaceCronJob of the PVC, depending on the schedule.
+func (r *PersistentVolumeClaimReconciler) processReclaimSpace(
+	ctx context.Context,
+	logger *logr.Logger,
+	req *reconcile.Request,
+	pvc *corev1.PersistentVolumeClaim,
+	driverName string) error {
+	op := r.reclaimSpaceOperation()
+	rsCronJob, err := r.findChildCronJob(ctx, logger, req)
+	if err != nil {
+		return err
+	}
 	if rsCronJob != nil {
-		logger = logger.WithValues("ReclaimSpaceCronJobName", rsCronJob.Name)
+		*logger = logger.WithValues("ReclaimSpaceCronJobName", rsCronJob.Name)
 	}
 
-	schedule, err := r.determineScheduleAndRequeue(ctx, &logger, pvc, pv.Spec.CSI.Driver)
-	if errors.Is(err, ErrConnNotFoundRequeueNeeded) {
-		return ctrl.Result{Requeue: true}, nil
-	}
+	schedule, err := r.determineScheduleAndRequeue(ctx, logger, pvc, driverName, op)
 	if errors.Is(err, ErrScheduleNotFound) {
 		// if schedule is not found,
 		// delete cron job.
 		if rsCronJob != nil {
-			err = r.deleteChildCronJob(ctx, &logger, rsCronJob)
+			err = r.deleteChildCronJob(ctx, logger, rsCronJob)
 			if err != nil {
-				return ctrl.Result{}, err
+				return err
 			}
 		}
-		// delete name from annotation.
-		_, nameFound := pvc.Annotations[rsCronJobNameAnnotation]
-		if nameFound {
-			// remove name annotation by patching it to null.
-			patch := []byte(fmt.Sprintf(`{"metadata":{"annotations":{%q: null}}}`, rsCronJobNameAnnotation))
-			err = r.Client.Patch(ctx, pvc, client.RawPatch(types.StrategicMergePatchType, patch))
-			if err != nil {
-				logger.Error(err, "Failed to remove annotation")
 
-				return ctrl.Result{}, err
-			}
-		}
-		logger.Info("Annotation not set, exiting reconcile")
-		// no schedule annotation set, just dequeue.
-		return ctrl.Result{}, nil
+		return r.removeCronJobNameAnnotation(ctx, logger, pvc, op)
 	}
 	if err != nil {
-		return ctrl.Result{}, err
+		return err
 	}
 
-	logger = logger.WithValues("Schedule", schedule)
+	*logger = logger.WithValues("Schedule", schedule)
 
 	if rsCronJob != nil {
 		newRSCronJob := constructRSCronJob(rsCronJob.Name, req.Namespace, schedule, pvc.Name)
 		if reflect.DeepEqual(newRSCronJob.Spec, rsCronJob.Spec) {
 			logger.Info("No change in reclaimSpaceCronJob.Spec, exiting reconcile")
 
-			return ctrl.Result{}, nil
+			return nil
 		}
 		// update rsCronJob spec
 		rsCronJob.Spec = newRSCronJob.Spec
@@ -168,30 +231,18 @@ func (r *PersistentVolumeClaimReconciler) Reconcile(ctx context.Context, req ctr
 		if err != nil {
 			logger.Error(err, "Failed to update reclaimSpaceCronJob")
 
-			return ctrl.Result{}, err
+			return err
 		}
 		logger.Info("Successfully updated reclaimSpaceCronJob")
 
-		return ctrl.Result{}, nil
+		return nil
 	}
 
 	rsCronJobName := generateCronJobName(req.Name)
-	logger = logger.WithValues("ReclaimSpaceCronJobName", rsCronJobName)
-	// add cronjob name and schedule in annotations.
-	// adding annotation is required for the case when pvc does not have
-	// have schedule annotation but namespace has.
-	patch := []byte(fmt.Sprintf(`{"metadata":{"annotations":{%q:%q,%q:%q}}}`,
-		rsCronJobNameAnnotation,
-		rsCronJobName,
-		rsCronJobScheduleTimeAnnotation,
-		schedule,
-	))
-	logger.Info("Adding annotation", "Annotation", string(patch))
-	err = r.Client.Patch(ctx, pvc, client.RawPatch(types.StrategicMergePatchType, patch))
+	*logger = logger.WithValues("ReclaimSpaceCronJobName", rsCronJobName)
+	err = r.addCronJobAnnotations(ctx, logger, pvc, op, rsCronJobName, schedule)
 	if err != nil {
-		logger.Error(err, "Failed to update annotation")
-
-		return ctrl.Result{}, err
+		return err
 	}
 
 	rsCronJob = constructRSCronJob(rsCronJobName, req.Namespace, schedule, pvc.Name)
@@ -199,70 +250,205 @@ func (r *PersistentVolumeClaimReconciler) Reconcile(ctx context.Context, req ctr
 	if err != nil {
 		logger.Error(err, "Failed to set controllerReference")
 
-		return ctrl.Result{}, err
+		return err
 	}
 
 	err = r.Client.Create(ctx, rsCronJob)
 	if err != nil {
 		logger.Error(err, "Failed to create reclaimSpaceCronJob")
 
-		return ctrl.Result{}, err
+		return err
 	}
 	logger.Info("Successfully created reclaimSpaceCronJob")
 
-	return ctrl.Result{}, nil
+	return nil
 }
 
-// checkDriverSupportReclaimsSpace checks if the driver supports space
-// reclamation or not. If the driver does not support space reclamation, it
-// returns false and if the driver supports space reclamation, it returns true.
-// If the driver name is not registered in the connection pool, it returns
-// false and requeues the request.
-func (r *PersistentVolumeClaimReconciler) checkDriverSupportReclaimsSpace(logger *logr.Logger, annotations map[string]string, driver string) (bool, bool) {
-	reclaimSpaceSupportedByDriver := false
+// processKeyRotation creates, updates or deletes the child
+// EncryptionKeyRotationCronJob of the PVC, depending on the schedule.
+func (r *PersistentVolumeClaimReconciler) processKeyRotation(
+	ctx context.Context,
+	logger *logr.Logger,
+	req *reconcile.Request,
+	pvc *corev1.PersistentVolumeClaim,
+	driverName string) error {
+	op := r.keyRotationOperation()
+	krCronJob, err := r.findChildKRCronJob(ctx, logger, req)
+	if err != nil {
+		return err
+	}
+	if krCronJob != nil {
+		*logger = logger.WithValues("EncryptionKeyRotationCronJobName", krCronJob.Name)
+	}
+
+	schedule, err := r.determineScheduleAndRequeue(ctx, logger, pvc, driverName, op)
+	if errors.Is(err, ErrScheduleNotFound) {
+		if krCronJob != nil {
+			err = r.deleteChildCronJob(ctx, logger, krCronJob)
+			if err != nil {
+				return err
+			}
+		}
+
+		return r.removeCronJobNameAnnotation(ctx, logger, pvc, op)
+	}
+	if err != nil {
+		return err
+	}
+
+	*logger = logger.WithValues("Schedule", schedule)
+
+	if krCronJob != nil {
+		newKRCronJob := constructKRCronJob(krCronJob.Name, req.Namespace, schedule, pvc.Name)
+		if reflect.DeepEqual(newKRCronJob.Spec, krCronJob.Spec) {
+			logger.Info("No change in encryptionKeyRotationCronJob.Spec, exiting reconcile")
+
+			return nil
+		}
+		krCronJob.Spec = newKRCronJob.Spec
+		err = r.Client.Update(ctx, krCronJob)
+		if err != nil {
+			logger.Error(err, "Failed to update encryptionKeyRotationCronJob")
 
-	if drivers, ok := annotations[csiAddonsDriverAnnotation]; ok && util.ContainsInSlice(strings.Split(drivers, ","), driver) {
-		reclaimSpaceSupportedByDriver = true
+			return err
+		}
+		logger.Info("Successfully updated encryptionKeyRotationCronJob")
+
+		return nil
+	}
+
+	krCronJobName := generateCronJobName(req.Name)
+	*logger = logger.WithValues("EncryptionKeyRotationCronJobName", krCronJobName)
+	err = r.addCronJobAnnotations(ctx, logger, pvc, op, krCronJobName, schedule)
+	if err != nil {
+		return err
+	}
+
+	krCronJob = constructKRCronJob(krCronJobName, req.Namespace, schedule, pvc.Name)
+	err = ctrl.SetControllerReference(pvc, krCronJob, r.Scheme)
+	if err != nil {
+		logger.Error(err, "Failed to set controllerReference")
+
+		return err
 	}
 
-	ok := r.supportsReclaimSpace(driver)
-	if reclaimSpaceSupportedByDriver && !ok {
-		logger.Info("Driver supports spacereclamation but driver is not registered in the connection pool, Reqeueing request", "DriverName", driver)
+	err = r.Client.Create(ctx, krCronJob)
+	if err != nil {
+		logger.Error(err, "Failed to create encryptionKeyRotationCronJob")
+
+		return err
+	}
+	logger.Info("Successfully created encryptionKeyRotationCronJob")
+
+	return nil
+}
+
+// addCronJobAnnotations adds the name of the child cronjob and the schedule
+// of the operation to the annotations of the PVC. Adding the schedule is
+// required for the case when pvc does not have schedule annotation but
+// namespace has.
+func (r *PersistentVolumeClaimReconciler) addCronJobAnnotations(
+	ctx context.Context,
+	logger *logr.Logger,
+	pvc *corev1.PersistentVolumeClaim,
+	op *scheduledOperation,
+	cronJobName, schedule string) error {
+	patch := []byte(fmt.Sprintf(`{"metadata":{"annotations":{%q:%q,%q:%q}}}`,
+		op.cronJobAnnotation,
+		cronJobName,
+		op.scheduleAnnotation,
+		schedule,
+	))
+	logger.Info("Adding annotation", "Annotation", string(patch))
+	err := r.Client.Patch(ctx, pvc, client.RawPatch(types.StrategicMergePatchType, patch))
+	if err != nil {
+		logger.Error(err, "Failed to update annotation")
+
+		return err
+	}
+
+	return nil
+}
+
+// removeCronJobNameAnnotation removes the name of the child cronjob of the
+// operation from the annotations of the PVC, if it is set.
+func (r *PersistentVolumeClaimReconciler) removeCronJobNameAnnotation(
+	ctx context.Context,
+	logger *logr.Logger,
+	pvc *corev1.PersistentVolumeClaim,
+	op *scheduledOperation) error {
+	_, nameFound := pvc.Annotations[op.cronJobAnnotation]
+	if nameFound {
+		// remove name annotation by patching it to null.
+		patch := []byte(fmt.Sprintf(`{"metadata":{"annotations":{%q: null}}}`, op.cronJobAnnotation))
+		err := r.Client.Patch(ctx, pvc, client.RawPatch(types.StrategicMergePatchType, patch))
+		if err != nil {
+			logger.Error(err, "Failed to remove annotation")
+
+			return err
+		}
+	}
+	logger.Info("Annotation not set, exiting reconcile")
+
+	return nil
+}
+
+// checkDriverSupport checks if the driver supports the operation or not. If
+// the driver does not support the operation, it returns false and if the
+// driver supports the operation, it returns true. If the driver name is not
+// registered in the connection pool, it returns false and requeues the
+// request.
+func (r *PersistentVolumeClaimReconciler) checkDriverSupport(
+	logger *logr.Logger,
+	annotations map[string]string,
+	driver string,
+	op *scheduledOperation) (bool, bool) {
+	supportedByDriver := false
+
+	if drivers, ok := annotations[op.driverAnnotation]; ok && util.ContainsInSlice(strings.Split(drivers, ","), driver) {
+		supportedByDriver = true
+	}
+
+	ok := op.supportedByDriver(driver)
+	if supportedByDriver && !ok {
+		logger.Info(fmt.Sprintf("Driver supports %s but driver is not registered in the connection pool, Reqeueing request", op.name), "DriverName", driver)
 		return true, false
 	}
 
 	if !ok {
-		logger.Info("Driver does not support spacereclamation, skip Requeue", "DriverName", driver)
+		logger.Info(fmt.Sprintf("Driver does not support %s, skip Requeue", op.name), "DriverName", driver)
 		return false, false
 	}
 
 	return false, true
 }
 
-// determineScheduleAndRequeue determines the schedule using the following steps
+// determineScheduleAndRequeue determines the schedule of the operation using
+// the following steps
 //   - Check if the schedule is persent in the PVC annotations. If yes, use that.
 //   - Check if the schedule is present in the namespace annotations. If yes,
 //     use that.
 //   - If schedule is not present in namespace annotations, return ErrorScheduleNotFound.
-//   - If schedule is present in namespace annotations, check for reclaimSpace
-//     support by the driver.
-//   - If driver supports reclaimSpace, use the schedule from namespace.
-//   - If driver does not support reclaimSpace, return ErrScheduleNotFound.
+//   - If schedule is present in namespace annotations, check for the support
+//     of the operation by the driver.
+//   - If driver supports the operation, use the schedule from namespace.
+//   - If driver does not support the operation, return ErrScheduleNotFound.
 //     Depending on requeue value, it will throw ErrorConnNotFoundRequeueNeeded.
 func (r *PersistentVolumeClaimReconciler) determineScheduleAndRequeue(
 	ctx context.Context,
 	logger *logr.Logger,
 	pvc *corev1.PersistentVolumeClaim,
 	driverName string,
+	op *scheduledOperation,
 ) (string, error) {
 	annotations := pvc.GetAnnotations()
-	schedule, scheduleFound := getScheduleFromAnnotation(logger, annotations)
+	schedule, scheduleFound := getScheduleFromAnnotation(logger, op.scheduleAnnotation, annotations)
 	if scheduleFound {
 		return schedule, nil
 	}
 	// check for namespace schedule annotation.
 	// We cannot have a generic solution for all CSI drivers to get the driver
-	// name from PV and check if driver supports space reclamation or not and
+	// name from PV and check if driver supports the operation or not and
 	// requeue the request if the driver is not registered in the connection
 	// pool. This can put the controller in a requeue loop. Hence we are
 	// reading the driver name from the namespace annotation and checking if
@@ -274,17 +460,17 @@ func (r *PersistentVolumeClaimReconciler) determineScheduleAndRequeue(
 		logger.Error(err, "Failed to get Namespace", "Namespace", pvc.Namespace)
 		return "", err
 	}
-	schedule, scheduleFound = getScheduleFromAnnotation(logger, ns.Annotations)
+	schedule, scheduleFound = getScheduleFromAnnotation(logger, op.scheduleAnnotation, ns.Annotations)
 	if !scheduleFound {
 		return "", ErrScheduleNotFound
 	}
 	// If the schedule is found, check whether driver supports the
-	// space reclamation using annotation on namespace and registered driver
+	// operation using annotation on namespace and registered driver
 	// capability for decision on requeue.
 
-	requeue, supportReclaimspace := r.checkDriverSupportReclaimsSpace(logger, ns.Annotations, driverName)
-	if supportReclaimspace {
-		// if driver supports space reclamation,
+	requeue, supported := r.checkDriverSupport(logger, ns.Annotations, driverName, op)
+	if supported {
+		// if driver supports the operation,
 		// return schedule from ns annotation.
 		return schedule, nil
 	}
@@ -307,23 +493,37 @@ func (r *PersistentVolumeClaimReconciler) SetupWithManager(mgr ctrl.Manager, ctr
 	if err != nil {
 		return err
 	}
+	err = mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&csiaddonsv1alpha1.EncryptionKeyRotationCronJob{},
+		jobOwnerKey,
+		extractOwnerNameFromPVCObj)
+	if err != nil {
+		return err
+	}
 
 	pred := predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
 			if e.ObjectNew == nil || e.ObjectOld == nil {
 				return false
 			}
-			// reconcile only if schedule annotation between old and new objects have changed.
-			oldSchdeule, oldOk := e.ObjectOld.GetAnnotations()[rsCronJobScheduleTimeAnnotation]
-			newSchdeule, newOk := e.ObjectNew.GetAnnotations()[rsCronJobScheduleTimeAnnotation]
+			// reconcile only if schedule annotations between old and new objects have changed.
+			for _, key := range []string{rsCronJobScheduleTimeAnnotation, krCronJobScheduleTimeAnnotation} {
+				oldSchdeule, oldOk := e.ObjectOld.GetAnnotations()[key]
+				newSchdeule, newOk := e.ObjectNew.GetAnnotations()[key]
+				if oldOk != newOk || oldSchdeule != newSchdeule {
+					return true
+				}
+			}
 
-			return oldOk != newOk || oldSchdeule != newSchdeule
+			return false
 		},
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&corev1.PersistentVolumeClaim{}).
 		Owns(&csiaddonsv1alpha1.ReclaimSpaceCronJob{}).
+		Owns(&csiaddonsv1alpha1.EncryptionKeyRotationCronJob{}).
 		WithEventFilter(pred).
 		WithOptions(ctrlOptions).
 		Complete(r)
@@ -367,26 +567,62 @@ func (r *PersistentVolumeClaimReconciler) findChildCronJob(
 func (r *PersistentVolumeClaimReconciler) deleteChildCronJob(
 	ctx context.Context,
 	logger *logr.Logger,
-	job *csiaddonsv1alpha1.ReclaimSpaceCronJob) error {
+	job client.Object) error {
 	err := r.Delete(ctx, job)
 	if client.IgnoreNotFound(err) != nil {
-		logger.Error(err, "Failed to delete child reclaimSpaceCronJob",
-			"ReclaimSpaceCronJobName", job.Name)
+		logger.Error(err, "Failed to delete child cronjob",
+			"CronJobName", job.GetName())
 
-		return fmt.Errorf("Failed to delete child reclaimSpaceCronJob %q: %w",
-			job.Name, err)
+		return fmt.Errorf("Failed to delete child cronjob %q: %w",
+			job.GetName(), err)
 	}
 
 	return nil
 }
 
-// getScheduleFromAnnotation parses schedule and returns it.
+// findChildKRCronJob lists child encryptionKeyRotationCronJobs, returns the
+// first cronjob and deletes the rest if there are more than one cronjob.
+func (r *PersistentVolumeClaimReconciler) findChildKRCronJob(
+	ctx context.Context,
+	logger *logr.Logger,
+	req *reconcile.Request) (*csiaddonsv1alpha1.EncryptionKeyRotationCronJob, error) {
+	var childJobs csiaddonsv1alpha1.EncryptionKeyRotationCronJobList
+	err := r.List(ctx,
+		&childJobs,
+		client.InNamespace(req.Namespace),
+		client.MatchingFields{jobOwnerKey: req.Name})
+	if err != nil {
+		logger.Error(err, "Failed to list child encryptionKeyRotationCronJobs")
+
+		return nil, fmt.Errorf("Failed to list child encryptionKeyRotationCronJob: %v", err)
+	}
+
+	var activeJob *csiaddonsv1alpha1.EncryptionKeyRotationCronJob
+	for i := range childJobs.Items {
+		if i == 0 {
+			activeJob = &childJobs.Items[i]
+			continue
+		}
+		// there should be only one child cronjob, delete rest if they
+		// exist
+		err = r.deleteChildCronJob(ctx, logger, &childJobs.Items[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return activeJob, nil
+}
+
+// getScheduleFromAnnotation parses the schedule in the annotation with the
+// given key and returns it.
 // A error is logged and default schedule is returned if it
 // is not in cron format.
 func getScheduleFromAnnotation(
 	logger *logr.Logger,
+	key string,
 	annotations map[string]string) (string, bool) {
-	schedule, ok := annotations[rsCronJobScheduleTimeAnnotation]
+	schedule, ok := annotations[key]
 	if !ok {
 		return "", false
 	}
@@ -428,15 +664,41 @@ func constructRSCronJob(name, namespace, schedule, pvcName string) *csiaddonsv1a
 	}
 }
 
+// constructKRCronJob constructs and returns EncryptionKeyRotationCronJob.
+func constructKRCronJob(name, namespace, schedule, pvcName string) *csiaddonsv1alpha1.EncryptionKeyRotationCronJob {
+	failedJobsHistoryLimit := defaultFailedJobsHistoryLimit
+	successfulJobsHistoryLimit := defaultSuccessfulJobsHistoryLimit
+	return &csiaddonsv1alpha1.EncryptionKeyRotationCronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: csiaddonsv1alpha1.EncryptionKeyRotationCronJobSpec{
+			Schedule: schedule,
+			JobSpec: csiaddonsv1alpha1.EncryptionKeyRotationJobTemplateSpec{
+				Spec: csiaddonsv1alpha1.EncryptionKeyRotationJobSpec{
+					Target:               csiaddonsv1alpha1.TargetSpec{PersistentVolumeClaim: pvcName},
+					BackoffLimit:         defaultBackoffLimit,
+					RetryDeadlineSeconds: defaultRetryDeadlineSeconds,
+				},
+			},
+			FailedJobsHistoryLimit:     &failedJobsHistoryLimit,
+			SuccessfulJobsHistoryLimit: &successfulJobsHistoryLimit,
+		},
+	}
+}
+
 // extractOwnerNameFromPVCObj extracts owner.Name from the object if it is
-// of type ReclaimSpaceCronJob and has a PVC as its owner.
+// of type ReclaimSpaceCronJob or EncryptionKeyRotationCronJob and has a PVC
+// as its owner.
 func extractOwnerNameFromPVCObj(rawObj client.Object) []string {
 	// extract the owner from job object.
-	job, ok := rawObj.(*csiaddonsv1alpha1.ReclaimSpaceCronJob)
-	if !ok {
+	switch rawObj.(type) {
+	case *csiaddonsv1alpha1.ReclaimSpaceCronJob, *csiaddonsv1alpha1.EncryptionKeyRotationCronJob:
+	default:
 		return nil
 	}
-	owner := metav1.GetControllerOf(job)
+	owner := metav1.GetControllerOf(rawObj)
 	if owner == nil {
 		return nil
 	}
@@ -466,3 +728,16 @@ func (r PersistentVolumeClaimReconciler) supportsReclaimSpace(driverName string)
 
 	return false
 }
+
+// supportsEncryptionKeyRotation checks if the CSI driver supports
+// EncryptionKeyRotation.
+func (r PersistentVolumeClaimReconciler) supportsEncryptionKeyRotation(driverName string) bool {
+	conns := r.ConnPool.GetByNodeID(driverName, "")
+	for _, v := range conns {
+		if extensions.HasServiceCapability(v.Capabilities, extensions.Capability_Service_ENCRYPTION_KEY_ROTATION) {
+			return true
+		}
+	}
+
+	return false
+}
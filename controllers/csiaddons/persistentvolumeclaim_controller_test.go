@@ -80,6 +80,32 @@ func TestConstructRSCronJob(t *testing.T) {
 	}
 }
 
+func TestConstructKRCronJob(t *testing.T) {
+	failedJobsHistoryLimit := defaultFailedJobsHistoryLimit
+	successfulJobsHistoryLimit := defaultSuccessfulJobsHistoryLimit
+	want := &csiaddonsv1alpha1.EncryptionKeyRotationCronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "hello",
+			Namespace: "default",
+		},
+		Spec: csiaddonsv1alpha1.EncryptionKeyRotationCronJobSpec{
+			Schedule: "@monthly",
+			JobSpec: csiaddonsv1alpha1.EncryptionKeyRotationJobTemplateSpec{
+				Spec: csiaddonsv1alpha1.EncryptionKeyRotationJobSpec{
+					Target:               csiaddonsv1alpha1.TargetSpec{PersistentVolumeClaim: "pvc-1"},
+					BackoffLimit:         defaultBackoffLimit,
+					RetryDeadlineSeconds: defaultRetryDeadlineSeconds,
+				},
+			},
+			FailedJobsHistoryLimit:     &failedJobsHistoryLimit,
+			SuccessfulJobsHistoryLimit: &successfulJobsHistoryLimit,
+		},
+	}
+
+	got := constructKRCronJob("hello", "default", "@monthly", "pvc-1")
+	assert.Equal(t, want, got)
+}
+
 func TestExtractOwnerNameFromPVCObj(t *testing.T) {
 	type args struct {
 		rawObj client.Object
@@ -151,6 +177,24 @@ func TestExtractOwnerNameFromPVCObj(t *testing.T) {
 			},
 			want: nil,
 		},
+		{
+			name: "encryptionKeyRotationCron obj with pvc owner",
+			args: args{
+				rawObj: &csiaddonsv1alpha1.EncryptionKeyRotationCronJob{
+					ObjectMeta: metav1.ObjectMeta{
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								APIVersion: "v1",
+								Kind:       "PersistentVolumeClaim",
+								Name:       "owner",
+								Controller: &boolTrue,
+							},
+						},
+					},
+				},
+			},
+			want: []string{"owner"},
+		},
 		{
 			name: "pvc obj with no owner",
 			args: args{
@@ -199,6 +243,7 @@ func TestGenerateCronJobName(t *testing.T) {
 func TestGetScheduleFromAnnotation(t *testing.T) {
 	type args struct {
 		logger      *logr.Logger
+		key         string
 		annotations map[string]string
 	}
 	logger := log.FromContext(context.TODO())
@@ -212,6 +257,7 @@ func TestGetScheduleFromAnnotation(t *testing.T) {
 			name: "no scheduling annotation set",
 			args: args{
 				logger:      &logger,
+				key:         rsCronJobScheduleTimeAnnotation,
 				annotations: map[string]string{},
 			},
 			want:  "",
@@ -221,6 +267,7 @@ func TestGetScheduleFromAnnotation(t *testing.T) {
 			name: "valid scheduling annotation set",
 			args: args{
 				logger: &logger,
+				key:    rsCronJobScheduleTimeAnnotation,
 				annotations: map[string]string{
 					rsCronJobScheduleTimeAnnotation: "@weekly",
 				},
@@ -228,10 +275,35 @@ func TestGetScheduleFromAnnotation(t *testing.T) {
 			want:  "@weekly",
 			want1: true,
 		},
+		{
+			name: "scheduling annotation of other operation set",
+			args: args{
+				logger: &logger,
+				key:    krCronJobScheduleTimeAnnotation,
+				annotations: map[string]string{
+					rsCronJobScheduleTimeAnnotation: "@weekly",
+				},
+			},
+			want:  "",
+			want1: false,
+		},
+		{
+			name: "valid key rotation scheduling annotation set",
+			args: args{
+				logger: &logger,
+				key:    krCronJobScheduleTimeAnnotation,
+				annotations: map[string]string{
+					krCronJobScheduleTimeAnnotation: "@monthly",
+				},
+			},
+			want:  "@monthly",
+			want1: true,
+		},
 		{
 			name: "invalid scheduling annotation set",
 			args: args{
 				logger: &logger,
+				key:    rsCronJobScheduleTimeAnnotation,
 				annotations: map[string]string{
 					rsCronJobScheduleTimeAnnotation: "@daytime",
 				},
@@ -242,7 +314,7 @@ func TestGetScheduleFromAnnotation(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, got1 := getScheduleFromAnnotation(tt.args.logger, tt.args.annotations)
+			got, got1 := getScheduleFromAnnotation(tt.args.logger, tt.args.key, tt.args.annotations)
 			assert.Equal(t, tt.want, got)
 			assert.Equal(t, tt.want1, got1)
 		})
@@ -129,7 +129,12 @@ func (r *ReclaimSpaceCronJobReconciler) Reconcile(ctx context.Context, req ctrl.
 	}
 
 	// figure out the next times that we need to create jobs at (or anything we missed).
-	missedRun, nextRun, err := getNextSchedule(rsCronJob, time.Now())
+	missedRun, nextRun, err := getNextSchedule(
+		rsCronJob.Spec.Schedule,
+		rsCronJob.Spec.StartingDeadlineSeconds,
+		rsCronJob.Status.LastScheduleTime,
+		rsCronJob.CreationTimestamp,
+		time.Now())
 	if err != nil {
 		logger.Error(err, "Failed to Parse out CronJob schedule", "schedule", rsCronJob.Spec.Schedule)
 		// invalid schedule, do not requeue.
@@ -315,7 +320,7 @@ func parseJobList(logger *logr.Logger, childJobs *csiaddonsv1alpha1.ReclaimSpace
 		}
 
 		// reconstitute scheduled time from annotation.
-		scheduledTimeForJob, err := getScheduledTimeForRSJob(&job)
+		scheduledTimeForJob, err := getScheduledTimeForJob(&childJobs.Items[i])
 		if err != nil {
 			logger.Error(err, "Failed to parse schedule time for child ReclaimSpaceJob", "ReclaimSpaceJob", job.Name)
 			continue
@@ -337,10 +342,10 @@ func parseJobList(logger *logr.Logger, childJobs *csiaddonsv1alpha1.ReclaimSpace
 	}
 }
 
-// getScheduledTimeForRSJob extract the scheduled time from the annotation that
+// getScheduledTimeForJob extract the scheduled time from the annotation that
 // is added during job creation.
-func getScheduledTimeForRSJob(rsJob *csiaddonsv1alpha1.ReclaimSpaceJob) (*time.Time, error) {
-	timeRaw := rsJob.Annotations[scheduledTimeAnnotation]
+func getScheduledTimeForJob(job metav1.Object) (*time.Time, error) {
+	timeRaw := job.GetAnnotations()[scheduledTimeAnnotation]
 	if len(timeRaw) == 0 {
 		return nil, nil
 	}
@@ -353,25 +358,29 @@ func getScheduledTimeForRSJob(rsJob *csiaddonsv1alpha1.ReclaimSpaceJob) (*time.T
 }
 
 // TODO: add unit test for getNextSchedule
-// getNextSchedule returns lastMissed and next time after parsing the schedule.
+// getNextSchedule returns lastMissed and next time after parsing the schedule
+// of a cronjob.
 // This function returns error if there are more than 100 missed start times.
 func getNextSchedule(
-	rsCronJob *csiaddonsv1alpha1.ReclaimSpaceCronJob,
+	schedule string,
+	startingDeadlineSeconds *int64,
+	lastScheduleTime *metav1.Time,
+	creationTime metav1.Time,
 	now time.Time) (time.Time, time.Time, error) {
-	sched, err := cron.ParseStandard(rsCronJob.Spec.Schedule)
+	sched, err := cron.ParseStandard(schedule)
 	if err != nil {
-		return time.Time{}, time.Time{}, fmt.Errorf("Unparsable schedule %q: %v", rsCronJob.Spec.Schedule, err)
+		return time.Time{}, time.Time{}, fmt.Errorf("Unparsable schedule %q: %v", schedule, err)
 	}
 
 	var earliestTime time.Time
-	if rsCronJob.Status.LastScheduleTime != nil {
-		earliestTime = rsCronJob.Status.LastScheduleTime.Time
+	if lastScheduleTime != nil {
+		earliestTime = lastScheduleTime.Time
 	} else {
-		earliestTime = rsCronJob.ObjectMeta.CreationTimestamp.Time
+		earliestTime = creationTime.Time
 	}
-	if rsCronJob.Spec.StartingDeadlineSeconds != nil {
+	if startingDeadlineSeconds != nil {
 		// controller is not going to schedule anything below this point
-		schedulingDeadline := now.Add(-time.Second * time.Duration(*rsCronJob.Spec.StartingDeadlineSeconds))
+		schedulingDeadline := now.Add(-time.Second * time.Duration(*startingDeadlineSeconds))
 
 		if schedulingDeadline.After(earliestTime) {
 			earliestTime = schedulingDeadline
@@ -394,7 +403,7 @@ func getNextSchedule(
 			return time.Time{}, time.Time{},
 				fmt.Errorf("too many missed start times (> 100). Set or decrease" +
 					".spec.startingDeadlineSeconds, check clock skew or" +
-					" delete and recreate reclaimspacecronjob.")
+					" delete and recreate the cronjob.")
 		}
 	}
 	return lastMissed, sched.Next(now), nil
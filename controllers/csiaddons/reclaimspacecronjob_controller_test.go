@@ -27,7 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func TestGetScheduledTimeForRSJob(t *testing.T) {
+func TestGetScheduledTimeForJob(t *testing.T) {
 	type args struct {
 		rsJob *csiaddonsv1alpha1.ReclaimSpaceJob
 	}
@@ -96,9 +96,9 @@ func TestGetScheduledTimeForRSJob(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := getScheduledTimeForRSJob(tt.args.rsJob)
+			got, err := getScheduledTimeForJob(tt.args.rsJob)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("getScheduledTimeForRSJob() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("getScheduledTimeForJob() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			fmt.Println(tt.want, got)
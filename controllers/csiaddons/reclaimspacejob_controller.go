@@ -212,7 +212,8 @@ func (r *ReclaimSpaceJobReconciler) reconcile(
 		return nil
 	}
 
-	target, err := r.getTargetDetails(ctx, logger, rsJob.Spec, namespace)
+	target, err := getTargetDetails(ctx, r.Client, logger,
+		rsJob.Spec.Target.PersistentVolumeClaim, namespace, r.Timeout, rsJob.Spec.Timeout)
 	if err != nil {
 		logger.Error(err, "Failed to get target details")
 		setFailedCondition(
@@ -281,17 +282,21 @@ func (r *ReclaimSpaceJobReconciler) reconcile(
 	return nil
 }
 
-// getTargetDetails fetches driverName, pvName and nodeID in targetDetails struct.
-func (r *ReclaimSpaceJobReconciler) getTargetDetails(
+// getTargetDetails fetches driverName, pvName and nodeID of the
+// PersistentVolumeClaim in targetDetails struct. The timeout overrides the
+// defaultTimeout when it is set.
+func getTargetDetails(
 	ctx context.Context,
+	c client.Client,
 	logger *logr.Logger,
-	spec csiaddonsv1alpha1.ReclaimSpaceJobSpec,
-	namespace string) (*targetDetails, error) {
-	*logger = logger.WithValues("PVCName", spec.Target.PersistentVolumeClaim, "PVCNamespace", namespace)
-	req := types.NamespacedName{Name: spec.Target.PersistentVolumeClaim, Namespace: namespace}
+	pvcName, namespace string,
+	defaultTimeout time.Duration,
+	timeout *int64) (*targetDetails, error) {
+	*logger = logger.WithValues("PVCName", pvcName, "PVCNamespace", namespace)
+	req := types.NamespacedName{Name: pvcName, Namespace: namespace}
 	pvc := &corev1.PersistentVolumeClaim{}
 
-	err := r.Client.Get(ctx, req, pvc)
+	err := c.Get(ctx, req, pvc)
 	if err != nil {
 		return nil, err
 	}
@@ -305,7 +310,7 @@ func (r *ReclaimSpaceJobReconciler) getTargetDetails(
 	pv := &corev1.PersistentVolume{}
 	req = types.NamespacedName{Name: pvc.Spec.VolumeName}
 
-	err = r.Client.Get(ctx, req, pv)
+	err = c.Get(ctx, req, pv)
 	if err != nil {
 		return nil, err
 	}
@@ -315,7 +320,7 @@ func (r *ReclaimSpaceJobReconciler) getTargetDetails(
 	}
 
 	volumeAttachments := &scv1.VolumeAttachmentList{}
-	err = r.Client.List(ctx, volumeAttachments)
+	err = c.List(ctx, volumeAttachments)
 	if err != nil {
 		return nil, err
 	}
@@ -323,20 +328,17 @@ func (r *ReclaimSpaceJobReconciler) getTargetDetails(
 	details := targetDetails{
 		driverName: pv.Spec.CSI.Driver,
 		pvName:     pv.Name,
+		nodeID:     getAttachedNodeID(volumeAttachments.Items, pv.Name),
 		// Set global default timeout.
-		timeout: r.Timeout,
+		timeout: defaultTimeout,
 	}
-	for _, v := range volumeAttachments.Items {
-		if v.DeletionTimestamp.IsZero() && v.Status.Attached && *v.Spec.Source.PersistentVolumeName == pv.Name {
-			*logger = logger.WithValues("NodeID", v.Spec.NodeName)
-			details.nodeID = v.Spec.NodeName
-			break
-		}
+	if details.nodeID != "" {
+		*logger = logger.WithValues("NodeID", details.nodeID)
 	}
 	// Override global default timeout if timeout is specified
 	// in spec.
-	if spec.Timeout != nil {
-		details.timeout = time.Second * time.Duration(*spec.Timeout)
+	if timeout != nil {
+		details.timeout = time.Second * time.Duration(*timeout)
 	}
 	*logger = logger.WithValues("Timeout", details.timeout)
 
@@ -0,0 +1,507 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	csiaddonsv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/csiaddons/v1alpha1"
+	extensions "github.com/csi-addons/kubernetes-csi-addons/extensions/v1alpha1"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/connection"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/proto"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	scv1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// PersistentVolumeClaimVolumeHealthy is the condition of the
+	// PersistentVolumeClaim that reports the health of the volume.
+	PersistentVolumeClaimVolumeHealthy corev1.PersistentVolumeClaimConditionType = "VolumeHealthy"
+
+	reasonVolumeConditionNormal      = "VolumeConditionNormal"
+	reasonVolumeConditionAbnormal    = "VolumeConditionAbnormal"
+	reasonVolumeConditionNotReported = "VolumeConditionNotReported"
+	reasonVolumeNotAttached          = "VolumeNotAttached"
+
+	// volumeAttachmentPVKey is the index of the VolumeAttachments by
+	// the name of their PersistentVolume.
+	volumeAttachmentPVKey = "spec.source.persistentVolumeName"
+)
+
+var (
+	volumeHealthAbnormal = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "csiaddons_volume_health_abnormal",
+			Help: "Whether the volume of the PersistentVolumeClaim is in an abnormal condition (1) or not (0).",
+		},
+		[]string{"namespace", "persistentvolumeclaim", "driver"})
+
+	volumeHealthChecks = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "csiaddons_volume_health_checks_total",
+			Help: "Number of volume health checks, by driver and result.",
+		},
+		[]string{"driver", "result"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(volumeHealthAbnormal, volumeHealthChecks)
+}
+
+// VolumeHealthReconciler reconciles a PersistentVolumeClaim object, and
+// checks the health of its volume periodically while it is attached.
+type VolumeHealthReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// ConnectionPool consists of map of Connection objects.
+	ConnPool *connection.ConnectionPool
+	// Timeout for the health check of a volume.
+	Timeout time.Duration
+	// Interval at which the health of a volume is checked.
+	Interval time.Duration
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",resources=persistentvolumes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=storage.k8s.io,resources=volumeattachments,verbs=get;list;watch
+//+kubebuilder:rbac:groups=csiaddons.openshift.io,resources=csiaddonsnodes,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile checks the health of the volume of the PersistentVolumeClaim on
+// the node where it is attached, and reports it in the VolumeHealthy
+// condition of the PersistentVolumeClaim, with Events and metrics.
+func (r *VolumeHealthReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	err := r.Client.Get(ctx, req.NamespacedName, pvc)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			deleteVolumeHealthMetrics(req.Namespace, req.Name)
+
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get PersistentVolumeClaim")
+
+		return ctrl.Result{}, err
+	}
+
+	if !pvc.DeletionTimestamp.IsZero() || pvc.Status.Phase != corev1.ClaimBound {
+		deleteVolumeHealthMetrics(req.Namespace, req.Name)
+
+		return ctrl.Result{}, nil
+	}
+
+	pv := &corev1.PersistentVolume{}
+	err = r.Client.Get(ctx, types.NamespacedName{Name: pvc.Spec.VolumeName}, pv)
+	if err != nil {
+		logger.Error(err, "Failed to get PersistentVolume", "PVName", pvc.Spec.VolumeName)
+
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if pv.Spec.CSI == nil {
+		return ctrl.Result{}, nil
+	}
+	driver := pv.Spec.CSI.Driver
+	logger = logger.WithValues("PVName", pv.Name, "DriverName", driver)
+
+	// PersistentVolumeClaims of drivers without a sidecar that checks the
+	// health of volumes are not requeued, they are reconciled again when
+	// a sidecar of the driver connects.
+	if !r.supportsVolumeHealth(driver) {
+		return ctrl.Result{}, nil
+	}
+	result := ctrl.Result{RequeueAfter: r.Interval}
+
+	volumeAttachments := &scv1.VolumeAttachmentList{}
+	err = r.Client.List(ctx, volumeAttachments, client.MatchingFields{volumeAttachmentPVKey: pv.Name})
+	if err != nil {
+		logger.Error(err, "Failed to list VolumeAttachments")
+
+		return ctrl.Result{}, err
+	}
+	nodeID := getAttachedNodeID(volumeAttachments.Items, pv.Name)
+	if nodeID == "" {
+		deleteVolumeHealthMetrics(req.Namespace, req.Name)
+
+		// the condition is only updated when it was reported
+		// before, to not update every unattached volume.
+		if getVolumeHealthCondition(pvc) == nil {
+			return result, nil
+		}
+
+		err = r.updateVolumeHealthCondition(ctx, pvc, corev1.ConditionUnknown,
+			reasonVolumeNotAttached, "volume is not attached to a node")
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		return result, nil
+	}
+	logger = logger.WithValues("NodeID", nodeID)
+
+	clientName, healthClient := r.getVolumeHealthClient(driver, nodeID)
+	if healthClient == nil {
+		logger.Info("Volume health client not found")
+
+		return result, nil
+	}
+	logger = logger.WithValues("nodeClient", clientName)
+
+	newCtx, cancel := context.WithTimeout(ctx, r.Timeout)
+	defer cancel()
+	resp, err := healthClient.NodeGetVolumeHealth(newCtx, &proto.VolumeHealthRequest{PvName: pv.Name})
+	switch {
+	case status.Code(err) == codes.Unimplemented || status.Code(err) == codes.FailedPrecondition:
+		// the driver does not report the condition of the volume,
+		// or the volume is not published on the node.
+		logger.Info("Volume condition is not reported", "error", err)
+		volumeHealthChecks.WithLabelValues(driver, "unknown").Inc()
+		deleteVolumeHealthMetrics(req.Namespace, req.Name)
+
+		err = r.updateVolumeHealthCondition(ctx, pvc, corev1.ConditionUnknown,
+			reasonVolumeConditionNotReported, status.Convert(err).Message())
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		return result, nil
+
+	case err != nil:
+		logger.Error(err, "Failed to get volume health")
+		volumeHealthChecks.WithLabelValues(driver, "error").Inc()
+
+		return result, nil
+	}
+
+	conditionStatus := corev1.ConditionTrue
+	reason := reasonVolumeConditionNormal
+	checkResult := "normal"
+	abnormal := 0.0
+	if resp.GetAbnormal() {
+		conditionStatus = corev1.ConditionFalse
+		reason = reasonVolumeConditionAbnormal
+		checkResult = "abnormal"
+		abnormal = 1
+	}
+	volumeHealthChecks.WithLabelValues(driver, checkResult).Inc()
+	volumeHealthAbnormal.WithLabelValues(req.Namespace, req.Name, driver).Set(abnormal)
+
+	err = r.updateVolumeHealthCondition(ctx, pvc, conditionStatus, reason, resp.GetMessage())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return result, nil
+}
+
+// updateVolumeHealthCondition sets the VolumeHealthy condition of the
+// PersistentVolumeClaim, and records an Event when the volume becomes
+// abnormal, or recovers. The status is only updated when the condition
+// changed.
+func (r *VolumeHealthReconciler) updateVolumeHealthCondition(
+	ctx context.Context,
+	pvc *corev1.PersistentVolumeClaim,
+	conditionStatus corev1.ConditionStatus,
+	reason, message string) error {
+	logger := log.FromContext(ctx)
+
+	previous := corev1.ConditionUnknown
+	if cond := getVolumeHealthCondition(pvc); cond != nil {
+		previous = cond.Status
+	}
+
+	if !setVolumeHealthCondition(pvc, conditionStatus, reason, message) {
+		return nil
+	}
+
+	err := r.Client.Status().Update(ctx, pvc)
+	if err != nil {
+		logger.Error(err, "Failed to update PersistentVolumeClaim status")
+
+		return err
+	}
+
+	switch {
+	case conditionStatus == corev1.ConditionFalse && previous != corev1.ConditionFalse:
+		r.Recorder.Event(pvc, corev1.EventTypeWarning, reasonVolumeConditionAbnormal, message)
+	case conditionStatus == corev1.ConditionTrue && previous == corev1.ConditionFalse:
+		r.Recorder.Event(pvc, corev1.EventTypeNormal, reasonVolumeConditionNormal, message)
+	}
+
+	return nil
+}
+
+// getVolumeHealthCondition returns the VolumeHealthy condition of the
+// PersistentVolumeClaim, or nil if it is not set.
+func getVolumeHealthCondition(pvc *corev1.PersistentVolumeClaim) *corev1.PersistentVolumeClaimCondition {
+	for i := range pvc.Status.Conditions {
+		if pvc.Status.Conditions[i].Type == PersistentVolumeClaimVolumeHealthy {
+			return &pvc.Status.Conditions[i]
+		}
+	}
+
+	return nil
+}
+
+// setVolumeHealthCondition sets the VolumeHealthy condition of the
+// PersistentVolumeClaim, and returns true if the condition changed.
+func setVolumeHealthCondition(
+	pvc *corev1.PersistentVolumeClaim,
+	conditionStatus corev1.ConditionStatus,
+	reason, message string) bool {
+	now := metav1.Now()
+
+	cond := getVolumeHealthCondition(pvc)
+	if cond == nil {
+		pvc.Status.Conditions = append(pvc.Status.Conditions, corev1.PersistentVolumeClaimCondition{
+			Type:               PersistentVolumeClaimVolumeHealthy,
+			Status:             conditionStatus,
+			Reason:             reason,
+			Message:            message,
+			LastProbeTime:      now,
+			LastTransitionTime: now,
+		})
+
+		return true
+	}
+
+	if cond.Status == conditionStatus && cond.Reason == reason && cond.Message == message {
+		return false
+	}
+
+	if cond.Status != conditionStatus {
+		cond.LastTransitionTime = now
+	}
+	cond.Status = conditionStatus
+	cond.Reason = reason
+	cond.Message = message
+	cond.LastProbeTime = now
+
+	return true
+}
+
+// getAttachedNodeID returns the node where the PersistentVolume is attached,
+// or an empty string if it is not attached.
+func getAttachedNodeID(volumeAttachments []scv1.VolumeAttachment, pvName string) string {
+	for _, v := range volumeAttachments {
+		if v.DeletionTimestamp.IsZero() && v.Status.Attached &&
+			v.Spec.Source.PersistentVolumeName != nil && *v.Spec.Source.PersistentVolumeName == pvName {
+			return v.Spec.NodeName
+		}
+	}
+
+	return ""
+}
+
+// deleteVolumeHealthMetrics removes the health of the volume of the
+// PersistentVolumeClaim from the metrics.
+func deleteVolumeHealthMetrics(namespace, name string) {
+	volumeHealthAbnormal.DeletePartialMatch(prometheus.Labels{
+		"namespace":             namespace,
+		"persistentvolumeclaim": name,
+	})
+}
+
+// supportsVolumeHealth checks if the CSI driver supports VolumeHealth.
+func (r *VolumeHealthReconciler) supportsVolumeHealth(driverName string) bool {
+	conns := r.ConnPool.GetByNodeID(driverName, "")
+	for _, v := range conns {
+		if extensions.HasServiceCapability(v.Capabilities, extensions.Capability_Service_VOLUME_HEALTH) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getVolumeHealthClient returns VolumeHealthClient given driverName and
+// nodeID.
+func (r *VolumeHealthReconciler) getVolumeHealthClient(driverName, nodeID string) (string, proto.VolumeHealthClient) {
+	conns := r.ConnPool.GetByNodeID(driverName, nodeID)
+	for k, v := range conns {
+		if extensions.HasServiceCapability(v.Capabilities, extensions.Capability_Service_VOLUME_HEALTH) {
+			return k, proto.NewVolumeHealthClient(v.Client)
+		}
+	}
+
+	return "", nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VolumeHealthReconciler) SetupWithManager(mgr ctrl.Manager, ctrlOptions controller.Options) error {
+	err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&scv1.VolumeAttachment{},
+		volumeAttachmentPVKey,
+		indexVolumeAttachmentByPersistentVolume)
+	if err != nil {
+		return err
+	}
+
+	// the updates of the status by this controller are ignored, the
+	// health of a volume is checked when it is bound, and then at the
+	// interval.
+	pvcPred := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPVC, oldOk := e.ObjectOld.(*corev1.PersistentVolumeClaim)
+			newPVC, newOk := e.ObjectNew.(*corev1.PersistentVolumeClaim)
+			if !oldOk || !newOk {
+				return false
+			}
+
+			return oldPVC.Status.Phase != newPVC.Status.Phase
+		},
+	}
+	vaPred := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldVA, oldOk := e.ObjectOld.(*scv1.VolumeAttachment)
+			newVA, newOk := e.ObjectNew.(*scv1.VolumeAttachment)
+			if !oldOk || !newOk {
+				return false
+			}
+
+			return oldVA.Status.Attached != newVA.Status.Attached
+		},
+	}
+	// the CSIAddonsNodes are watched to pick up the PersistentVolumeClaims
+	// of a driver once its sidecars have connected.
+	nodePred := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNode, oldOk := e.ObjectOld.(*csiaddonsv1alpha1.CSIAddonsNode)
+			newNode, newOk := e.ObjectNew.(*csiaddonsv1alpha1.CSIAddonsNode)
+			if !oldOk || !newOk {
+				return false
+			}
+
+			return oldNode.Status.State != newNode.Status.State &&
+				newNode.Status.State == csiaddonsv1alpha1.CSIAddonsNodeStateConnected
+		},
+		DeleteFunc: func(_ event.DeleteEvent) bool {
+			return false
+		},
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("volumehealth").
+		For(&corev1.PersistentVolumeClaim{}, builder.WithPredicates(pvcPred)).
+		Watches(
+			&scv1.VolumeAttachment{},
+			handler.EnqueueRequestsFromMapFunc(r.findPersistentVolumeClaimForVolumeAttachment),
+			builder.WithPredicates(vaPred),
+		).
+		Watches(
+			&csiaddonsv1alpha1.CSIAddonsNode{},
+			handler.EnqueueRequestsFromMapFunc(r.findPersistentVolumeClaimsForCSIAddonsNode),
+			builder.WithPredicates(nodePred),
+		).
+		WithOptions(ctrlOptions).
+		Complete(r)
+}
+
+// indexVolumeAttachmentByPersistentVolume returns the name of the
+// PersistentVolume of the VolumeAttachment.
+func indexVolumeAttachmentByPersistentVolume(rawObj client.Object) []string {
+	va, ok := rawObj.(*scv1.VolumeAttachment)
+	if !ok || va.Spec.Source.PersistentVolumeName == nil {
+		return nil
+	}
+
+	return []string{*va.Spec.Source.PersistentVolumeName}
+}
+
+// findPersistentVolumeClaimForVolumeAttachment returns a reconcile request
+// for the PersistentVolumeClaim that is bound to the attached
+// PersistentVolume.
+func (r *VolumeHealthReconciler) findPersistentVolumeClaimForVolumeAttachment(
+	ctx context.Context,
+	obj client.Object) []reconcile.Request {
+	va, ok := obj.(*scv1.VolumeAttachment)
+	if !ok || va.Spec.Source.PersistentVolumeName == nil {
+		return nil
+	}
+
+	pv := &corev1.PersistentVolume{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: *va.Spec.Source.PersistentVolumeName}, pv)
+	if err != nil || pv.Spec.ClaimRef == nil {
+		return nil
+	}
+
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{
+			Name:      pv.Spec.ClaimRef.Name,
+			Namespace: pv.Spec.ClaimRef.Namespace,
+		},
+	}}
+}
+
+// findPersistentVolumeClaimsForCSIAddonsNode returns reconcile requests for
+// the bound PersistentVolumeClaims of the driver of the CSIAddonsNode, when
+// the driver supports the health of volumes.
+func (r *VolumeHealthReconciler) findPersistentVolumeClaimsForCSIAddonsNode(
+	ctx context.Context,
+	obj client.Object) []reconcile.Request {
+	node, ok := obj.(*csiaddonsv1alpha1.CSIAddonsNode)
+	if !ok || !r.supportsVolumeHealth(node.Spec.Driver.Name) {
+		return nil
+	}
+
+	pvs := &corev1.PersistentVolumeList{}
+	err := r.Client.List(ctx, pvs)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to list PersistentVolumes", "CSIAddonsNode", node.Name)
+
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, pv := range pvs.Items {
+		if pv.Spec.CSI == nil || pv.Spec.CSI.Driver != node.Spec.Driver.Name || pv.Spec.ClaimRef == nil {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      pv.Spec.ClaimRef.Name,
+				Namespace: pv.Spec.ClaimRef.Namespace,
+			},
+		})
+	}
+
+	return requests
+}
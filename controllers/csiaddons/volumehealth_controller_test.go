@@ -0,0 +1,397 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	csiaddonsv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/csiaddons/v1alpha1"
+	extensions "github.com/csi-addons/kubernetes-csi-addons/extensions/v1alpha1"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/connection"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/proto"
+
+	"github.com/csi-addons/spec/lib/go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	scv1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeVolumeHealthServer is a sidecar that reports the health of the volumes
+// in responses, other volumes are not reported.
+type fakeVolumeHealthServer struct {
+	proto.UnimplementedVolumeHealthServer
+	responses map[string]*proto.VolumeHealthResponse
+}
+
+func (f *fakeVolumeHealthServer) NodeGetVolumeHealth(
+	_ context.Context,
+	req *proto.VolumeHealthRequest) (*proto.VolumeHealthResponse, error) {
+	res, ok := f.responses[req.GetPvName()]
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "volume condition is not reported")
+	}
+
+	return res, nil
+}
+
+// newFakeSidecarConnPool returns a ConnectionPool with a connection to a
+// sidecar for the driver on the node, with the Service capability of
+// serviceType. The services of the sidecar are registered by register.
+func newFakeSidecarConnPool(
+	t *testing.T,
+	driver, nodeID string,
+	serviceType extensions.Capability_Service_Type,
+	register func(*grpc.Server)) *connection.ConnectionPool {
+	t.Helper()
+
+	socket := filepath.Join(t.TempDir(), "sidecar.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	register(server)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("unix://"+socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	pool := connection.NewConnectionPool()
+	pool.Put(nodeID, &connection.Connection{
+		Client:       conn,
+		Capabilities: []*identity.Capability{extensions.NewServiceCapability(serviceType)},
+		NodeID:       nodeID,
+		DriverName:   driver,
+	})
+
+	return pool
+}
+
+func TestSetVolumeHealthCondition(t *testing.T) {
+	t.Parallel()
+	pvc := &corev1.PersistentVolumeClaim{}
+
+	assert.True(t, setVolumeHealthCondition(pvc, corev1.ConditionTrue, reasonVolumeConditionNormal, ""))
+	cond := getVolumeHealthCondition(pvc)
+	require.NotNil(t, cond)
+	assert.Equal(t, corev1.ConditionTrue, cond.Status)
+	transitionTime := cond.LastTransitionTime
+
+	// an unchanged condition is not updated.
+	assert.False(t, setVolumeHealthCondition(pvc, corev1.ConditionTrue, reasonVolumeConditionNormal, ""))
+
+	// a new message updates the condition, but it does not transition.
+	assert.True(t, setVolumeHealthCondition(pvc, corev1.ConditionTrue, reasonVolumeConditionNormal, "healthy"))
+	assert.Equal(t, transitionTime, getVolumeHealthCondition(pvc).LastTransitionTime)
+
+	assert.True(t, setVolumeHealthCondition(pvc, corev1.ConditionFalse, reasonVolumeConditionAbnormal, "degraded"))
+	cond = getVolumeHealthCondition(pvc)
+	assert.Equal(t, corev1.ConditionFalse, cond.Status)
+	assert.Equal(t, reasonVolumeConditionAbnormal, cond.Reason)
+	assert.Equal(t, "degraded", cond.Message)
+	assert.Len(t, pvc.Status.Conditions, 1)
+}
+
+func TestGetAttachedNodeID(t *testing.T) {
+	t.Parallel()
+	pvName := "pv-1"
+	otherPVName := "pv-2"
+	now := metav1.Now()
+
+	newVA := func(pv *string, node string, attached bool) scv1.VolumeAttachment {
+		return scv1.VolumeAttachment{
+			Spec: scv1.VolumeAttachmentSpec{
+				NodeName: node,
+				Source:   scv1.VolumeAttachmentSource{PersistentVolumeName: pv},
+			},
+			Status: scv1.VolumeAttachmentStatus{Attached: attached},
+		}
+	}
+	deleted := newVA(&pvName, "node-3", true)
+	deleted.DeletionTimestamp = &now
+
+	tests := []struct {
+		name string
+		vas  []scv1.VolumeAttachment
+		want string
+	}{
+		{
+			name: "attached volume",
+			vas:  []scv1.VolumeAttachment{newVA(&otherPVName, "node-1", true), newVA(&pvName, "node-2", true)},
+			want: "node-2",
+		},
+		{
+			name: "volume not attached yet",
+			vas:  []scv1.VolumeAttachment{newVA(&pvName, "node-1", false)},
+			want: "",
+		},
+		{
+			name: "volume attachment is deleted",
+			vas:  []scv1.VolumeAttachment{deleted},
+			want: "",
+		},
+		{
+			name: "inline volume",
+			vas:  []scv1.VolumeAttachment{newVA(nil, "node-1", true)},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, newtt.want, getAttachedNodeID(newtt.vas, pvName))
+		})
+	}
+}
+
+func TestVolumeHealthReconcile(t *testing.T) {
+	t.Parallel()
+	driver := "test.csi.io"
+	nodeID := "node-1"
+	interval := time.Minute
+
+	newObjects := func(name string) (*corev1.PersistentVolumeClaim, *corev1.PersistentVolume, *scv1.VolumeAttachment) {
+		pvName := "pv-" + name
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: pvName},
+			Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+		}
+		pv := &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: pvName},
+			Spec: corev1.PersistentVolumeSpec{
+				PersistentVolumeSource: corev1.PersistentVolumeSource{
+					CSI: &corev1.CSIPersistentVolumeSource{Driver: driver, VolumeHandle: "handle-" + name},
+				},
+				ClaimRef: &corev1.ObjectReference{Name: name, Namespace: "default"},
+			},
+		}
+		va := &scv1.VolumeAttachment{
+			ObjectMeta: metav1.ObjectMeta{Name: "va-" + name},
+			Spec: scv1.VolumeAttachmentSpec{
+				Attacher: driver,
+				NodeName: nodeID,
+				Source:   scv1.VolumeAttachmentSource{PersistentVolumeName: &pvName},
+			},
+			Status: scv1.VolumeAttachmentStatus{Attached: true},
+		}
+
+		return pvc, pv, va
+	}
+
+	tests := []struct {
+		name     string
+		pvcName  string
+		attached bool
+		// unsupported is set when the sidecar of the driver does not
+		// check the health of volumes.
+		unsupported bool
+		condition   *corev1.PersistentVolumeClaimCondition
+		wantStatus  corev1.ConditionStatus
+		wantReason  string
+		wantEvent   bool
+	}{
+		{
+			name:       "healthy volume",
+			pvcName:    "healthy",
+			attached:   true,
+			wantStatus: corev1.ConditionTrue,
+			wantReason: reasonVolumeConditionNormal,
+		},
+		{
+			name:       "abnormal volume",
+			pvcName:    "abnormal",
+			attached:   true,
+			wantStatus: corev1.ConditionFalse,
+			wantReason: reasonVolumeConditionAbnormal,
+			wantEvent:  true,
+		},
+		{
+			name:       "recovered volume",
+			pvcName:    "healthy",
+			attached:   true,
+			condition:  &corev1.PersistentVolumeClaimCondition{Type: PersistentVolumeClaimVolumeHealthy, Status: corev1.ConditionFalse},
+			wantStatus: corev1.ConditionTrue,
+			wantReason: reasonVolumeConditionNormal,
+			wantEvent:  true,
+		},
+		{
+			name:       "condition not reported",
+			pvcName:    "unreported",
+			attached:   true,
+			wantStatus: corev1.ConditionUnknown,
+			wantReason: reasonVolumeConditionNotReported,
+		},
+		{
+			name:     "volume not attached",
+			pvcName:  "healthy",
+			attached: false,
+		},
+		{
+			name:       "volume detached after it was checked",
+			pvcName:    "healthy",
+			attached:   false,
+			condition:  &corev1.PersistentVolumeClaimCondition{Type: PersistentVolumeClaimVolumeHealthy, Status: corev1.ConditionTrue},
+			wantStatus: corev1.ConditionUnknown,
+			wantReason: reasonVolumeNotAttached,
+		},
+		{
+			name:        "driver without volume health",
+			pvcName:     "healthy",
+			attached:    true,
+			unsupported: true,
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			pvc, pv, va := newObjects(newtt.pvcName)
+			if newtt.condition != nil {
+				pvc.Status.Conditions = append(pvc.Status.Conditions, *newtt.condition)
+			}
+			va.Status.Attached = newtt.attached
+
+			serviceType := extensions.Capability_Service_VOLUME_HEALTH
+			wantRequeue := interval
+			if newtt.unsupported {
+				serviceType = extensions.Capability_Service_ENCRYPTION_KEY_ROTATION
+				wantRequeue = 0
+			}
+
+			recorder := record.NewFakeRecorder(1)
+			r := &VolumeHealthReconciler{
+				Client: fake.NewClientBuilder().
+					WithObjects(pvc, pv, va).
+					WithStatusSubresource(pvc).
+					WithIndex(&scv1.VolumeAttachment{}, volumeAttachmentPVKey, indexVolumeAttachmentByPersistentVolume).
+					Build(),
+				ConnPool: newFakeSidecarConnPool(t, driver, nodeID, serviceType, func(s *grpc.Server) {
+					proto.RegisterVolumeHealthServer(s, &fakeVolumeHealthServer{
+						responses: map[string]*proto.VolumeHealthResponse{
+							"pv-healthy":  {Abnormal: false},
+							"pv-abnormal": {Abnormal: true, Message: "volume is degraded"},
+						},
+					})
+				}),
+				Timeout:  time.Minute,
+				Interval: interval,
+				Recorder: recorder,
+			}
+
+			key := types.NamespacedName{Name: pvc.Name, Namespace: pvc.Namespace}
+			res, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+			require.NoError(t, err)
+			assert.Equal(t, wantRequeue, res.RequeueAfter)
+
+			updated := &corev1.PersistentVolumeClaim{}
+			require.NoError(t, r.Client.Get(context.Background(), key, updated))
+			cond := getVolumeHealthCondition(updated)
+			if newtt.wantStatus == "" {
+				assert.Nil(t, cond)
+			} else {
+				require.NotNil(t, cond)
+				assert.Equal(t, newtt.wantStatus, cond.Status)
+				assert.Equal(t, newtt.wantReason, cond.Reason)
+			}
+			assert.Equal(t, newtt.wantEvent, len(recorder.Events) == 1)
+		})
+	}
+}
+
+func TestFindPersistentVolumeClaimsForCSIAddonsNode(t *testing.T) {
+	t.Parallel()
+	driver := "test.csi.io"
+	newPV := func(name, pvDriver, claim string) *corev1.PersistentVolume {
+		pv := &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: corev1.PersistentVolumeSpec{
+				PersistentVolumeSource: corev1.PersistentVolumeSource{
+					CSI: &corev1.CSIPersistentVolumeSource{Driver: pvDriver, VolumeHandle: "handle-" + name},
+				},
+			},
+		}
+		if claim != "" {
+			pv.Spec.ClaimRef = &corev1.ObjectReference{Name: claim, Namespace: "default"}
+		}
+
+		return pv
+	}
+	node := &csiaddonsv1alpha1.CSIAddonsNode{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1", Namespace: "default"},
+		Spec: csiaddonsv1alpha1.CSIAddonsNodeSpec{
+			Driver: csiaddonsv1alpha1.CSIAddonsNodeDriver{Name: driver, NodeID: "node-1"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		serviceType extensions.Capability_Service_Type
+		want        []types.NamespacedName
+	}{
+		{
+			name:        "driver with volume health",
+			serviceType: extensions.Capability_Service_VOLUME_HEALTH,
+			want:        []types.NamespacedName{{Name: "claim-1", Namespace: "default"}},
+		},
+		{
+			name:        "driver without volume health",
+			serviceType: extensions.Capability_Service_ENCRYPTION_KEY_ROTATION,
+			want:        nil,
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			r := &VolumeHealthReconciler{
+				Client: fake.NewClientBuilder().
+					WithObjects(
+						newPV("pv-1", driver, "claim-1"),
+						newPV("pv-2", driver, ""),
+						newPV("pv-3", "other.csi.io", "claim-3"),
+					).
+					Build(),
+				ConnPool: newFakeSidecarConnPool(t, driver, "node-1", newtt.serviceType, func(_ *grpc.Server) {}),
+			}
+
+			var got []types.NamespacedName
+			for _, req := range r.findPersistentVolumeClaimsForCSIAddonsNode(context.Background(), node) {
+				got = append(got, req.NamespacedName)
+			}
+			assert.Equal(t, newtt.want, got)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2022 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+
+	replicationv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/replication.storage/v1alpha1"
+	extensions "github.com/csi-addons/kubernetes-csi-addons/extensions/v1alpha1"
+	conn "github.com/csi-addons/kubernetes-csi-addons/internal/connection"
+
+	"github.com/csi-addons/spec/lib/go/identity"
+)
+
+// replicationModeCapabilities maps the replication modes to the capability
+// that the driver advertises for them. The CSI-Addons specification has no
+// capabilities for the modes, the types are registered in the extensions.
+var replicationModeCapabilities = map[replicationv1alpha1.ReplicationMode]extensions.Capability_VolumeReplication_Type{
+	replicationv1alpha1.SyncReplicationMode:     extensions.Capability_VolumeReplication_SYNC_MODE,
+	replicationv1alpha1.AsyncReplicationMode:    extensions.Capability_VolumeReplication_ASYNC_MODE,
+	replicationv1alpha1.SnapshotReplicationMode: extensions.Capability_VolumeReplication_SNAPSHOT_MODE,
+}
+
+// checkReplicationMode returns an error if none of the connections of the
+// driver supports the replication mode. No mode means the driver default,
+// which is always supported.
+func checkReplicationMode(conns map[string]*conn.Connection, driverName string, mode replicationv1alpha1.ReplicationMode) error {
+	if mode == "" {
+		return nil
+	}
+
+	want, ok := replicationModeCapabilities[mode]
+	if !ok {
+		return fmt.Errorf("unknown replication mode %q", mode)
+	}
+
+	for _, v := range conns {
+		if extensions.HasVolumeReplicationCapability(v.Capabilities, want) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("replication mode %q is not supported by driver %s", mode, driverName)
+}
+
+// hasVolumeReplicationCapability returns true if the capabilities contain the
+// VolumeReplication capability of the type.
+func hasVolumeReplicationCapability(caps []*identity.Capability, capType identity.Capability_VolumeReplication_Type) bool {
+	for _, cap := range caps {
+		if cap.GetVolumeReplication().GetType() == capType {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	replicationv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/replication.storage/v1alpha1"
+	extensions "github.com/csi-addons/kubernetes-csi-addons/extensions/v1alpha1"
+	conn "github.com/csi-addons/kubernetes-csi-addons/internal/connection"
+
+	"github.com/csi-addons/spec/lib/go/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckReplicationMode(t *testing.T) {
+	t.Parallel()
+	volumeReplicationCapability := func(capType identity.Capability_VolumeReplication_Type) *identity.Capability {
+		return &identity.Capability{
+			Type: &identity.Capability_VolumeReplication_{
+				VolumeReplication: &identity.Capability_VolumeReplication{
+					Type: capType,
+				},
+			},
+		}
+	}
+	conns := map[string]*conn.Connection{
+		"driver-1": {
+			Capabilities: []*identity.Capability{
+				volumeReplicationCapability(identity.Capability_VolumeReplication_VOLUME_REPLICATION),
+				extensions.NewVolumeReplicationCapability(extensions.Capability_VolumeReplication_ASYNC_MODE),
+				extensions.NewVolumeReplicationCapability(extensions.Capability_VolumeReplication_SNAPSHOT_MODE),
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		conns   map[string]*conn.Connection
+		mode    replicationv1alpha1.ReplicationMode
+		wantErr bool
+	}{
+		{
+			name:    "no mode",
+			conns:   map[string]*conn.Connection{},
+			wantErr: false,
+		},
+		{
+			name:    "supported mode",
+			conns:   conns,
+			mode:    replicationv1alpha1.SnapshotReplicationMode,
+			wantErr: false,
+		},
+		{
+			name:    "unsupported mode",
+			conns:   conns,
+			mode:    replicationv1alpha1.SyncReplicationMode,
+			wantErr: true,
+		},
+		{
+			name:    "unknown mode",
+			conns:   conns,
+			mode:    "journal",
+			wantErr: true,
+		},
+		{
+			name:    "no connections",
+			conns:   map[string]*conn.Connection{},
+			mode:    replicationv1alpha1.AsyncReplicationMode,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			err := checkReplicationMode(newtt.conns, "test-driver", newtt.mode)
+			assert.Equal(t, newtt.wantErr, err != nil)
+		})
+	}
+}
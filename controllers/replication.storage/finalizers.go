@@ -30,6 +30,7 @@ import (
 const (
 	volumeReplicationFinalizer = "replication.storage.openshift.io"
 	pvcReplicationFinalizer    = "replication.storage.openshift.io/pvc-protection"
+	vrcReplicationFinalizer    = "replication.storage.openshift.io/vrc-protection"
 )
 
 // addFinalizerToVR adds the VR finalizer on the VolumeReplication instance.
@@ -93,3 +94,34 @@ func (r *VolumeReplicationReconciler) removeFinalizerFromPVC(logger logr.Logger,
 
 	return nil
 }
+
+// addFinalizerToVRC adds the VRC finalizer on the VolumeReplicationClass.
+func (r *VolumeReplicationClassReconciler) addFinalizerToVRC(logger logr.Logger, vrc *replicationv1alpha1.VolumeReplicationClass) error {
+	if !util.ContainsInSlice(vrc.ObjectMeta.Finalizers, vrcReplicationFinalizer) {
+		logger.Info("adding finalizer to volumeReplicationClass object", "Finalizer", vrcReplicationFinalizer)
+		vrc.ObjectMeta.Finalizers = append(vrc.ObjectMeta.Finalizers, vrcReplicationFinalizer)
+		if err := r.Client.Update(context.TODO(), vrc); err != nil {
+			return fmt.Errorf("failed to add finalizer (%s) to VolumeReplicationClass resource"+
+				" (%s) %w",
+				vrcReplicationFinalizer, vrc.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// removeFinalizerFromVRC removes the VRC finalizer from the
+// VolumeReplicationClass.
+func (r *VolumeReplicationClassReconciler) removeFinalizerFromVRC(logger logr.Logger, vrc *replicationv1alpha1.VolumeReplicationClass) error {
+	if util.ContainsInSlice(vrc.ObjectMeta.Finalizers, vrcReplicationFinalizer) {
+		logger.Info("removing finalizer from volumeReplicationClass object", "Finalizer", vrcReplicationFinalizer)
+		vrc.ObjectMeta.Finalizers = util.RemoveFromSlice(vrc.ObjectMeta.Finalizers, vrcReplicationFinalizer)
+		if err := r.Client.Update(context.TODO(), vrc); err != nil {
+			return fmt.Errorf("failed to remove finalizer (%s) from VolumeReplicationClass resource"+
+				" (%s), %w",
+				vrcReplicationFinalizer, vrc.Name, err)
+		}
+	}
+
+	return nil
+}
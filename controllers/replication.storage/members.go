@@ -0,0 +1,418 @@
+/*
+Copyright 2022 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	replicationv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/replication.storage/v1alpha1"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	podDataSource         = "Pod"
+	statefulSetDataSource = "StatefulSet"
+
+	// memberOfLabel is set on the member VolumeReplications with the name of
+	// the VolumeReplication of the Pod or StatefulSet.
+	memberOfLabel = replicationParameterPrefix + "member-of"
+
+	// MembersPending is the reason of the conditions of a VolumeReplication
+	// whose members have not all reported the condition yet.
+	MembersPending = "MembersPending"
+)
+
+// reconcileMembers creates a member VolumeReplication for each of the PVCs of
+// the Pod or StatefulSet data source, and reports the state of the members in
+// the status. Members of PVCs that are no longer used by the data source are
+// kept while their PVC exists, like the PVCs of a scaled down StatefulSet, and
+// removed once it is deleted.
+func (r *VolumeReplicationReconciler) reconcileMembers(
+	ctx context.Context,
+	logger logr.Logger,
+	instance *replicationv1alpha1.VolumeReplication) (ctrl.Result, error) {
+	// the members are garbage collected with their owner, and disable the
+	// replication of their volume themselves.
+	if !instance.GetDeletionTimestamp().IsZero() {
+		logger.Info("volumeReplication object is terminated, skipping reconciliation")
+
+		return ctrl.Result{}, nil
+	}
+
+	pvcNames, err := r.getDataSourcePVCNames(ctx, instance)
+	if errors.IsNotFound(err) {
+		// the data source is watched, the instance is reconciled again
+		// once it is created.
+		logger.Info("data source not found", "Kind", instance.Spec.DataSource.Kind, "Name", instance.Spec.DataSource.Name)
+		setDataSourceNotFoundCondition(&instance.Status.Conditions, instance.Generation)
+
+		return ctrl.Result{}, r.updateReplicationStatus(instance, logger, getCurrentReplicationState(instance), err.Error())
+	}
+	if err != nil {
+		logger.Error(err, "failed to get PVCs of data source", "Kind", instance.Spec.DataSource.Kind, "Name", instance.Spec.DataSource.Name)
+		setFailureCondition(instance)
+		uErr := r.updateReplicationStatus(instance, logger, getCurrentReplicationState(instance), err.Error())
+		if uErr != nil {
+			logger.Error(uErr, "failed to update volumeReplication status", "VRName", instance.Name)
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	existing := &replicationv1alpha1.VolumeReplicationList{}
+	err = r.Client.List(ctx, existing,
+		client.InNamespace(instance.Namespace),
+		client.MatchingLabels{memberOfLabel: instance.Name})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	retained, err := r.getRetainedMemberPVCNames(ctx, instance, existing, pvcNames)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	pvcNames = append(pvcNames, retained...)
+
+	wanted := map[string]bool{}
+	members := make([]*replicationv1alpha1.VolumeReplication, 0, len(pvcNames))
+	for _, pvcName := range pvcNames {
+		member, err := r.createOrUpdateMember(ctx, instance, pvcName)
+		if err != nil {
+			logger.Error(err, "failed to create or update member volumeReplication", "PVCName", pvcName)
+
+			return ctrl.Result{}, err
+		}
+		wanted[member.Name] = true
+		members = append(members, member)
+	}
+
+	for i := range existing.Items {
+		stale := &existing.Items[i]
+		if wanted[stale.Name] || !metav1.IsControlledBy(stale, instance) {
+			continue
+		}
+		logger.Info("deleting member volumeReplication", "MemberName", stale.Name)
+		err = r.Client.Delete(ctx, stale)
+		if err != nil && !errors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+	}
+
+	state, msg := aggregateMemberStatus(instance, members)
+
+	return ctrl.Result{}, r.updateReplicationStatus(instance, logger, state, msg)
+}
+
+// getRetainedMemberPVCNames returns the names of the PVCs of the existing
+// members that are not used by the data source anymore, but still exist.
+func (r *VolumeReplicationReconciler) getRetainedMemberPVCNames(
+	ctx context.Context,
+	instance *replicationv1alpha1.VolumeReplication,
+	existing *replicationv1alpha1.VolumeReplicationList,
+	pvcNames []string) ([]string, error) {
+	used := map[string]bool{}
+	for _, pvcName := range pvcNames {
+		used[pvcName] = true
+	}
+
+	retained := []string{}
+	for i := range existing.Items {
+		member := &existing.Items[i]
+		pvcName := member.Spec.DataSource.Name
+		if used[pvcName] || !metav1.IsControlledBy(member, instance) {
+			continue
+		}
+
+		pvc := &corev1.PersistentVolumeClaim{}
+		err := r.Client.Get(ctx, types.NamespacedName{Name: pvcName, Namespace: instance.Namespace}, pvc)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		// the member holds a finalizer on the PVC, it is removed with
+		// the member.
+		if !pvc.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+
+		used[pvcName] = true
+		retained = append(retained, pvcName)
+	}
+
+	return retained, nil
+}
+
+// getDataSourcePVCNames returns the names of the PVCs of the Pod or
+// StatefulSet data source.
+func (r *VolumeReplicationReconciler) getDataSourcePVCNames(
+	ctx context.Context,
+	instance *replicationv1alpha1.VolumeReplication) ([]string, error) {
+	key := types.NamespacedName{Name: instance.Spec.DataSource.Name, Namespace: instance.Namespace}
+
+	switch instance.Spec.DataSource.Kind {
+	case podDataSource:
+		pod := &corev1.Pod{}
+		if err := r.Client.Get(ctx, key, pod); err != nil {
+			return nil, fmt.Errorf("failed to get Pod %q: %w", key, err)
+		}
+
+		return getPodPVCNames(pod), nil
+	case statefulSetDataSource:
+		sts := &appsv1.StatefulSet{}
+		if err := r.Client.Get(ctx, key, sts); err != nil {
+			return nil, fmt.Errorf("failed to get StatefulSet %q: %w", key, err)
+		}
+
+		return getStatefulSetPVCNames(sts), nil
+	}
+
+	return nil, fmt.Errorf("unsupported datasource kind %q", instance.Spec.DataSource.Kind)
+}
+
+// getPodPVCNames returns the names of the PVCs used by the Pod, including
+// the PVCs of its generic ephemeral volumes.
+func getPodPVCNames(pod *corev1.Pod) []string {
+	names := []string{}
+	for _, vol := range pod.Spec.Volumes {
+		switch {
+		case vol.PersistentVolumeClaim != nil:
+			names = append(names, vol.PersistentVolumeClaim.ClaimName)
+		case vol.Ephemeral != nil:
+			names = append(names, pod.Name+"-"+vol.Name)
+		}
+	}
+
+	return names
+}
+
+// getStatefulSetPVCNames returns the names of the PVCs created from the
+// volumeClaimTemplates for each of the replicas of the StatefulSet.
+func getStatefulSetPVCNames(sts *appsv1.StatefulSet) []string {
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	start := int32(0)
+	if sts.Spec.Ordinals != nil {
+		start = sts.Spec.Ordinals.Start
+	}
+
+	names := []string{}
+	for ordinal := start; ordinal < start+replicas; ordinal++ {
+		for _, template := range sts.Spec.VolumeClaimTemplates {
+			names = append(names, fmt.Sprintf("%s-%s-%d", template.Name, sts.Name, ordinal))
+		}
+	}
+
+	return names
+}
+
+// getMemberName returns the name of the member VolumeReplication of the PVC.
+// Names that are too long are shortened with a hash of the PVC name.
+func getMemberName(parent, pvcName string) string {
+	name := parent + "-" + pvcName
+	if len(name) <= validation.DNS1123SubdomainMaxLength {
+		return name
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(pvcName))
+	suffix := fmt.Sprintf("-%08x", h.Sum32())
+	maxParentLength := validation.DNS1123SubdomainMaxLength - len(suffix)
+	if len(parent) > maxParentLength {
+		parent = parent[:maxParentLength]
+	}
+
+	return parent + suffix
+}
+
+// createOrUpdateMember creates the member VolumeReplication of the PVC, or
+// updates it with the spec of its owner.
+func (r *VolumeReplicationReconciler) createOrUpdateMember(
+	ctx context.Context,
+	instance *replicationv1alpha1.VolumeReplication,
+	pvcName string) (*replicationv1alpha1.VolumeReplication, error) {
+	member := &replicationv1alpha1.VolumeReplication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getMemberName(instance.Name, pvcName),
+			Namespace: instance.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, member, func() error {
+		if member.Labels == nil {
+			member.Labels = map[string]string{}
+		}
+		member.Labels[memberOfLabel] = instance.Name
+
+		// the replicationHandle identifies the replication of a single
+		// volume, it does not apply to the members.
+		member.Spec = replicationv1alpha1.VolumeReplicationSpec{
+			VolumeReplicationClass: instance.Spec.VolumeReplicationClass,
+			ReplicationState:       instance.Spec.ReplicationState,
+			DataSource: corev1.TypedLocalObjectReference{
+				Kind: pvcDataSource,
+				Name: pvcName,
+			},
+			AutoResync:     instance.Spec.AutoResync,
+			ForcePromotion: instance.Spec.ForcePromotion,
+		}
+
+		return controllerutil.SetControllerReference(instance, member, r.Scheme)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// aggregateMemberStatus sets the members, conditions and last sync time of
+// the instance from the status of its members, and returns the state and
+// message to report.
+func aggregateMemberStatus(
+	instance *replicationv1alpha1.VolumeReplication,
+	members []*replicationv1alpha1.VolumeReplication) (replicationv1alpha1.State, string) {
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].Name < members[j].Name
+	})
+
+	instance.Status.Members = make([]replicationv1alpha1.VolumeReplicationMemberStatus, 0, len(members))
+	for _, member := range members {
+		instance.Status.Members = append(instance.Status.Members, replicationv1alpha1.VolumeReplicationMemberStatus{
+			Name:                  member.Name,
+			PersistentVolumeClaim: member.Spec.DataSource.Name,
+			State:                 member.Status.State,
+		})
+	}
+
+	setMembersCondition(instance, members, ConditionCompleted, metav1.ConditionFalse)
+	setMembersCondition(instance, members, ConditionDegraded, metav1.ConditionTrue)
+	setMembersCondition(instance, members, ConditionResyncing, metav1.ConditionTrue)
+
+	// the group is only as recent as its least recently synced member.
+	instance.Status.LastSyncTime = nil
+	for _, member := range members {
+		if member.Status.LastSyncTime == nil {
+			instance.Status.LastSyncTime = nil
+
+			break
+		}
+		if instance.Status.LastSyncTime == nil || member.Status.LastSyncTime.Before(instance.Status.LastSyncTime) {
+			instance.Status.LastSyncTime = member.Status.LastSyncTime
+		}
+	}
+
+	if len(members) == 0 {
+		return replicationv1alpha1.UnknownState, "data source has no persistent volume claims"
+	}
+
+	wantState := getReplicationState(instance)
+	completed := 0
+	for _, member := range members {
+		if isMemberCompleted(member, wantState) {
+			completed++
+		}
+	}
+
+	if completed != len(members) {
+		return getCurrentReplicationState(instance),
+			fmt.Sprintf("%d of %d volumes are marked %s", completed, len(members), string(instance.Spec.ReplicationState))
+	}
+
+	return wantState, fmt.Sprintf("all %d volumes are marked %s", len(members), string(instance.Spec.ReplicationState))
+}
+
+// isMemberCompleted returns true if the member reconciled its current spec
+// and reached the state.
+func isMemberCompleted(member *replicationv1alpha1.VolumeReplication, state replicationv1alpha1.State) bool {
+	return member.Status.ObservedGeneration == member.Generation &&
+		member.Status.State == state &&
+		meta.IsStatusConditionTrue(member.Status.Conditions, ConditionCompleted)
+}
+
+// setMembersCondition sets the condition of the instance to the dominant
+// status of its members. If any member has the dominant status, or not all
+// members reported the condition, the instance gets the dominant status.
+// Otherwise, all members agree and their status is used.
+func setMembersCondition(
+	instance *replicationv1alpha1.VolumeReplication,
+	members []*replicationv1alpha1.VolumeReplication,
+	conditionType string,
+	dominant metav1.ConditionStatus) {
+	if len(members) == 0 {
+		return
+	}
+
+	var result *metav1.Condition
+	for _, member := range members {
+		cond := meta.FindStatusCondition(member.Status.Conditions, conditionType)
+		if cond == nil || cond.ObservedGeneration != member.Generation {
+			if result == nil || result.Status != dominant {
+				result = &metav1.Condition{Status: dominant, Reason: MembersPending}
+			}
+
+			continue
+		}
+		if cond.Status == dominant {
+			result = cond
+
+			break
+		}
+		if result == nil {
+			result = cond
+		}
+	}
+
+	setStatusCondition(&instance.Status.Conditions, &metav1.Condition{
+		Type:               conditionType,
+		Reason:             result.Reason,
+		ObservedGeneration: instance.Generation,
+		Status:             result.Status,
+	})
+}
+
+// podLifecyclePredicate filters the updates of Pods, as the volumes of a Pod
+// can not change. The Pods are watched by their metadata only, so that not
+// all Pods in the cluster are cached, and only the Pods that are the data
+// source of a VolumeReplication are mapped to a request.
+func podLifecyclePredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return true },
+		DeleteFunc:  func(event.DeleteEvent) bool { return true },
+		UpdateFunc:  func(event.UpdateEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2022 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	replicationv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/replication.storage/v1alpha1"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestGetPodPVCNames(t *testing.T) {
+	t.Parallel()
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app"},
+		Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{
+				{
+					Name: "data",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data-pvc"},
+					},
+				},
+				{
+					Name: "config",
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{},
+					},
+				},
+				{
+					Name: "scratch",
+					VolumeSource: corev1.VolumeSource{
+						Ephemeral: &corev1.EphemeralVolumeSource{},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"data-pvc", "app-scratch"}, getPodPVCNames(pod))
+}
+
+func TestGetStatefulSetPVCNames(t *testing.T) {
+	t.Parallel()
+	replicas := int32(2)
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db"},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &replicas,
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				{ObjectMeta: metav1.ObjectMeta{Name: "data"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "wal"}},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"data-db-0", "wal-db-0", "data-db-1", "wal-db-1"}, getStatefulSetPVCNames(sts))
+
+	sts.Spec.Ordinals = &appsv1.StatefulSetOrdinals{Start: 3}
+	sts.Spec.Replicas = nil
+	assert.Equal(t, []string{"data-db-3", "wal-db-3"}, getStatefulSetPVCNames(sts))
+}
+
+func TestGetMemberName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "vr-data", getMemberName("vr", "data"))
+
+	long := strings.Repeat("a", validation.DNS1123SubdomainMaxLength)
+	name := getMemberName(long, "data")
+	assert.Len(t, name, validation.DNS1123SubdomainMaxLength)
+	assert.NotEqual(t, name, getMemberName(long, "wal"))
+}
+
+func TestReconcileMembers(t *testing.T) {
+	t.Parallel()
+	replicas := int32(2)
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db",
+			Namespace: mockNamespace,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &replicas,
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				{ObjectMeta: metav1.ObjectMeta{Name: "data"}},
+			},
+		},
+	}
+	instance := &replicationv1alpha1.VolumeReplication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db-replication",
+			Namespace: mockNamespace,
+			UID:       "db-replication-uid",
+		},
+		Spec: replicationv1alpha1.VolumeReplicationSpec{
+			VolumeReplicationClass: "volume-replication-class",
+			ReplicationState:       replicationv1alpha1.Primary,
+			DataSource: corev1.TypedLocalObjectReference{
+				Kind: statefulSetDataSource,
+				Name: sts.Name,
+			},
+		},
+	}
+	// the members of replicas that were scaled down, the PVC of the first
+	// one is retained and the PVC of the second one was deleted.
+	newScaledDownMember := func(pvcName string) *replicationv1alpha1.VolumeReplication {
+		return &replicationv1alpha1.VolumeReplication{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      getMemberName(instance.Name, pvcName),
+				Namespace: mockNamespace,
+				Labels:    map[string]string{memberOfLabel: instance.Name},
+			},
+			Spec: replicationv1alpha1.VolumeReplicationSpec{
+				DataSource: corev1.TypedLocalObjectReference{Kind: pvcDataSource, Name: pvcName},
+			},
+		}
+	}
+	retained := newScaledDownMember("data-db-2")
+	stale := newScaledDownMember("data-db-3")
+	retainedPVC := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "data-db-2", Namespace: mockNamespace},
+	}
+	scheme := createFakeScheme(t)
+	r := &VolumeReplicationReconciler{
+		Client: fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(sts, instance.DeepCopy(), retainedPVC).
+			WithStatusSubresource(&replicationv1alpha1.VolumeReplication{}).
+			Build(),
+		Scheme: scheme,
+	}
+	for _, member := range []*replicationv1alpha1.VolumeReplication{retained, stale} {
+		assert.NoError(t, controllerutil.SetControllerReference(instance, member, r.Scheme))
+		assert.NoError(t, r.Client.Create(context.TODO(), member))
+	}
+
+	_, err := r.reconcileMembers(context.TODO(), testr.New(t), instance)
+	assert.NoError(t, err)
+
+	members := &replicationv1alpha1.VolumeReplicationList{}
+	err = r.Client.List(context.TODO(), members, client.MatchingLabels{memberOfLabel: instance.Name})
+	assert.NoError(t, err)
+	names := []string{}
+	for _, member := range members.Items {
+		names = append(names, member.Name)
+		assert.Equal(t, pvcDataSource, member.Spec.DataSource.Kind)
+		assert.Equal(t, replicationv1alpha1.Primary, member.Spec.ReplicationState)
+		assert.True(t, metav1.IsControlledBy(&member, instance))
+	}
+	assert.ElementsMatch(t, []string{"db-replication-data-db-0", "db-replication-data-db-1", retained.Name}, names)
+
+	assert.Len(t, instance.Status.Members, 3)
+	assert.Equal(t, "data-db-0", instance.Status.Members[0].PersistentVolumeClaim)
+	assert.Equal(t, "0 of 3 volumes are marked primary", instance.Status.Message)
+	cond := meta.FindStatusCondition(instance.Status.Conditions, ConditionCompleted)
+	assert.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, MembersPending, cond.Reason)
+
+	// once all members are promoted, so is the instance.
+	for i := range members.Items {
+		member := &members.Items[i]
+		member.Status.State = replicationv1alpha1.PrimaryState
+		member.Status.ObservedGeneration = member.Generation
+		setPromotedCondition(&member.Status.Conditions, member.Generation)
+		assert.NoError(t, r.Client.Status().Update(context.TODO(), member))
+	}
+
+	updated := &replicationv1alpha1.VolumeReplication{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, updated)
+	assert.NoError(t, err)
+	_, err = r.reconcileMembers(context.TODO(), testr.New(t), updated)
+	assert.NoError(t, err)
+	assert.Equal(t, replicationv1alpha1.PrimaryState, updated.Status.State)
+	assert.Equal(t, "all 3 volumes are marked primary", updated.Status.Message)
+	assert.True(t, meta.IsStatusConditionTrue(updated.Status.Conditions, ConditionCompleted))
+	assert.True(t, meta.IsStatusConditionFalse(updated.Status.Conditions, ConditionDegraded))
+}
+
+func TestReconcileMembersDataSourceNotFound(t *testing.T) {
+	t.Parallel()
+	instance := &replicationv1alpha1.VolumeReplication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app-replication",
+			Namespace: mockNamespace,
+		},
+		Spec: replicationv1alpha1.VolumeReplicationSpec{
+			VolumeReplicationClass: "volume-replication-class",
+			ReplicationState:       replicationv1alpha1.Primary,
+			DataSource: corev1.TypedLocalObjectReference{
+				Kind: podDataSource,
+				Name: "app",
+			},
+		},
+	}
+	scheme := createFakeScheme(t)
+	r := &VolumeReplicationReconciler{
+		Client: fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(instance.DeepCopy()).
+			WithStatusSubresource(&replicationv1alpha1.VolumeReplication{}).
+			Build(),
+		Scheme: scheme,
+	}
+
+	// the Pod is watched, the instance is not requeued.
+	result, err := r.reconcileMembers(context.TODO(), testr.New(t), instance)
+	assert.NoError(t, err)
+	assert.True(t, result.IsZero())
+
+	cond := meta.FindStatusCondition(instance.Status.Conditions, ConditionCompleted)
+	assert.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, DataSourceNotFound, cond.Reason)
+	assert.True(t, meta.IsStatusConditionTrue(instance.Status.Conditions, ConditionDegraded))
+}
+
+func TestPodLifecyclePredicate(t *testing.T) {
+	t.Parallel()
+	pod := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "app"}}
+
+	pred := podLifecyclePredicate()
+	assert.True(t, pred.Create(event.CreateEvent{Object: pod}))
+	assert.True(t, pred.Delete(event.DeleteEvent{Object: pod}))
+	assert.False(t, pred.Update(event.UpdateEvent{ObjectOld: pod, ObjectNew: pod}))
+	assert.False(t, pred.Generic(event.GenericEvent{Object: pod}))
+}
@@ -19,7 +19,11 @@ package controllers
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
+
+	replicationv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/replication.storage/v1alpha1"
 )
 
 const (
@@ -31,6 +35,13 @@ const (
 
 	prefixedReplicationSecretNameKey      = replicationParameterPrefix + "replication-secret-name"      // name key for secret
 	prefixedReplicationSecretNamespaceKey = replicationParameterPrefix + "replication-secret-namespace" // namespace key secret
+
+	prefixedPeerKubeconfigSecretNameKey      = replicationParameterPrefix + "peer-kubeconfig-secret-name"      // name key for peer kubeconfig secret
+	prefixedPeerKubeconfigSecretNamespaceKey = replicationParameterPrefix + "peer-kubeconfig-secret-namespace" // namespace key for peer kubeconfig secret
+
+	prefixedDemoteRequeueIntervalKey = replicationParameterPrefix + "demote-requeue-interval" // requeue interval after demotion
+	prefixedResyncRequeueIntervalKey = replicationParameterPrefix + "resync-requeue-interval" // requeue interval while resyncing
+	prefixedInfoRequeueIntervalKey   = replicationParameterPrefix + "info-requeue-interval"   // requeue interval to refresh the replication info
 )
 
 // filterPrefixedParameters removes all the reserved keys from the
@@ -46,6 +57,47 @@ func filterPrefixedParameters(prefix string, param map[string]string) map[string
 	return newParam
 }
 
+// getDriverParameters returns the parameters of the replicationclass that are
+// passed to the driver, including the mode and schedule.
+func getDriverParameters(vrc *replicationv1alpha1.VolumeReplicationClass) map[string]string {
+	param := filterPrefixedParameters(replicationParameterPrefix, vrc.Spec.Parameters)
+	for k, v := range getScheduleParameters(vrc.Spec.Schedule) {
+		param[k] = v
+	}
+	if vrc.Spec.Mode != "" {
+		param[replicationv1alpha1.ReplicationModeParameter] = string(vrc.Spec.Mode)
+	}
+
+	return param
+}
+
+// getScheduleParameters converts the schedule to the scheduling parameters of
+// the driver. The interval is formatted in hours or minutes, like "1h" or
+// "90m".
+func getScheduleParameters(schedule *replicationv1alpha1.ReplicationSchedule) map[string]string {
+	param := map[string]string{}
+	if schedule == nil {
+		return param
+	}
+
+	interval := schedule.Interval.Duration
+	if interval%time.Hour == 0 {
+		param[replicationv1alpha1.ScheduleIntervalParameter] = fmt.Sprintf("%dh", interval/time.Hour)
+	} else {
+		param[replicationv1alpha1.ScheduleIntervalParameter] = fmt.Sprintf("%dm", interval/time.Minute)
+	}
+
+	if schedule.StartTime != nil {
+		param[replicationv1alpha1.ScheduleStartTimeParameter] = schedule.StartTime.UTC().Format(time.RFC3339)
+	}
+
+	if schedule.RetentionCount != nil {
+		param[replicationv1alpha1.ScheduleRetentionCountParameter] = strconv.Itoa(int(*schedule.RetentionCount))
+	}
+
+	return param
+}
+
 // validatePrefixParameters checks for unknown reserved keys in parameters and
 // empty values for reserved keys.
 func validatePrefixedParameters(param map[string]string) error {
@@ -60,6 +112,24 @@ func validatePrefixedParameters(param map[string]string) error {
 				if v == "" {
 					return errors.New("secret namespace cannot be empty")
 				}
+			case prefixedPeerKubeconfigSecretNameKey:
+				if v == "" {
+					return errors.New("peer kubeconfig secret name cannot be empty")
+				}
+			case prefixedPeerKubeconfigSecretNamespaceKey:
+				if v == "" {
+					return errors.New("peer kubeconfig secret namespace cannot be empty")
+				}
+			case prefixedDemoteRequeueIntervalKey,
+				prefixedResyncRequeueIntervalKey,
+				prefixedInfoRequeueIntervalKey:
+				d, err := time.ParseDuration(v)
+				if err != nil {
+					return fmt.Errorf("invalid duration %q for parameter %q: %w", v, k, err)
+				}
+				if d <= 0 {
+					return fmt.Errorf("duration for parameter %q must be positive", k)
+				}
 			// keep adding known prefixes to this list.
 			default:
 
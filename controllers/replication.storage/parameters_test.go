@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	replicationv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/replication.storage/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetDriverParameters(t *testing.T) {
+	t.Parallel()
+	retentionCount := int32(3)
+	startTime := metav1.NewTime(time.Date(2022, 9, 5, 14, 0, 0, 0, time.FixedZone("", 2*60*60)))
+
+	tests := []struct {
+		name       string
+		parameters map[string]string
+		mode       replicationv1alpha1.ReplicationMode
+		schedule   *replicationv1alpha1.ReplicationSchedule
+		want       map[string]string
+	}{
+		{
+			name: "without schedule",
+			parameters: map[string]string{
+				prefixedReplicationSecretNameKey: "secret",
+				"schedulingInterval":             "1m",
+			},
+			want: map[string]string{
+				"schedulingInterval": "1m",
+			},
+		},
+		{
+			name: "interval in hours",
+			schedule: &replicationv1alpha1.ReplicationSchedule{
+				Interval: metav1.Duration{Duration: 2 * time.Hour},
+			},
+			want: map[string]string{
+				"schedulingInterval": "2h",
+			},
+		},
+		{
+			name: "mode",
+			mode: replicationv1alpha1.AsyncReplicationMode,
+			want: map[string]string{
+				"replicationMode": "async",
+			},
+		},
+		{
+			name: "complete schedule",
+			parameters: map[string]string{
+				"mirroringMode": "snapshot",
+			},
+			mode: replicationv1alpha1.SnapshotReplicationMode,
+			schedule: &replicationv1alpha1.ReplicationSchedule{
+				Interval:       metav1.Duration{Duration: 90 * time.Minute},
+				StartTime:      &startTime,
+				RetentionCount: &retentionCount,
+			},
+			want: map[string]string{
+				"mirroringMode":            "snapshot",
+				"replicationMode":          "snapshot",
+				"schedulingInterval":       "90m",
+				"schedulingStartTime":      "2022-09-05T12:00:00Z",
+				"schedulingRetentionCount": "3",
+			},
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			vrc := &replicationv1alpha1.VolumeReplicationClass{
+				Spec: replicationv1alpha1.VolumeReplicationClassSpec{
+					Parameters: newtt.parameters,
+					Mode:       newtt.mode,
+					Schedule:   newtt.schedule,
+				},
+			}
+			assert.Equal(t, newtt.want, getDriverParameters(vrc))
+		})
+	}
+}
@@ -29,7 +29,7 @@ import (
 )
 
 // getPVCDataSource get pvc, pv object from the request.
-func (r VolumeReplicationReconciler) getPVCDataSource(logger logr.Logger, req types.NamespacedName) (*corev1.PersistentVolumeClaim, *corev1.PersistentVolume, error) {
+func (r *VolumeReplicationReconciler) getPVCDataSource(logger logr.Logger, req types.NamespacedName) (*corev1.PersistentVolumeClaim, *corev1.PersistentVolume, error) {
 	pvc := &corev1.PersistentVolumeClaim{}
 	err := r.Client.Get(context.TODO(), req, pvc)
 	if err != nil {
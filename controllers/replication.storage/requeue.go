@@ -0,0 +1,123 @@
+/*
+Copyright 2022 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	defaultDemoteRequeueInterval = 15 * time.Second
+	defaultResyncRequeueInterval = 30 * time.Second
+	defaultMaxRequeueInterval    = 5 * time.Minute
+
+	// minRequeueInterval and maxInfoRequeueInterval bound the intervals
+	// configured in the VolumeReplicationClass.
+	minRequeueInterval     = 5 * time.Second
+	maxInfoRequeueInterval = 24 * time.Hour
+
+	// degradedBackoffAfter is the time after which the interval for a
+	// degraded volume is doubled.
+	degradedBackoffAfter = 10 * time.Minute
+)
+
+// RequeueIntervals holds the intervals at which the state of a
+// VolumeReplication is polled. Intervals that are not set use the defaults.
+type RequeueIntervals struct {
+	// Demote is the interval after the volume is demoted, or failed to be
+	// marked secondary.
+	Demote time.Duration
+	// Resync is the interval while the volume is resyncing.
+	Resync time.Duration
+	// Info is the interval to refresh the replication info, when the
+	// VolumeReplicationClass does not have a schedulingInterval.
+	Info time.Duration
+	// Max is the maximum of the Demote and Resync intervals, including the
+	// backoff of degraded volumes.
+	Max time.Duration
+}
+
+// withDefaults returns the intervals with the defaults for the ones that are
+// not set, and the intervals configured in the VolumeReplicationClass
+// parameters.
+func (ri RequeueIntervals) withDefaults(vrcParameters map[string]string, logger logr.Logger) RequeueIntervals {
+	if ri.Max <= 0 {
+		ri.Max = defaultMaxRequeueInterval
+	}
+	if ri.Demote <= 0 {
+		ri.Demote = defaultDemoteRequeueInterval
+	}
+	if ri.Resync <= 0 {
+		ri.Resync = defaultResyncRequeueInterval
+	}
+	if ri.Info <= 0 {
+		ri.Info = defaultScheduleTime
+	}
+
+	ri.Demote = getRequeueIntervalParameter(vrcParameters, prefixedDemoteRequeueIntervalKey, ri.Demote, ri.Max, logger)
+	ri.Resync = getRequeueIntervalParameter(vrcParameters, prefixedResyncRequeueIntervalKey, ri.Resync, ri.Max, logger)
+	ri.Info = getRequeueIntervalParameter(vrcParameters, prefixedInfoRequeueIntervalKey, ri.Info, maxInfoRequeueInterval, logger)
+
+	return ri
+}
+
+// getRequeueIntervalParameter returns the interval from the parameter with the
+// given key, bounded by minRequeueInterval and max. The default is returned if
+// the parameter is not set or cannot be parsed.
+func getRequeueIntervalParameter(parameters map[string]string, key string, def, max time.Duration, logger logr.Logger) time.Duration {
+	interval := def
+	if raw, ok := parameters[key]; ok {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			logger.Error(err, "failed to parse requeue interval", "key", key, "value", raw)
+		} else {
+			interval = parsed
+		}
+	}
+
+	if interval < minRequeueInterval {
+		return minRequeueInterval
+	}
+	if interval > max {
+		return max
+	}
+
+	return interval
+}
+
+// getDegradedRequeueInterval doubles the interval for every
+// degradedBackoffAfter that the volume has been degraded, up to max. This
+// reduces the load of volumes that stay degraded for a long time.
+func getDegradedRequeueInterval(interval, max time.Duration, conditions []metav1.Condition, now time.Time) time.Duration {
+	degraded := meta.FindStatusCondition(conditions, ConditionDegraded)
+	if degraded != nil && degraded.Status == metav1.ConditionTrue {
+		steps := int(now.Sub(degraded.LastTransitionTime.Time) / degradedBackoffAfter)
+		for i := 0; i < steps && interval < max; i++ {
+			interval *= 2
+		}
+	}
+
+	if interval > max {
+		return max
+	}
+
+	return interval
+}
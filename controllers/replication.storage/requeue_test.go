@@ -0,0 +1,163 @@
+/*
+Copyright 2022 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRequeueIntervalsWithDefaults(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		intervals  RequeueIntervals
+		parameters map[string]string
+		want       RequeueIntervals
+	}{
+		{
+			name: "defaults",
+			want: RequeueIntervals{
+				Demote: defaultDemoteRequeueInterval,
+				Resync: defaultResyncRequeueInterval,
+				Info:   defaultScheduleTime,
+				Max:    defaultMaxRequeueInterval,
+			},
+		},
+		{
+			name: "configured intervals",
+			intervals: RequeueIntervals{
+				Demote: 20 * time.Second,
+				Resync: time.Minute,
+				Info:   2 * time.Hour,
+				Max:    10 * time.Minute,
+			},
+			want: RequeueIntervals{
+				Demote: 20 * time.Second,
+				Resync: time.Minute,
+				Info:   2 * time.Hour,
+				Max:    10 * time.Minute,
+			},
+		},
+		{
+			name: "class parameters override the configured intervals",
+			intervals: RequeueIntervals{
+				Demote: 20 * time.Second,
+			},
+			parameters: map[string]string{
+				prefixedDemoteRequeueIntervalKey: "10s",
+				prefixedResyncRequeueIntervalKey: "1m",
+				prefixedInfoRequeueIntervalKey:   "30m",
+			},
+			want: RequeueIntervals{
+				Demote: 10 * time.Second,
+				Resync: time.Minute,
+				Info:   30 * time.Minute,
+				Max:    defaultMaxRequeueInterval,
+			},
+		},
+		{
+			name: "class parameters are bounded",
+			parameters: map[string]string{
+				prefixedDemoteRequeueIntervalKey: "1s",
+				prefixedResyncRequeueIntervalKey: "1h",
+				prefixedInfoRequeueIntervalKey:   "48h",
+			},
+			want: RequeueIntervals{
+				Demote: minRequeueInterval,
+				Resync: defaultMaxRequeueInterval,
+				Info:   maxInfoRequeueInterval,
+				Max:    defaultMaxRequeueInterval,
+			},
+		},
+		{
+			name: "invalid class parameter",
+			parameters: map[string]string{
+				prefixedResyncRequeueIntervalKey: "often",
+			},
+			want: RequeueIntervals{
+				Demote: defaultDemoteRequeueInterval,
+				Resync: defaultResyncRequeueInterval,
+				Info:   defaultScheduleTime,
+				Max:    defaultMaxRequeueInterval,
+			},
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, newtt.want, newtt.intervals.withDefaults(newtt.parameters, testr.New(t)))
+		})
+	}
+}
+
+func TestGetDegradedRequeueInterval(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	degradedSince := func(d time.Duration, status metav1.ConditionStatus) []metav1.Condition {
+		return []metav1.Condition{
+			{
+				Type:               ConditionDegraded,
+				Status:             status,
+				LastTransitionTime: metav1.NewTime(now.Add(-d)),
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		conditions []metav1.Condition
+		want       time.Duration
+	}{
+		{
+			name: "no conditions",
+			want: 30 * time.Second,
+		},
+		{
+			name:       "not degraded",
+			conditions: degradedSince(time.Hour, metav1.ConditionFalse),
+			want:       30 * time.Second,
+		},
+		{
+			name:       "recently degraded",
+			conditions: degradedSince(time.Minute, metav1.ConditionTrue),
+			want:       30 * time.Second,
+		},
+		{
+			name:       "degraded for 20 minutes",
+			conditions: degradedSince(20*time.Minute, metav1.ConditionTrue),
+			want:       2 * time.Minute,
+		},
+		{
+			name:       "degraded for a day",
+			conditions: degradedSince(24*time.Hour, metav1.ConditionTrue),
+			want:       5 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, newtt.want, getDegradedRequeueInterval(30*time.Second, 5*time.Minute, newtt.conditions, now))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2022 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	replicationv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/replication.storage/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// checkReplicationSecret checks that the replication secret exists and
+// contains data, as the driver reads it on every request. It returns the
+// reason of the SecretValid condition and a message describing it. An error is
+// only returned when the secret could not be read.
+func (r *VolumeReplicationReconciler) checkReplicationSecret(ctx context.Context, ref types.NamespacedName) (string, string, error) {
+	secret := &corev1.Secret{}
+	err := r.Client.Get(ctx, ref, secret)
+	if errors.IsNotFound(err) {
+		return SecretNotFound, fmt.Sprintf("replication secret %q not found", ref), nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get replication secret %q: %w", ref, err)
+	}
+
+	if len(secret.Data) == 0 {
+		return SecretInvalid, fmt.Sprintf("replication secret %q does not contain any data", ref), nil
+	}
+
+	return Valid, "", nil
+}
+
+// findVolumeReplicationsForSecret returns the VolumeReplications whose
+// VolumeReplicationClass refers to the secret, so that they are reconciled
+// when the secret is created, rotated or deleted. The VolumeReplicationClasses
+// are indexed by their secrets by the VolumeReplicationClass controller.
+func (r *VolumeReplicationReconciler) findVolumeReplicationsForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	vrcs := &replicationv1alpha1.VolumeReplicationClassList{}
+	err := r.Client.List(ctx, vrcs,
+		client.MatchingFields{vrcSecretKey: client.ObjectKeyFromObject(obj).String()})
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to list volumeReplicationClasses", "Secret", obj.GetName())
+
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for i := range vrcs.Items {
+		requests = append(requests, r.findVolumeReplicationsForVolumeReplicationClass(ctx, &vrcs.Items[i])...)
+	}
+
+	return requests
+}
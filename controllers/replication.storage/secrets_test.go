@@ -0,0 +1,126 @@
+/*
+Copyright 2022 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	replicationv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/replication.storage/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestCheckReplicationSecret(t *testing.T) {
+	t.Parallel()
+	ref := types.NamespacedName{Name: "replication-secret", Namespace: mockNamespace}
+
+	tests := []struct {
+		name       string
+		secret     *corev1.Secret
+		wantReason string
+	}{
+		{
+			name:       "secret not found",
+			wantReason: SecretNotFound,
+		},
+		{
+			name: "secret without data",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: ref.Name, Namespace: ref.Namespace},
+			},
+			wantReason: SecretInvalid,
+		},
+		{
+			name: "valid secret",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: ref.Name, Namespace: ref.Namespace},
+				Data:       map[string][]byte{"userKey": []byte("key")},
+			},
+			wantReason: Valid,
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			objects := []client.Object{}
+			if newtt.secret != nil {
+				objects = append(objects, newtt.secret.DeepCopy())
+			}
+			r := &VolumeReplicationReconciler{
+				Client: fake.NewClientBuilder().WithScheme(createFakeScheme(t)).WithObjects(objects...).Build(),
+			}
+
+			reason, message, err := r.checkReplicationSecret(context.TODO(), ref)
+			assert.NoError(t, err)
+			assert.Equal(t, newtt.wantReason, reason)
+			assert.Equal(t, newtt.wantReason == Valid, message == "")
+		})
+	}
+}
+
+func TestFindVolumeReplicationsForSecret(t *testing.T) {
+	t.Parallel()
+	newVRC := func(name, secretName string) *replicationv1alpha1.VolumeReplicationClass {
+		return &replicationv1alpha1.VolumeReplicationClass{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: replicationv1alpha1.VolumeReplicationClassSpec{
+				Provisioner: "test-driver",
+				Parameters: map[string]string{
+					prefixedReplicationSecretNameKey:      secretName,
+					prefixedReplicationSecretNamespaceKey: mockNamespace,
+				},
+			},
+		}
+	}
+	newVR := func(name, class string) *replicationv1alpha1.VolumeReplication {
+		return &replicationv1alpha1.VolumeReplication{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: mockNamespace},
+			Spec:       replicationv1alpha1.VolumeReplicationSpec{VolumeReplicationClass: class},
+		}
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(createFakeScheme(t)).
+		WithObjects(
+			newVRC("class-1", "secret-1"),
+			newVRC("class-2", "secret-2"),
+			newVR("vr-1", "class-1"),
+			newVR("vr-2", "class-2"),
+			newVR("vr-3", "class-1"),
+		).
+		WithIndex(&replicationv1alpha1.VolumeReplication{}, volumeReplicationClassKey, indexVolumeReplicationByClass).
+		WithIndex(&replicationv1alpha1.VolumeReplicationClass{}, vrcSecretKey, indexVolumeReplicationClassBySecret).
+		Build()
+	r := &VolumeReplicationReconciler{Client: c}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "secret-1", Namespace: mockNamespace}}
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "vr-1", Namespace: mockNamespace}},
+		{NamespacedName: types.NamespacedName{Name: "vr-3", Namespace: mockNamespace}},
+	}, r.findVolumeReplicationsForSecret(context.TODO(), secret))
+
+	secret = &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "secret-1", Namespace: "other-ns"}}
+	assert.Empty(t, r.findVolumeReplicationsForSecret(context.TODO(), secret))
+}
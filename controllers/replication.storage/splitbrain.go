@@ -0,0 +1,200 @@
+/*
+Copyright 2022 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	replicationv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/replication.storage/v1alpha1"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// peerKubeconfigSecretKey is the key of the kubeconfig in the peer
+	// kubeconfig Secret.
+	peerKubeconfigSecretKey = "kubeconfig"
+
+	// splitBrainRequeueInterval is the interval at which a promotion that is
+	// blocked by a primary peer is retried.
+	splitBrainRequeueInterval = time.Minute
+)
+
+// peerClient is a client for the peer cluster, built from the kubeconfig in
+// the Secret with the given resourceVersion.
+type peerClient struct {
+	resourceVersion string
+	client          client.Client
+}
+
+// getPeerVolumeReplication returns the VolumeReplication with the same name
+// and namespace from the peer cluster configured in the
+// VolumeReplicationClass. It returns nil if no peer cluster is configured, or
+// if the peer VolumeReplication does not exist.
+func (r *VolumeReplicationReconciler) getPeerVolumeReplication(
+	ctx context.Context,
+	vrc *replicationv1alpha1.VolumeReplicationClass,
+	instance *replicationv1alpha1.VolumeReplication) (*replicationv1alpha1.VolumeReplication, error) {
+	secretName := vrc.Spec.Parameters[prefixedPeerKubeconfigSecretNameKey]
+	secretNamespace := vrc.Spec.Parameters[prefixedPeerKubeconfigSecretNamespaceKey]
+	if secretName == "" || secretNamespace == "" {
+		return nil, nil
+	}
+
+	c, err := r.getPeerClient(ctx, types.NamespacedName{Name: secretName, Namespace: secretNamespace})
+	if err != nil {
+		return nil, err
+	}
+
+	peer := &replicationv1alpha1.VolumeReplication{}
+	err = c.Get(ctx, types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, peer)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return peer, nil
+}
+
+// getPeerClient returns a client for the peer cluster. The client is cached
+// until the Secret changes.
+func (r *VolumeReplicationReconciler) getPeerClient(ctx context.Context, secretRef types.NamespacedName) (client.Client, error) {
+	return r.peerClients.get(ctx, r.Client, r.Scheme, r.Timeout, secretRef)
+}
+
+// peerClientCache caches the clients for the peer clusters by the namespaced
+// name of their kubeconfig Secret.
+type peerClientCache struct {
+	clients map[string]*peerClient
+	lock    sync.Mutex
+}
+
+// get returns a client for the peer cluster configured in the kubeconfig
+// Secret, which is read with the local client c. The client is cached until
+// the Secret changes.
+func (pc *peerClientCache) get(
+	ctx context.Context,
+	c client.Client,
+	scheme *runtime.Scheme,
+	timeout time.Duration,
+	secretRef types.NamespacedName) (client.Client, error) {
+	secret := &corev1.Secret{}
+	err := c.Get(ctx, secretRef, secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get peer kubeconfig secret %q: %w", secretRef, err)
+	}
+
+	pc.lock.Lock()
+	defer pc.lock.Unlock()
+
+	if cached, ok := pc.clients[secretRef.String()]; ok && cached.resourceVersion == secret.ResourceVersion {
+		return cached.client, nil
+	}
+
+	kubeconfig, ok := secret.Data[peerKubeconfigSecretKey]
+	if !ok {
+		return nil, fmt.Errorf("peer kubeconfig secret %q does not contain the %q key", secretRef, peerKubeconfigSecretKey)
+	}
+
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse peer kubeconfig from secret %q: %w", secretRef, err)
+	}
+	config.Timeout = timeout
+
+	peer, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for the peer cluster: %w", err)
+	}
+
+	if pc.clients == nil {
+		pc.clients = map[string]*peerClient{}
+	}
+	pc.clients[secretRef.String()] = &peerClient{
+		resourceVersion: secret.ResourceVersion,
+		client:          peer,
+	}
+
+	return peer, nil
+}
+
+// checkSplitBrain compares the VolumeReplication with its peer and sets the
+// SplitBrain condition. It returns true when the promotion of the volume must
+// be refused because the peer is primary.
+func (r *VolumeReplicationReconciler) checkSplitBrain(
+	ctx context.Context,
+	logger logr.Logger,
+	vrc *replicationv1alpha1.VolumeReplicationClass,
+	instance *replicationv1alpha1.VolumeReplication) bool {
+	if !hasPeerCluster(vrc) {
+		return false
+	}
+
+	peer, err := r.getPeerVolumeReplication(ctx, vrc, instance)
+	if err != nil {
+		// An unreachable peer must not block the promotion, as that is
+		// the case the promotion is needed for.
+		logger.Error(err, "failed to get peer VolumeReplication")
+		setPeerUnreachableCondition(&instance.Status.Conditions, instance.Generation)
+
+		return false
+	}
+
+	if !isPeerPrimary(peer) {
+		setNoSplitBrainCondition(&instance.Status.Conditions, instance.Generation)
+
+		return false
+	}
+
+	setSplitBrainCondition(&instance.Status.Conditions, instance.Generation)
+	if instance.Status.State != replicationv1alpha1.PrimaryState {
+		logger.Info("peer VolumeReplication is primary, refusing to promote volume")
+
+		return true
+	}
+
+	logger.Info("split-brain detected, both the volume and its peer are primary")
+	r.Recorder.Eventf(instance, corev1.EventTypeWarning, "SplitBrain",
+		"both the volume and the peer volume are primary, demote one of them to resolve the split-brain")
+
+	return false
+}
+
+// hasPeerCluster returns true if a peer cluster is configured in the
+// VolumeReplicationClass.
+func hasPeerCluster(vrc *replicationv1alpha1.VolumeReplicationClass) bool {
+	return vrc.Spec.Parameters[prefixedPeerKubeconfigSecretNameKey] != "" &&
+		vrc.Spec.Parameters[prefixedPeerKubeconfigSecretNamespaceKey] != ""
+}
+
+// isPeerPrimary returns true if the peer VolumeReplication reports that its
+// volume is primary.
+func isPeerPrimary(peer *replicationv1alpha1.VolumeReplication) bool {
+	return peer != nil && peer.Status.State == replicationv1alpha1.PrimaryState
+}
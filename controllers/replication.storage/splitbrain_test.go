@@ -0,0 +1,144 @@
+/*
+Copyright 2022 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	replicationv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/replication.storage/v1alpha1"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCheckSplitBrain(t *testing.T) {
+	t.Parallel()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "peer-kubeconfig",
+			Namespace: mockNamespace,
+		},
+	}
+	vrc := &replicationv1alpha1.VolumeReplicationClass{
+		Spec: replicationv1alpha1.VolumeReplicationClassSpec{
+			Parameters: map[string]string{
+				prefixedPeerKubeconfigSecretNameKey:      secret.Name,
+				prefixedPeerKubeconfigSecretNamespaceKey: secret.Namespace,
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		localState    replicationv1alpha1.State
+		peerState     replicationv1alpha1.State
+		createPeer    bool
+		wantBlocked   bool
+		wantCondition metav1.ConditionStatus
+		wantEvents    int
+	}{
+		{
+			name:          "peer does not exist",
+			localState:    replicationv1alpha1.SecondaryState,
+			createPeer:    false,
+			wantBlocked:   false,
+			wantCondition: metav1.ConditionFalse,
+		},
+		{
+			name:          "peer is secondary",
+			localState:    replicationv1alpha1.SecondaryState,
+			peerState:     replicationv1alpha1.SecondaryState,
+			createPeer:    true,
+			wantBlocked:   false,
+			wantCondition: metav1.ConditionFalse,
+		},
+		{
+			name:          "peer is primary before promotion",
+			localState:    replicationv1alpha1.SecondaryState,
+			peerState:     replicationv1alpha1.PrimaryState,
+			createPeer:    true,
+			wantBlocked:   true,
+			wantCondition: metav1.ConditionTrue,
+		},
+		{
+			name:          "both are primary",
+			localState:    replicationv1alpha1.PrimaryState,
+			peerState:     replicationv1alpha1.PrimaryState,
+			createPeer:    true,
+			wantBlocked:   false,
+			wantCondition: metav1.ConditionTrue,
+			wantEvents:    1,
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			instance := &replicationv1alpha1.VolumeReplication{}
+			mockVolumeReplicationObj.DeepCopyInto(instance)
+			instance.Status.State = newtt.localState
+
+			scheme := createFakeScheme(t)
+			peerBuilder := fake.NewClientBuilder().WithScheme(scheme)
+			if newtt.createPeer {
+				peer := &replicationv1alpha1.VolumeReplication{}
+				mockVolumeReplicationObj.DeepCopyInto(peer)
+				peer.Status.State = newtt.peerState
+				peerBuilder = peerBuilder.WithObjects(peer)
+			}
+
+			recorder := record.NewFakeRecorder(1)
+			r := createFakeVolumeReplicationReconciler(t, secret.DeepCopy())
+			r.Recorder = recorder
+
+			// the peer client is cached for the current version of the
+			// Secret, so that no kubeconfig is needed.
+			s := &corev1.Secret{}
+			err := r.Client.Get(context.TODO(), types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}, s)
+			assert.NoError(t, err)
+			r.peerClients.clients = map[string]*peerClient{
+				types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}.String(): {
+					resourceVersion: s.ResourceVersion,
+					client:          peerBuilder.Build(),
+				},
+			}
+
+			blocked := r.checkSplitBrain(context.TODO(), testr.New(t), vrc, instance)
+			assert.Equal(t, newtt.wantBlocked, blocked)
+			assert.True(t, meta.IsStatusConditionPresentAndEqual(instance.Status.Conditions, ConditionSplitBrain, newtt.wantCondition))
+			assert.Len(t, recorder.Events, newtt.wantEvents)
+		})
+	}
+}
+
+func TestCheckSplitBrainWithoutPeer(t *testing.T) {
+	t.Parallel()
+	r := createFakeVolumeReplicationReconciler(t)
+	instance := &replicationv1alpha1.VolumeReplication{}
+	mockVolumeReplicationObj.DeepCopyInto(instance)
+
+	blocked := r.checkSplitBrain(context.TODO(), testr.New(t), mockVolumeReplicationClassObj, instance)
+	assert.False(t, blocked)
+	assert.Nil(t, meta.FindStatusCondition(instance.Status.Conditions, ConditionSplitBrain))
+}
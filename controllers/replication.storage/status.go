@@ -23,9 +23,13 @@ import (
 )
 
 const (
-	ConditionCompleted = "Completed"
-	ConditionDegraded  = "Degraded"
-	ConditionResyncing = "Resyncing"
+	ConditionCompleted  = "Completed"
+	ConditionDegraded   = "Degraded"
+	ConditionResyncing  = "Resyncing"
+	ConditionSplitBrain = "SplitBrain"
+	// ConditionSecretValid reports whether the replication secret of the
+	// VolumeReplicationClass exists and contains data.
+	ConditionSecretValid = "SecretValid"
 )
 
 const (
@@ -40,6 +44,13 @@ const (
 	ResyncTriggered = "ResyncTriggered"
 	FailedToResync  = "FailedToResync"
 	NotResyncing    = "NotResyncing"
+	PeerIsPrimary   = "PeerIsPrimary"
+	NoSplitBrain    = "NoSplitBrain"
+	PeerUnreachable = "PeerUnreachable"
+
+	UnsupportedReplicationMode = "UnsupportedReplicationMode"
+	SecretInvalid              = "SecretInvalid"
+	DataSourceNotFound         = "DataSourceNotFound"
 )
 
 // sets conditions when volume was promoted successfully.
@@ -146,6 +157,22 @@ func setFailedDemotionCondition(conditions *[]metav1.Condition, observedGenerati
 	})
 }
 
+// sets conditions when the replication mode is not supported by the driver.
+func setUnsupportedModeCondition(conditions *[]metav1.Condition, observedGeneration int64) {
+	setStatusCondition(conditions, &metav1.Condition{
+		Type:               ConditionCompleted,
+		Reason:             UnsupportedReplicationMode,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionFalse,
+	})
+	setStatusCondition(conditions, &metav1.Condition{
+		Type:               ConditionDegraded,
+		Reason:             Error,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionTrue,
+	})
+}
+
 // sets conditions when volume resync was triggered successfully.
 func setResyncCondition(conditions *[]metav1.Condition, observedGeneration int64) {
 	setStatusCondition(conditions, &metav1.Condition{
@@ -190,6 +217,80 @@ func setFailedResyncCondition(conditions *[]metav1.Condition, observedGeneration
 	})
 }
 
+// sets conditions when both the volume and its peer are primary, or when the
+// peer is primary while the volume is to be promoted.
+func setSplitBrainCondition(conditions *[]metav1.Condition, observedGeneration int64) {
+	setStatusCondition(conditions, &metav1.Condition{
+		Type:               ConditionSplitBrain,
+		Reason:             PeerIsPrimary,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionTrue,
+	})
+}
+
+// sets conditions when the peer is not primary.
+func setNoSplitBrainCondition(conditions *[]metav1.Condition, observedGeneration int64) {
+	setStatusCondition(conditions, &metav1.Condition{
+		Type:               ConditionSplitBrain,
+		Reason:             NoSplitBrain,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionFalse,
+	})
+}
+
+// sets conditions when the state of the peer could not be fetched.
+func setPeerUnreachableCondition(conditions *[]metav1.Condition, observedGeneration int64) {
+	setStatusCondition(conditions, &metav1.Condition{
+		Type:               ConditionSplitBrain,
+		Reason:             PeerUnreachable,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionUnknown,
+	})
+}
+
+// sets conditions when the replication secret exists and contains data.
+func setSecretValidCondition(conditions *[]metav1.Condition, observedGeneration int64) {
+	setStatusCondition(conditions, &metav1.Condition{
+		Type:               ConditionSecretValid,
+		Reason:             Valid,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionTrue,
+	})
+}
+
+// sets conditions when the replication secret is not found, or does not
+// contain any data.
+func setSecretInvalidCondition(conditions *[]metav1.Condition, observedGeneration int64, reason string) {
+	setStatusCondition(conditions, &metav1.Condition{
+		Type:               ConditionSecretValid,
+		Reason:             reason,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionFalse,
+	})
+	setStatusCondition(conditions, &metav1.Condition{
+		Type:               ConditionDegraded,
+		Reason:             Error,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionTrue,
+	})
+}
+
+// sets conditions when the Pod or StatefulSet data source does not exist.
+func setDataSourceNotFoundCondition(conditions *[]metav1.Condition, observedGeneration int64) {
+	setStatusCondition(conditions, &metav1.Condition{
+		Type:               ConditionCompleted,
+		Reason:             DataSourceNotFound,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionFalse,
+	})
+	setStatusCondition(conditions, &metav1.Condition{
+		Type:               ConditionDegraded,
+		Reason:             DataSourceNotFound,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionTrue,
+	})
+}
+
 func setStatusCondition(existingConditions *[]metav1.Condition, newCondition *metav1.Condition) {
 	if existingConditions == nil {
 		existingConditions = &[]metav1.Condition{}
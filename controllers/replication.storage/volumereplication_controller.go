@@ -31,6 +31,7 @@ import (
 	"github.com/csi-addons/spec/lib/go/identity"
 	"github.com/go-logr/logr"
 	"google.golang.org/grpc/codes"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -39,9 +40,13 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -52,6 +57,13 @@ const (
 	volumeReplicationClass = "VolumeReplicationClass"
 	volumeReplication      = "VolumeReplication"
 	defaultScheduleTime    = time.Hour
+
+	// volumeReplicationDataSourceKey indexes VolumeReplications by the kind
+	// and name of their data source.
+	volumeReplicationDataSourceKey = "spec.dataSource"
+	// volumeReplicationClassKey indexes VolumeReplications by the name of
+	// their VolumeReplicationClass.
+	volumeReplicationClassKey = replicationv1alpha1.VolumeReplicationClassField
 )
 
 var (
@@ -69,14 +81,26 @@ type VolumeReplicationReconciler struct {
 	// Timeout for the Reconcile operation.
 	Timeout     time.Duration
 	Replication grpcClient.VolumeReplication
+	// Recorder is used to record events for the replication operations.
+	Recorder record.EventRecorder
+	// RequeueIntervals are the intervals at which the state of the
+	// VolumeReplication is polled.
+	RequeueIntervals RequeueIntervals
+
+	// peerClients caches the clients for the peer clusters.
+	peerClients peerClientCache
 }
 
-// +kubebuilder:rbac:groups=replication.storage.openshift.io,resources=volumereplications,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups=replication.storage.openshift.io,resources=volumereplications,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups=replication.storage.openshift.io,resources=volumereplications/status,verbs=update
 // +kubebuilder:rbac:groups=replication.storage.openshift.io,resources=volumereplications/finalizers,verbs=update
 // +kubebuilder:rbac:groups=replication.storage.openshift.io,resources=volumereplicationclasses,verbs=get;list;watch
 //+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims/finalizers,verbs=update
 //+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -114,6 +138,18 @@ func (r *VolumeReplicationReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return ctrl.Result{}, err
 	}
 
+	vrcObj, err = r.migrateVolumeReplicationClass(logger, instance, vrcObj)
+	if err != nil {
+		logger.Error(err, "failed to migrate to replacement volumeReplicationClass")
+		setFailureCondition(instance)
+		uErr := r.updateReplicationStatus(instance, logger, getCurrentReplicationState(instance), err.Error())
+		if uErr != nil {
+			logger.Error(uErr, "failed to update volumeReplication status", "VRName", instance.Name)
+		}
+
+		return ctrl.Result{}, err
+	}
+
 	err = validatePrefixedParameters(vrcObj.Spec.Parameters)
 	if err != nil {
 		logger.Error(err, "failed to validate parameters of volumeReplicationClass", "VRCName", instance.Spec.VolumeReplicationClass)
@@ -125,8 +161,12 @@ func (r *VolumeReplicationReconciler) Reconcile(ctx context.Context, req ctrl.Re
 
 		return ctrl.Result{}, err
 	}
-	// remove the prefix keys in volume replication class parameters
-	parameters := filterPrefixedParameters(replicationParameterPrefix, vrcObj.Spec.Parameters)
+	requeueIntervals := r.RequeueIntervals.withDefaults(vrcObj.Spec.Parameters, logger)
+
+	// remove the prefix keys in volume replication class parameters, and
+	// add the schedule.
+	parameters := getDriverParameters(vrcObj)
+	instance.Status.Schedule = vrcObj.Spec.Schedule.DeepCopy()
 
 	// get secret
 	secretName := vrcObj.Spec.Parameters[prefixedReplicationSecretNameKey]
@@ -157,6 +197,8 @@ func (r *VolumeReplicationReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		}
 
 		volumeHandle = pv.Spec.CSI.VolumeHandle
+	case podDataSource, statefulSetDataSource:
+		return r.reconcileMembers(ctx, logger, instance)
 	default:
 		err = fmt.Errorf("unsupported datasource kind")
 		logger.Error(err, "given kind not supported", "Kind", instance.Spec.DataSource.Kind)
@@ -174,6 +216,31 @@ func (r *VolumeReplicationReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		logger.Info("Replication handle", "ReplicationHandleName", replicationHandle)
 	}
 
+	// the driver reads the replication secret on every request, a missing
+	// or empty secret is reported instead of failing the requests. The
+	// VolumeReplication is reconciled again when the secret changes.
+	if secretName != "" && secretNamespace != "" {
+		reason, message, sErr := r.checkReplicationSecret(ctx, types.NamespacedName{Name: secretName, Namespace: secretNamespace})
+		if sErr != nil {
+			logger.Error(sErr, "failed to check replication secret")
+
+			return ctrl.Result{}, sErr
+		}
+		if reason != Valid {
+			logger.Info("replication secret is not valid", "Reason", reason, "SecretName", secretName, "SecretNamespace", secretNamespace)
+			setSecretInvalidCondition(&instance.Status.Conditions, instance.Generation, reason)
+			uErr := r.updateReplicationStatus(instance, logger, getCurrentReplicationState(instance), message)
+			if uErr != nil {
+				logger.Error(uErr, "failed to update volumeReplication status", "VRName", instance.Name)
+			}
+
+			return ctrl.Result{}, nil
+		}
+		setSecretValidCondition(&instance.Status.Conditions, instance.Generation)
+	} else {
+		meta.RemoveStatusCondition(&instance.Status.Conditions, ConditionSecretValid)
+	}
+
 	replicationClient, err := r.getReplicationClient(vrcObj.Spec.Provisioner)
 	if err != nil {
 		logger.Error(err, "Failed to get ReplicationClient")
@@ -181,6 +248,22 @@ func (r *VolumeReplicationReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return ctrl.Result{}, err
 	}
 
+	// the replication of a volume being deleted is disabled, whatever the
+	// mode.
+	if instance.GetDeletionTimestamp().IsZero() {
+		err = checkReplicationMode(r.Connpool.GetByNodeID(vrcObj.Spec.Provisioner, ""), vrcObj.Spec.Provisioner, vrcObj.Spec.Mode)
+		if err != nil {
+			logger.Error(err, "replication mode is not supported", "Mode", vrcObj.Spec.Mode)
+			setUnsupportedModeCondition(&instance.Status.Conditions, instance.Generation)
+			uErr := r.updateReplicationStatus(instance, logger, getCurrentReplicationState(instance), err.Error())
+			if uErr != nil {
+				logger.Error(uErr, "failed to update volumeReplication status", "VRName", instance.Name)
+			}
+
+			return ctrl.Result{}, nil
+		}
+	}
+
 	vr := &volumeReplicationInstance{
 		logger:   logger,
 		instance: instance,
@@ -255,6 +338,19 @@ func (r *VolumeReplicationReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return reconcile.Result{}, err
 	}
 
+	if instance.Spec.ReplicationState == replicationv1alpha1.Primary && r.checkSplitBrain(ctx, logger, vrcObj, instance) {
+		err = r.updateReplicationStatus(instance, logger, getCurrentReplicationState(instance),
+			"peer volume is primary, refusing to promote volume to avoid a split-brain")
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{
+			Requeue:      true,
+			RequeueAfter: splitBrainRequeueInterval,
+		}, nil
+	}
+
 	var replicationErr error
 	var requeueForResync bool
 
@@ -288,6 +384,9 @@ func (r *VolumeReplicationReconciler) Reconcile(ctx context.Context, req ctrl.Re
 			replicationErr = r.markVolumeAsSecondary(vr)
 			if replicationErr == nil {
 				logger.Info("volume is not ready to use")
+				// the sync details of the primary volume do not apply
+				// to the secondary volume.
+				clearLastSyncInfo(&instance.Status)
 				// set the status.State to secondary as the
 				// instance.Status.State is primary for the first time.
 				err = r.updateReplicationStatus(instance, logger, getReplicationState(instance), "volume is marked secondary and is degraded")
@@ -296,9 +395,8 @@ func (r *VolumeReplicationReconciler) Reconcile(ctx context.Context, req ctrl.Re
 				}
 
 				return ctrl.Result{
-					Requeue: true,
-					// Setting Requeue time for 15 seconds
-					RequeueAfter: time.Second * 15,
+					Requeue:      true,
+					RequeueAfter: requeueIntervals.Demote,
 				}, nil
 			}
 		} else {
@@ -337,8 +435,10 @@ func (r *VolumeReplicationReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		if instance.Status.State == replicationv1alpha1.SecondaryState {
 			return ctrl.Result{
 				Requeue: true,
-				// in case of any error during secondary state, requeue for every 15 seconds.
-				RequeueAfter: time.Second * 15,
+				// in case of any error during secondary state, requeue with
+				// a backoff while the volume stays degraded.
+				RequeueAfter: getDegradedRequeueInterval(requeueIntervals.Demote, requeueIntervals.Max,
+					instance.Status.Conditions, time.Now()),
 			}, nil
 		}
 
@@ -348,17 +448,25 @@ func (r *VolumeReplicationReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	if requeueForResync {
 		logger.Info("volume is not ready to use, requeuing for resync")
 
-		err = r.updateReplicationStatus(instance, logger, getCurrentReplicationState(instance), "volume is degraded")
+		// report how far the secondary volume is behind while it is resyncing.
+		if instance.Spec.ReplicationState == replicationv1alpha1.Secondary {
+			if _, err = r.updateReplicationInfo(vr); err != nil {
+				logger.Info("failed to get volume replication info of secondary volume", "error", err)
+			}
+		}
+
+		err = r.updateReplicationStatus(instance, logger, getCurrentReplicationState(instance), getResyncMessage(instance.Status.ResyncProgress))
 		if err != nil {
 			logger.Error(err, "failed to update volumeReplication status", "VRName", instance.Name)
 		}
 
 		return ctrl.Result{
 			Requeue: true,
-			// Setting Requeue time for 30 seconds as the resync can take time
-			// and having default Requeue exponential backoff time can affect
-			// the RTO time.
-			RequeueAfter: time.Second * 30,
+			// The resync can take time and having default Requeue
+			// exponential backoff time can affect the RTO time. Volumes
+			// that stay degraded for long are polled less often.
+			RequeueAfter: getDegradedRequeueInterval(requeueIntervals.Resync, requeueIntervals.Max,
+				instance.Status.Conditions, time.Now()),
 		}, nil
 	}
 
@@ -373,33 +481,21 @@ func (r *VolumeReplicationReconciler) Reconcile(ctx context.Context, req ctrl.Re
 
 	requeueForInfo := false
 
-	if instance.Spec.ReplicationState == replicationv1alpha1.Primary {
-		info, err := r.getVolumeReplicationInfo(vr)
-		if err == nil {
-			ts := info.GetLastSyncTime()
-			if ts != nil {
-				lastSyncTime := metav1.NewTime(ts.AsTime())
-				instance.Status.LastSyncTime = &lastSyncTime
-				instance.Status.LastSyncDuration = nil
-				instance.Status.LastSyncBytes = nil
-				td := info.GetLastSyncDuration()
-				if td != nil {
-					lastSyncDuration := metav1.Duration{Duration: td.AsDuration()}
-					instance.Status.LastSyncDuration = &lastSyncDuration
-				}
-				tb := info.GetLastSyncBytes()
-				if tb != 0 {
-					instance.Status.LastSyncBytes = &tb
-				}
-			}
-			requeueForInfo = true
-		} else if !util.IsUnimplementedError(err) {
+	switch instance.Spec.ReplicationState {
+	case replicationv1alpha1.Primary:
+		requeueForInfo, err = r.updateReplicationInfo(vr)
+		if err != nil {
 			logger.Error(err, "Failed to get volume replication info")
 			return ctrl.Result{}, err
 		}
-	}
-	if instance.Spec.ReplicationState == replicationv1alpha1.Secondary {
-		instance.Status.LastSyncTime = nil
+	case replicationv1alpha1.Secondary:
+		// Not all drivers report the replication info of secondary
+		// volumes, failing to get it does not fail the reconcile.
+		requeueForInfo, err = r.updateReplicationInfo(vr)
+		if err != nil {
+			logger.Info("failed to get volume replication info of secondary volume", "error", err)
+			requeueForInfo = true
+		}
 	}
 	err = r.updateReplicationStatus(instance, logger, getReplicationState(instance), msg)
 	if err != nil {
@@ -409,7 +505,7 @@ func (r *VolumeReplicationReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	logger.Info(msg)
 
 	if requeueForInfo {
-		reconcileInternal := getInfoReconcileInterval(parameters, logger)
+		reconcileInternal := getInfoReconcileInterval(parameters, requeueIntervals.Info, logger)
 		return ctrl.Result{
 			Requeue:      true,
 			RequeueAfter: reconcileInternal,
@@ -422,23 +518,23 @@ func (r *VolumeReplicationReconciler) Reconcile(ctx context.Context, req ctrl.Re
 // getInfoReconcileInterval takes parameters and returns the half of the scheduling
 // interval after converting it to time.Duration. The interval is converted
 // into half to keep the LastSyncTime and Storage LastSyncTime to be in sync.
-// If the schedulingInterval is empty or there is error parsing, it is set to
-// the default value.
-func getInfoReconcileInterval(parameters map[string]string, logger logr.Logger) time.Duration {
+// If the schedulingInterval is empty or there is error parsing, the given
+// default interval is returned.
+func getInfoReconcileInterval(parameters map[string]string, defaultInterval time.Duration, logger logr.Logger) time.Duration {
 	// the schedulingInterval looks like below, which is the part of volumereplicationclass
 	// and is an optional parameter.
 	// ```parameters:
 	// 		replication.storage.openshift.io/replication-secret-name: rook-csi-rbd-provisioner
 	//		replication.storage.openshift.io/replication-secret-namespace: rook-ceph
 	//		schedulingInterval: 1m```
-	rawScheduleTime := parameters["schedulingInterval"]
+	rawScheduleTime := parameters[replicationv1alpha1.ScheduleIntervalParameter]
 	if rawScheduleTime == "" {
-		return defaultScheduleTime
+		return defaultInterval
 	}
 	scheduleTime, err := time.ParseDuration(rawScheduleTime)
 	if err != nil {
 		logger.Error(err, "failed to parse time: %v", rawScheduleTime)
-		return defaultScheduleTime
+		return defaultInterval
 	}
 	// Return schedule internal to avoid frequent reconcile if the
 	// schedulingInterval is less than 2 minutes.
@@ -502,15 +598,194 @@ func (r *VolumeReplicationReconciler) SetupWithManager(mgr ctrl.Manager, ctrlOpt
 		return err
 	}
 
+	err = r.setupIndexers(mgr)
+	if err != nil {
+		return err
+	}
+
 	pred := predicate.GenerationChangedPredicate{}
 
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&replicationv1alpha1.VolumeReplication{}).
-		WithEventFilter(pred).
+		For(&replicationv1alpha1.VolumeReplication{}, builder.WithPredicates(pred)).
+		Watches(
+			&corev1.PersistentVolumeClaim{},
+			handler.EnqueueRequestsFromMapFunc(r.findVolumeReplicationsForPVC),
+			builder.WithPredicates(pvcBindingChangedPredicate()),
+		).
+		// only the metadata of Pods and StatefulSets is cached, the data
+		// sources are read from the API server.
+		Watches(
+			&corev1.Pod{},
+			handler.EnqueueRequestsFromMapFunc(r.findVolumeReplicationsForPod),
+			builder.OnlyMetadata,
+			builder.WithPredicates(podLifecyclePredicate()),
+		).
+		Watches(
+			&appsv1.StatefulSet{},
+			handler.EnqueueRequestsFromMapFunc(r.findVolumeReplicationsForStatefulSet),
+			builder.OnlyMetadata,
+			builder.WithPredicates(pred),
+		).
+		// the members of a Pod or StatefulSet report their status to
+		// their owner.
+		Owns(&replicationv1alpha1.VolumeReplication{}).
+		Watches(
+			&replicationv1alpha1.VolumeReplicationClass{},
+			handler.EnqueueRequestsFromMapFunc(r.findVolumeReplicationsForVolumeReplicationClass),
+			// the replaced-by annotation migrates the VolumeReplications.
+			builder.WithPredicates(predicate.Or(pred, predicate.AnnotationChangedPredicate{})),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findVolumeReplicationsForSecret),
+			builder.OnlyMetadata,
+		).
 		WithOptions(ctrlOptions).
 		Complete(r)
 }
 
+// setupIndexers indexes the VolumeReplications by their PVC and
+// VolumeReplicationClass, so that the VolumeReplications can be found when
+// those change.
+func (r *VolumeReplicationReconciler) setupIndexers(mgr ctrl.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&replicationv1alpha1.VolumeReplication{},
+		volumeReplicationDataSourceKey,
+		indexVolumeReplicationByDataSource)
+	if err != nil {
+		return err
+	}
+
+	return mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&replicationv1alpha1.VolumeReplication{},
+		volumeReplicationClassKey,
+		indexVolumeReplicationByClass)
+}
+
+// indexVolumeReplicationByDataSource returns the kind and name of the data
+// source of the VolumeReplication.
+func indexVolumeReplicationByDataSource(rawObj client.Object) []string {
+	vr, ok := rawObj.(*replicationv1alpha1.VolumeReplication)
+	if !ok {
+		return nil
+	}
+
+	return []string{dataSourceIndexValue(vr.Spec.DataSource.Kind, vr.Spec.DataSource.Name)}
+}
+
+// dataSourceIndexValue returns the value of the volumeReplicationDataSourceKey
+// index for a data source.
+func dataSourceIndexValue(kind, name string) string {
+	return kind + "/" + name
+}
+
+// indexVolumeReplicationByClass returns the name of the
+// VolumeReplicationClass of the VolumeReplication.
+func indexVolumeReplicationByClass(rawObj client.Object) []string {
+	vr, ok := rawObj.(*replicationv1alpha1.VolumeReplication)
+	if !ok || vr.Spec.VolumeReplicationClass == "" {
+		return nil
+	}
+
+	return []string{vr.Spec.VolumeReplicationClass}
+}
+
+// pvcBindingChangedPredicate filters the PVC updates that do not change the
+// binding of the PVC, like the owner annotation set by this controller. The
+// deletion of a PVC is passed, as the member of a scaled down StatefulSet is
+// kept until its PVC is deleted.
+func pvcBindingChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPVC, ok := e.ObjectOld.(*corev1.PersistentVolumeClaim)
+			if !ok {
+				return false
+			}
+			newPVC, ok := e.ObjectNew.(*corev1.PersistentVolumeClaim)
+			if !ok {
+				return false
+			}
+
+			return oldPVC.Status.Phase != newPVC.Status.Phase ||
+				oldPVC.Spec.VolumeName != newPVC.Spec.VolumeName ||
+				oldPVC.GetDeletionTimestamp().IsZero() != newPVC.GetDeletionTimestamp().IsZero()
+		},
+	}
+}
+
+// findVolumeReplicationsForPVC returns the VolumeReplications that use the PVC
+// as data source, and the VolumeReplications of the Pods or StatefulSets
+// those are a member of.
+func (r *VolumeReplicationReconciler) findVolumeReplicationsForPVC(ctx context.Context, obj client.Object) []reconcile.Request {
+	requests := r.findVolumeReplicationsForDataSource(ctx, pvcDataSource, obj)
+	for i := range requests {
+		vr := &replicationv1alpha1.VolumeReplication{}
+		if err := r.Client.Get(ctx, requests[i].NamespacedName, vr); err != nil {
+			continue
+		}
+		if owner, ok := vr.Labels[memberOfLabel]; ok {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: owner, Namespace: vr.Namespace},
+			})
+		}
+	}
+
+	return requests
+}
+
+// findVolumeReplicationsForPod returns the VolumeReplications that use the Pod
+// as data source.
+func (r *VolumeReplicationReconciler) findVolumeReplicationsForPod(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.findVolumeReplicationsForDataSource(ctx, podDataSource, obj)
+}
+
+// findVolumeReplicationsForStatefulSet returns the VolumeReplications that use
+// the StatefulSet as data source.
+func (r *VolumeReplicationReconciler) findVolumeReplicationsForStatefulSet(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.findVolumeReplicationsForDataSource(ctx, statefulSetDataSource, obj)
+}
+
+// findVolumeReplicationsForDataSource returns the VolumeReplications that use
+// the object of the given kind as data source.
+func (r *VolumeReplicationReconciler) findVolumeReplicationsForDataSource(ctx context.Context, kind string, obj client.Object) []reconcile.Request {
+	return r.findVolumeReplications(ctx,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{volumeReplicationDataSourceKey: dataSourceIndexValue(kind, obj.GetName())})
+}
+
+// findVolumeReplicationsForVolumeReplicationClass returns the
+// VolumeReplications that use the VolumeReplicationClass.
+func (r *VolumeReplicationReconciler) findVolumeReplicationsForVolumeReplicationClass(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.findVolumeReplications(ctx,
+		client.MatchingFields{volumeReplicationClassKey: obj.GetName()})
+}
+
+// findVolumeReplications returns a reconcile request for each of the
+// VolumeReplications matching the list options.
+func (r *VolumeReplicationReconciler) findVolumeReplications(ctx context.Context, opts ...client.ListOption) []reconcile.Request {
+	vrs := &replicationv1alpha1.VolumeReplicationList{}
+	err := r.Client.List(ctx, vrs, opts...)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to list volumeReplications")
+
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(vrs.Items))
+	for _, vr := range vrs.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      vr.Name,
+				Namespace: vr.Namespace,
+			},
+		})
+	}
+
+	return requests
+}
+
 func (r *VolumeReplicationReconciler) waitForCrds() error {
 	logger := log.FromContext(context.TODO(), "Name", "checkingDependencies")
 
@@ -570,30 +845,40 @@ func (r *VolumeReplicationReconciler) markVolumeAsPrimary(vr *volumeReplicationI
 		Params: vr.commonRequestParameters,
 	}
 
+	forcePromotion := vr.instance.Spec.ForcePromotion
+	if forcePromotion == replicationv1alpha1.ForcePromotionAlways {
+		volumeReplication.Force = true
+	}
+
 	resp := volumeReplication.Promote()
 	if resp.Error != nil {
 		isKnownError := resp.HasKnownGRPCError(volumePromotionKnownErrors)
-		if !isKnownError {
-			if resp.Error != nil {
-				vr.logger.Error(resp.Error, "failed to promote volume")
-				setFailedPromotionCondition(&vr.instance.Status.Conditions, vr.instance.Generation)
+		if !isKnownError || volumeReplication.Force || forcePromotion == replicationv1alpha1.ForcePromotionNever {
+			vr.logger.Error(resp.Error, "failed to promote volume", "ForcePromotion", forcePromotion)
+			setFailedPromotionCondition(&vr.instance.Status.Conditions, vr.instance.Generation)
 
-				return resp.Error
-			}
-		} else {
-			// force promotion
-			vr.logger.Info("force promoting volume due to known grpc error", "error", resp.Error)
-			volumeReplication.Force = true
-			resp := volumeReplication.Promote()
-			if resp.Error != nil {
-				vr.logger.Error(resp.Error, "failed to force promote volume")
-				setFailedPromotionCondition(&vr.instance.Status.Conditions, vr.instance.Generation)
-
-				return resp.Error
-			}
+			return resp.Error
 		}
+
+		// force promotion
+		vr.logger.Info("force promoting volume due to known grpc error", "error", resp.Error)
+		volumeReplication.Force = true
+		resp = volumeReplication.Promote()
+		if resp.Error != nil {
+			vr.logger.Error(resp.Error, "failed to force promote volume")
+			setFailedPromotionCondition(&vr.instance.Status.Conditions, vr.instance.Generation)
+
+			return resp.Error
+		}
+	}
+
+	if volumeReplication.Force {
+		vr.instance.Status.LastForcePromotionTime = getCurrentTime()
+		r.Recorder.Eventf(vr.instance, corev1.EventTypeWarning, "ForcePromoted",
+			"volume %s was force promoted, make sure that the peer volume is not primary", vr.commonRequestParameters.VolumeID)
 	}
 
+	vr.instance.Status.ResyncProgress = nil
 	setPromotedCondition(&vr.instance.Status.Conditions, vr.instance.Generation)
 
 	return nil
@@ -646,15 +931,93 @@ func (r *VolumeReplicationReconciler) resyncVolume(vr *volumeReplicationInstance
 	setResyncCondition(&vr.instance.Status.Conditions, vr.instance.Generation)
 
 	if !resyncResponse.GetReady() {
+		setResyncProgress(&vr.instance.Status, resyncResponse.GetProgress(), time.Now())
+
 		return true, nil
 	}
 
+	vr.instance.Status.ResyncProgress = nil
+
 	// No longer degraded, as volume is fully synced
 	setNotDegradedCondition(&vr.instance.Status.Conditions, vr.instance.Generation)
 
 	return false, nil
 }
 
+// setResyncProgress sets the progress of the resync in the status, and
+// estimates the completion time from the progress since the previous report.
+// The progress is cleared if the driver did not report it.
+func setResyncProgress(status *replicationv1alpha1.VolumeReplicationStatus, progress *proto.ResyncProgress, now time.Time) {
+	if progress == nil {
+		status.ResyncProgress = nil
+
+		return
+	}
+
+	previous := status.ResyncProgress
+	current := &replicationv1alpha1.ResyncProgress{
+		LastUpdateTime: metav1.NewTime(now),
+	}
+	if progress.GetPercentComplete() >= 0 {
+		percentComplete := progress.GetPercentComplete()
+		current.PercentComplete = &percentComplete
+	}
+	if progress.GetBytesRemaining() >= 0 {
+		bytesRemaining := progress.GetBytesRemaining()
+		current.BytesRemaining = &bytesRemaining
+	}
+
+	if previous != nil {
+		elapsed := now.Sub(previous.LastUpdateTime.Time)
+		switch {
+		case current.BytesRemaining != nil && previous.BytesRemaining != nil:
+			current.EstimatedCompletionTime = estimateCompletionTime(
+				float64(*previous.BytesRemaining-*current.BytesRemaining),
+				float64(*current.BytesRemaining), elapsed, now)
+		case current.PercentComplete != nil && previous.PercentComplete != nil:
+			current.EstimatedCompletionTime = estimateCompletionTime(
+				float64(*current.PercentComplete-*previous.PercentComplete),
+				float64(100-*current.PercentComplete), elapsed, now)
+		}
+	}
+
+	status.ResyncProgress = current
+}
+
+// estimateCompletionTime returns the time at which the remaining work is done,
+// if it continues at the rate at which done work was done during elapsed. It
+// returns nil if no progress was made.
+func estimateCompletionTime(done, remaining float64, elapsed time.Duration, now time.Time) *metav1.Time {
+	if done <= 0 || elapsed <= 0 {
+		return nil
+	}
+
+	remainingTime := time.Duration(remaining / done * float64(elapsed))
+	completionTime := metav1.NewTime(now.Add(remainingTime).Truncate(time.Second))
+
+	return &completionTime
+}
+
+// getResyncMessage returns the status message for a volume that is resyncing.
+func getResyncMessage(progress *replicationv1alpha1.ResyncProgress) string {
+	msg := "volume is degraded"
+	if progress == nil {
+		return msg
+	}
+
+	if progress.PercentComplete != nil {
+		msg = fmt.Sprintf("%s, resync is %d%% complete", msg, *progress.PercentComplete)
+	}
+	if progress.BytesRemaining != nil {
+		msg = fmt.Sprintf("%s, %d bytes remaining", msg, *progress.BytesRemaining)
+	}
+	if progress.EstimatedCompletionTime != nil {
+		msg = fmt.Sprintf("%s, estimated to complete at %s", msg, progress.EstimatedCompletionTime.UTC().Format(time.RFC3339))
+	}
+
+	return msg
+}
+
 // disableVolumeReplication defines and runs a set of tasks required to disable volume replication.
 func (r *VolumeReplicationReconciler) disableVolumeReplication(vr *volumeReplicationInstance) error {
 	volumeReplication := replication.Replication{
@@ -694,6 +1057,59 @@ func (r *VolumeReplicationReconciler) enableReplication(vr *volumeReplicationIns
 	return nil
 }
 
+// updateReplicationInfo updates the sync details in the status of the
+// VolumeReplication with the replication info reported by the driver. It
+// returns true if the replication info should be fetched again. The sync
+// details are cleared if the driver does not implement
+// GetVolumeReplicationInfo.
+func (r *VolumeReplicationReconciler) updateReplicationInfo(vr *volumeReplicationInstance) (bool, error) {
+	info, err := r.getVolumeReplicationInfo(vr)
+	if err != nil {
+		if util.IsUnimplementedError(err) {
+			clearLastSyncInfo(&vr.instance.Status)
+
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	setLastSyncInfo(&vr.instance.Status, info)
+
+	return true, nil
+}
+
+// setLastSyncInfo sets the sync details in the status from the replication
+// info. For a primary volume these describe the last sync to the peer, for a
+// secondary volume the last sync received from the peer.
+func setLastSyncInfo(status *replicationv1alpha1.VolumeReplicationStatus, info *proto.GetVolumeReplicationInfoResponse) {
+	ts := info.GetLastSyncTime()
+	if ts == nil {
+		return
+	}
+
+	lastSyncTime := metav1.NewTime(ts.AsTime())
+	status.LastSyncTime = &lastSyncTime
+	status.LastSyncDuration = nil
+	status.LastSyncBytes = nil
+	td := info.GetLastSyncDuration()
+	if td != nil {
+		lastSyncDuration := metav1.Duration{Duration: td.AsDuration()}
+		status.LastSyncDuration = &lastSyncDuration
+	}
+	tb := info.GetLastSyncBytes()
+	if tb != 0 {
+		status.LastSyncBytes = &tb
+	}
+}
+
+// clearLastSyncInfo clears the sync details in the status.
+func clearLastSyncInfo(status *replicationv1alpha1.VolumeReplicationStatus) {
+	status.LastSyncTime = nil
+	status.LastSyncDuration = nil
+	status.LastSyncBytes = nil
+}
+
 // getVolumeReplicationInfo gets volume replication info.
 func (r *VolumeReplicationReconciler) getVolumeReplicationInfo(vr *volumeReplicationInstance) (*proto.GetVolumeReplicationInfoResponse, error) {
 	volumeReplication := replication.Replication{
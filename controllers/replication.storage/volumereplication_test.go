@@ -17,10 +17,28 @@ limitations under the License.
 package controllers
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	replicationv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/replication.storage/v1alpha1"
+	"github.com/csi-addons/kubernetes-csi-addons/controllers/replication.storage/replication"
+	grpcClient "github.com/csi-addons/kubernetes-csi-addons/internal/client"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/proto"
+
 	"github.com/go-logr/logr/testr"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 func TestGetScheduledTime(t *testing.T) {
@@ -83,9 +101,371 @@ func TestGetScheduledTime(t *testing.T) {
 		newtt := tt
 		t.Run("", func(t *testing.T) {
 			t.Parallel()
-			if got := getInfoReconcileInterval(newtt.parameters, logger); got != newtt.time {
+			if got := getInfoReconcileInterval(newtt.parameters, defaultScheduleTime, logger); got != newtt.time {
 				t.Errorf("GetSchedluedTime() = %v, want %v", got, newtt.time)
 			}
 		})
 	}
 }
+
+// promoteClient implements PromoteVolume only, the other calls panic.
+type promoteClient struct {
+	grpcClient.VolumeReplication
+	forced []bool
+	err    error
+}
+
+func (p *promoteClient) PromoteVolume(
+	volumeID, replicationID string,
+	force bool,
+	secretName, secretNamespace string,
+	parameters map[string]string) (*proto.PromoteVolumeResponse, error) {
+	p.forced = append(p.forced, force)
+	if force {
+		return &proto.PromoteVolumeResponse{}, nil
+	}
+
+	return nil, p.err
+}
+
+func TestMarkVolumeAsPrimary(t *testing.T) {
+	t.Parallel()
+	notReady := status.Error(codes.FailedPrecondition, "volume is not ready")
+	tests := []struct {
+		name           string
+		forcePromotion replicationv1alpha1.ForcePromotionPolicy
+		promoteErr     error
+		wantForced     []bool
+		wantErr        bool
+	}{
+		{
+			name:           "auto without error",
+			forcePromotion: replicationv1alpha1.ForcePromotionAuto,
+			promoteErr:     nil,
+			wantForced:     []bool{false},
+		},
+		{
+			name:           "auto with known error",
+			forcePromotion: replicationv1alpha1.ForcePromotionAuto,
+			promoteErr:     notReady,
+			wantForced:     []bool{false, true},
+		},
+		{
+			name:           "default with known error",
+			forcePromotion: "",
+			promoteErr:     notReady,
+			wantForced:     []bool{false, true},
+		},
+		{
+			name:           "auto with unknown error",
+			forcePromotion: replicationv1alpha1.ForcePromotionAuto,
+			promoteErr:     status.Error(codes.Internal, "failed"),
+			wantForced:     []bool{false},
+			wantErr:        true,
+		},
+		{
+			name:           "never with known error",
+			forcePromotion: replicationv1alpha1.ForcePromotionNever,
+			promoteErr:     notReady,
+			wantForced:     []bool{false},
+			wantErr:        true,
+		},
+		{
+			name:           "always",
+			forcePromotion: replicationv1alpha1.ForcePromotionAlways,
+			promoteErr:     nil,
+			wantForced:     []bool{true},
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			client := &promoteClient{err: newtt.promoteErr}
+			recorder := record.NewFakeRecorder(1)
+			r := &VolumeReplicationReconciler{Recorder: recorder}
+			vr := &volumeReplicationInstance{
+				logger: testr.New(t),
+				instance: &replicationv1alpha1.VolumeReplication{
+					Spec: replicationv1alpha1.VolumeReplicationSpec{
+						ForcePromotion: newtt.forcePromotion,
+					},
+				},
+				commonRequestParameters: replication.CommonRequestParameters{
+					Replication: client,
+				},
+			}
+
+			err := r.markVolumeAsPrimary(vr)
+			if newtt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, newtt.wantForced, client.forced)
+
+			forced := !newtt.wantErr && newtt.wantForced[len(newtt.wantForced)-1]
+			assert.Equal(t, forced, vr.instance.Status.LastForcePromotionTime != nil)
+			assert.Equal(t, forced, len(recorder.Events) == 1)
+		})
+	}
+}
+
+// infoClient implements GetVolumeReplicationInfo only, the other calls panic.
+type infoClient struct {
+	grpcClient.VolumeReplication
+	resp *proto.GetVolumeReplicationInfoResponse
+	err  error
+}
+
+func (i *infoClient) GetVolumeReplicationInfo(
+	volumeID, replicationID, secretName, secretNamespace string) (*proto.GetVolumeReplicationInfoResponse, error) {
+	return i.resp, i.err
+}
+
+func TestUpdateReplicationInfo(t *testing.T) {
+	t.Parallel()
+	lastSyncTime := time.Now().Add(-time.Minute).Truncate(time.Second)
+	tests := []struct {
+		name         string
+		resp         *proto.GetVolumeReplicationInfoResponse
+		err          error
+		wantRequeue  bool
+		wantErr      bool
+		wantSyncTime bool
+	}{
+		{
+			name: "info reported",
+			resp: &proto.GetVolumeReplicationInfoResponse{
+				LastSyncTime:     timestamppb.New(lastSyncTime),
+				LastSyncDuration: durationpb.New(time.Second),
+				LastSyncBytes:    1024,
+			},
+			wantRequeue:  true,
+			wantSyncTime: true,
+		},
+		{
+			name:         "info not found",
+			err:          status.Error(codes.NotFound, "not found"),
+			wantRequeue:  true,
+			wantSyncTime: true,
+		},
+		{
+			name:         "unimplemented",
+			err:          status.Error(codes.Unimplemented, "unimplemented"),
+			wantRequeue:  false,
+			wantSyncTime: false,
+		},
+		{
+			name:         "failure",
+			err:          status.Error(codes.Internal, "failed"),
+			wantErr:      true,
+			wantSyncTime: true,
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			previousSyncTime := metav1.NewTime(lastSyncTime.Add(-time.Hour))
+			vr := &volumeReplicationInstance{
+				logger: testr.New(t),
+				instance: &replicationv1alpha1.VolumeReplication{
+					Status: replicationv1alpha1.VolumeReplicationStatus{
+						LastSyncTime: &previousSyncTime,
+					},
+				},
+				commonRequestParameters: replication.CommonRequestParameters{
+					Replication: &infoClient{resp: newtt.resp, err: newtt.err},
+				},
+			}
+
+			r := &VolumeReplicationReconciler{}
+			requeue, err := r.updateReplicationInfo(vr)
+			if newtt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, newtt.wantRequeue, requeue)
+			assert.Equal(t, newtt.wantSyncTime, vr.instance.Status.LastSyncTime != nil)
+			if newtt.resp != nil {
+				assert.True(t, lastSyncTime.Equal(vr.instance.Status.LastSyncTime.Time))
+				assert.Equal(t, time.Second, vr.instance.Status.LastSyncDuration.Duration)
+				assert.Equal(t, int64(1024), *vr.instance.Status.LastSyncBytes)
+			}
+		})
+	}
+}
+
+func TestSetResyncProgress(t *testing.T) {
+	t.Parallel()
+	now := time.Now().Truncate(time.Second)
+	int32Ptr := func(i int32) *int32 { return &i }
+	int64Ptr := func(i int64) *int64 { return &i }
+	tests := []struct {
+		name     string
+		previous *replicationv1alpha1.ResyncProgress
+		progress *proto.ResyncProgress
+		want     *replicationv1alpha1.ResyncProgress
+	}{
+		{
+			name:     "not reported",
+			previous: &replicationv1alpha1.ResyncProgress{PercentComplete: int32Ptr(10)},
+			progress: nil,
+			want:     nil,
+		},
+		{
+			name:     "first report",
+			previous: nil,
+			progress: &proto.ResyncProgress{BytesRemaining: 1000, PercentComplete: 10},
+			want: &replicationv1alpha1.ResyncProgress{
+				PercentComplete: int32Ptr(10),
+				BytesRemaining:  int64Ptr(1000),
+				LastUpdateTime:  metav1.NewTime(now),
+			},
+		},
+		{
+			name: "estimate from bytes",
+			previous: &replicationv1alpha1.ResyncProgress{
+				PercentComplete: int32Ptr(10),
+				BytesRemaining:  int64Ptr(1000),
+				LastUpdateTime:  metav1.NewTime(now.Add(-time.Minute)),
+			},
+			progress: &proto.ResyncProgress{BytesRemaining: 800, PercentComplete: 30},
+			want: &replicationv1alpha1.ResyncProgress{
+				PercentComplete:         int32Ptr(30),
+				BytesRemaining:          int64Ptr(800),
+				LastUpdateTime:          metav1.NewTime(now),
+				EstimatedCompletionTime: &metav1.Time{Time: now.Add(4 * time.Minute)},
+			},
+		},
+		{
+			name: "estimate from percentage",
+			previous: &replicationv1alpha1.ResyncProgress{
+				PercentComplete: int32Ptr(50),
+				LastUpdateTime:  metav1.NewTime(now.Add(-time.Minute)),
+			},
+			progress: &proto.ResyncProgress{BytesRemaining: -1, PercentComplete: 75},
+			want: &replicationv1alpha1.ResyncProgress{
+				PercentComplete:         int32Ptr(75),
+				LastUpdateTime:          metav1.NewTime(now),
+				EstimatedCompletionTime: &metav1.Time{Time: now.Add(time.Minute)},
+			},
+		},
+		{
+			name: "no progress",
+			previous: &replicationv1alpha1.ResyncProgress{
+				BytesRemaining: int64Ptr(1000),
+				LastUpdateTime: metav1.NewTime(now.Add(-time.Minute)),
+			},
+			progress: &proto.ResyncProgress{BytesRemaining: 1000, PercentComplete: -1},
+			want: &replicationv1alpha1.ResyncProgress{
+				BytesRemaining: int64Ptr(1000),
+				LastUpdateTime: metav1.NewTime(now),
+			},
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			status := &replicationv1alpha1.VolumeReplicationStatus{ResyncProgress: newtt.previous}
+			setResyncProgress(status, newtt.progress, now)
+			assert.Equal(t, newtt.want, status.ResyncProgress)
+		})
+	}
+}
+
+func TestGetResyncMessage(t *testing.T) {
+	t.Parallel()
+	percentComplete := int32(42)
+	bytesRemaining := int64(1024)
+	completionTime := metav1.NewTime(time.Date(2022, 9, 5, 10, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, "volume is degraded", getResyncMessage(nil))
+	assert.Equal(t, "volume is degraded, resync is 42% complete, 1024 bytes remaining, estimated to complete at 2022-09-05T10:00:00Z",
+		getResyncMessage(&replicationv1alpha1.ResyncProgress{
+			PercentComplete:         &percentComplete,
+			BytesRemaining:          &bytesRemaining,
+			EstimatedCompletionTime: &completionTime,
+		}))
+}
+
+func TestFindVolumeReplications(t *testing.T) {
+	t.Parallel()
+	newVR := func(name, namespace, kind, pvc, class string) *replicationv1alpha1.VolumeReplication {
+		return &replicationv1alpha1.VolumeReplication{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: replicationv1alpha1.VolumeReplicationSpec{
+				VolumeReplicationClass: class,
+				DataSource: corev1.TypedLocalObjectReference{
+					Kind: kind,
+					Name: pvc,
+				},
+			},
+		}
+	}
+
+	scheme := createFakeScheme(t)
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newVR("vr-1", mockNamespace, pvcDataSource, mockPVCName, "class-1"),
+			newVR("vr-2", mockNamespace, pvcDataSource, "other-pvc", "class-1"),
+			newVR("vr-3", "other-ns", pvcDataSource, mockPVCName, "class-2"),
+			newVR("vr-4", mockNamespace, "Unknown", mockPVCName, "class-2"),
+			newVR("vr-5", mockNamespace, podDataSource, "app", "class-1"),
+			newVR("vr-6", mockNamespace, statefulSetDataSource, "db", "class-1"),
+		).
+		WithIndex(&replicationv1alpha1.VolumeReplication{}, volumeReplicationDataSourceKey, indexVolumeReplicationByDataSource).
+		WithIndex(&replicationv1alpha1.VolumeReplication{}, volumeReplicationClassKey, indexVolumeReplicationByClass).
+		Build()
+	r := &VolumeReplicationReconciler{Client: c, Scheme: scheme}
+
+	requests := r.findVolumeReplicationsForPVC(context.TODO(), mockPersistentVolumeClaim)
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "vr-1", Namespace: mockNamespace}},
+	}, requests)
+
+	vrc := &replicationv1alpha1.VolumeReplicationClass{ObjectMeta: metav1.ObjectMeta{Name: "class-2"}}
+	requests = r.findVolumeReplicationsForVolumeReplicationClass(context.TODO(), vrc)
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "vr-3", Namespace: "other-ns"}},
+		{NamespacedName: types.NamespacedName{Name: "vr-4", Namespace: mockNamespace}},
+	}, requests)
+
+	// Pods and StatefulSets are watched by their metadata only.
+	pod := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: mockNamespace}}
+	requests = r.findVolumeReplicationsForPod(context.TODO(), pod)
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "vr-5", Namespace: mockNamespace}},
+	}, requests)
+
+	sts := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: mockNamespace}}
+	requests = r.findVolumeReplicationsForStatefulSet(context.TODO(), sts)
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "vr-6", Namespace: mockNamespace}},
+	}, requests)
+
+	other := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: mockNamespace}}
+	assert.Empty(t, r.findVolumeReplicationsForPod(context.TODO(), other))
+}
+
+func TestPVCBindingChangedPredicate(t *testing.T) {
+	t.Parallel()
+	pending := &corev1.PersistentVolumeClaim{
+		Status: corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending},
+	}
+	annotated := mockPersistentVolumeClaim.DeepCopy()
+	annotated.Annotations = map[string]string{"owner": "vr"}
+
+	pred := pvcBindingChangedPredicate()
+	assert.True(t, pred.Create(event.CreateEvent{Object: mockPersistentVolumeClaim}))
+	assert.True(t, pred.Delete(event.DeleteEvent{Object: mockPersistentVolumeClaim}))
+	assert.True(t, pred.Update(event.UpdateEvent{ObjectOld: pending, ObjectNew: mockPersistentVolumeClaim}))
+	assert.False(t, pred.Update(event.UpdateEvent{ObjectOld: mockPersistentVolumeClaim, ObjectNew: annotated}))
+}
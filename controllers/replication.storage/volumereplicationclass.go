@@ -18,16 +18,18 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 
 	replicationv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/replication.storage/v1alpha1"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 )
 
 // getVolumeReplicationClass get volume replication class object from the subjected namespace and return the same.
-func (r VolumeReplicationReconciler) getVolumeReplicationClass(logger logr.Logger, vrcName string) (*replicationv1alpha1.VolumeReplicationClass, error) {
+func (r *VolumeReplicationReconciler) getVolumeReplicationClass(logger logr.Logger, vrcName string) (*replicationv1alpha1.VolumeReplicationClass, error) {
 	vrcObj := &replicationv1alpha1.VolumeReplicationClass{}
 	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: vrcName}, vrcObj)
 	if err != nil {
@@ -42,3 +44,42 @@ func (r VolumeReplicationReconciler) getVolumeReplicationClass(logger logr.Logge
 
 	return vrcObj, nil
 }
+
+// migrateVolumeReplicationClass moves the VolumeReplication to the class that
+// replaces its class, and returns the class to use. The replacement must use
+// the same provisioner, as the replication of the volume is not re-created.
+func (r *VolumeReplicationReconciler) migrateVolumeReplicationClass(
+	logger logr.Logger,
+	instance *replicationv1alpha1.VolumeReplication,
+	vrcObj *replicationv1alpha1.VolumeReplicationClass) (*replicationv1alpha1.VolumeReplicationClass, error) {
+	replacement := vrcObj.Annotations[replicationv1alpha1.VolumeReplicationClassReplacedByAnnotation]
+	// the replication of a volume being deleted is disabled with the class
+	// it was enabled with.
+	if replacement == "" || replacement == vrcObj.Name || !instance.GetDeletionTimestamp().IsZero() {
+		return vrcObj, nil
+	}
+
+	newVRC, err := r.getVolumeReplicationClass(logger, replacement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get replacement VolumeReplicationClass %q: %w", replacement, err)
+	}
+
+	if newVRC.Spec.Provisioner != vrcObj.Spec.Provisioner {
+		return nil, fmt.Errorf("replacement VolumeReplicationClass %q uses provisioner %q instead of %q",
+			replacement, newVRC.Spec.Provisioner, vrcObj.Spec.Provisioner)
+	}
+
+	if !newVRC.GetDeletionTimestamp().IsZero() {
+		return nil, fmt.Errorf("replacement VolumeReplicationClass %q is being deleted", replacement)
+	}
+
+	logger.Info("migrating volumeReplication to replacement volumeReplicationClass", "From", vrcObj.Name, "To", replacement)
+	instance.Spec.VolumeReplicationClass = replacement
+	if err = r.Client.Update(context.TODO(), instance); err != nil {
+		return nil, fmt.Errorf("failed to migrate VolumeReplication to VolumeReplicationClass %q: %w", replacement, err)
+	}
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Migrated",
+		"migrated from VolumeReplicationClass %s to %s", vrcObj.Name, replacement)
+
+	return newVRC, nil
+}
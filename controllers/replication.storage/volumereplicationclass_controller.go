@@ -0,0 +1,365 @@
+/*
+Copyright 2022 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	replicationv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/replication.storage/v1alpha1"
+	conn "github.com/csi-addons/kubernetes-csi-addons/internal/connection"
+
+	"github.com/csi-addons/spec/lib/go/identity"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// ConditionValid reports whether the parameters and secrets of the
+	// VolumeReplicationClass are valid.
+	ConditionValid = "Valid"
+	// ConditionDriverConnected reports whether a sidecar of the driver that
+	// supports the replication is connected.
+	ConditionDriverConnected = "DriverConnected"
+
+	Valid              = "Valid"
+	InvalidParameters  = "InvalidParameters"
+	SecretNotFound     = "SecretNotFound"
+	SecretUnreadable   = "SecretUnreadable"
+	DriverConnected    = "DriverConnected"
+	DriverNotConnected = "DriverNotConnected"
+
+	// vrcSecretKey indexes VolumeReplicationClasses by the namespaced names
+	// of the secrets in their parameters.
+	vrcSecretKey = "spec.parameters.secret"
+
+	// driverCheckInterval is the interval at which the connection to the
+	// driver is checked, as the connection pool does not send events.
+	driverCheckInterval = time.Minute
+)
+
+// VolumeReplicationClassReconciler reconciles the status of a
+// VolumeReplicationClass object.
+type VolumeReplicationClassReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// ConnectionPool consists of map of Connection objects
+	Connpool *conn.ConnectionPool
+}
+
+// +kubebuilder:rbac:groups=replication.storage.openshift.io,resources=volumereplicationclasses,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups=replication.storage.openshift.io,resources=volumereplicationclasses/status,verbs=update
+// +kubebuilder:rbac:groups=replication.storage.openshift.io,resources=volumereplicationclasses/finalizers,verbs=update
+// +kubebuilder:rbac:groups=replication.storage.openshift.io,resources=volumereplications,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+
+// Reconcile validates the VolumeReplicationClass, checks the connection to
+// its driver, and counts the VolumeReplications that use it.
+func (r *VolumeReplicationClassReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx, "Request.Name", req.Name)
+
+	instance := &replicationv1alpha1.VolumeReplicationClass{}
+	err := r.Client.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("volumeReplicationClass resource not found")
+
+			return ctrl.Result{}, nil
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	count, err := r.countVolumeReplications(ctx, instance.Name)
+	if err != nil {
+		logger.Error(err, "failed to count volumeReplications")
+
+		return ctrl.Result{}, err
+	}
+
+	// the class is needed to disable the replication of the volumes, it is
+	// only deleted once no VolumeReplication uses it.
+	if instance.GetDeletionTimestamp().IsZero() {
+		if err = r.addFinalizerToVRC(logger, instance); err != nil {
+			logger.Error(err, "Failed to add VolumeReplicationClass finalizer")
+
+			return ctrl.Result{}, err
+		}
+	} else if count == 0 {
+		if err = r.removeFinalizerFromVRC(logger, instance); err != nil {
+			logger.Error(err, "Failed to remove VolumeReplicationClass finalizer")
+
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{}, nil
+	} else {
+		logger.Info("volumeReplicationClass is in use, postponing deletion", "VolumeReplications", count)
+	}
+
+	oldStatus := instance.Status.DeepCopy()
+	meta.SetStatusCondition(&instance.Status.Conditions, r.validate(ctx, instance))
+	meta.SetStatusCondition(&instance.Status.Conditions, r.checkDriver(instance))
+	instance.Status.VolumeReplications = count
+	instance.Status.ObservedGeneration = instance.Generation
+
+	// the status is checked periodically, only update it on changes.
+	if !reflect.DeepEqual(oldStatus, &instance.Status) {
+		err = r.Client.Status().Update(ctx, instance)
+		if err != nil {
+			logger.Error(err, "failed to update volumeReplicationClass status")
+
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: driverCheckInterval}, nil
+}
+
+// validate checks the prefixed parameters and the secrets they refer to, and
+// returns the Valid condition.
+func (r *VolumeReplicationClassReconciler) validate(
+	ctx context.Context,
+	instance *replicationv1alpha1.VolumeReplicationClass) metav1.Condition {
+	cond := metav1.Condition{
+		Type:               ConditionValid,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: instance.Generation,
+	}
+
+	err := validatePrefixedParameters(instance.Spec.Parameters)
+	if err != nil {
+		cond.Reason = InvalidParameters
+		cond.Message = err.Error()
+
+		return cond
+	}
+
+	for _, ref := range getSecretRefs(instance.Spec.Parameters) {
+		secret := &corev1.Secret{}
+		err = r.Client.Get(ctx, ref, secret)
+		switch {
+		case errors.IsNotFound(err):
+			cond.Reason = SecretNotFound
+			cond.Message = fmt.Sprintf("secret %q not found", ref)
+
+			return cond
+		case err != nil:
+			cond.Reason = SecretUnreadable
+			cond.Message = fmt.Sprintf("failed to read secret %q: %v", ref, err)
+
+			return cond
+		}
+	}
+
+	if ref, ok := getPeerKubeconfigSecretRef(instance.Spec.Parameters); ok {
+		secret := &corev1.Secret{}
+		err = r.Client.Get(ctx, ref, secret)
+		if err == nil {
+			if _, found := secret.Data[peerKubeconfigSecretKey]; !found {
+				cond.Reason = SecretUnreadable
+				cond.Message = fmt.Sprintf("peer kubeconfig secret %q does not contain the %q key", ref, peerKubeconfigSecretKey)
+
+				return cond
+			}
+		}
+	}
+
+	cond.Status = metav1.ConditionTrue
+	cond.Reason = Valid
+	cond.Message = "parameters and secrets are valid"
+
+	return cond
+}
+
+// checkDriver returns the DriverConnected condition, that is true when a
+// sidecar of the driver that supports the replication, and the mode of the
+// VolumeReplicationClass, is connected.
+func (r *VolumeReplicationClassReconciler) checkDriver(instance *replicationv1alpha1.VolumeReplicationClass) metav1.Condition {
+	cond := metav1.Condition{
+		Type:               ConditionDriverConnected,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: instance.Generation,
+	}
+
+	conns := r.Connpool.GetByNodeID(instance.Spec.Provisioner, "")
+	capable := map[string]*conn.Connection{}
+	for k, v := range conns {
+		if hasVolumeReplicationCapability(v.Capabilities, identity.Capability_VolumeReplication_VOLUME_REPLICATION) {
+			capable[k] = v
+		}
+	}
+	if len(capable) == 0 {
+		cond.Reason = DriverNotConnected
+		cond.Message = fmt.Sprintf("no sidecar of driver %s with the replication capability is connected", instance.Spec.Provisioner)
+
+		return cond
+	}
+
+	err := checkReplicationMode(capable, instance.Spec.Provisioner, instance.Spec.Mode)
+	if err != nil {
+		cond.Reason = UnsupportedReplicationMode
+		cond.Message = err.Error()
+
+		return cond
+	}
+
+	cond.Status = metav1.ConditionTrue
+	cond.Reason = DriverConnected
+	cond.Message = fmt.Sprintf("%d sidecar(s) of driver %s connected", len(capable), instance.Spec.Provisioner)
+
+	return cond
+}
+
+// countVolumeReplications returns the number of VolumeReplications that use
+// the VolumeReplicationClass. The VolumeReplications are indexed by their
+// class by the VolumeReplication controller.
+func (r *VolumeReplicationClassReconciler) countVolumeReplications(ctx context.Context, vrcName string) (int32, error) {
+	vrs := &replicationv1alpha1.VolumeReplicationList{}
+	err := r.Client.List(ctx, vrs, client.MatchingFields{volumeReplicationClassKey: vrcName})
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(len(vrs.Items)), nil
+}
+
+// getSecretRefs returns the secrets of the parameters that the driver needs.
+func getSecretRefs(parameters map[string]string) []types.NamespacedName {
+	refs := []types.NamespacedName{}
+	name := parameters[prefixedReplicationSecretNameKey]
+	namespace := parameters[prefixedReplicationSecretNamespaceKey]
+	if name != "" && namespace != "" {
+		refs = append(refs, types.NamespacedName{Name: name, Namespace: namespace})
+	}
+
+	if ref, ok := getPeerKubeconfigSecretRef(parameters); ok {
+		refs = append(refs, ref)
+	}
+
+	return refs
+}
+
+// getPeerKubeconfigSecretRef returns the peer kubeconfig secret of the
+// parameters, if any.
+func getPeerKubeconfigSecretRef(parameters map[string]string) (types.NamespacedName, bool) {
+	name := parameters[prefixedPeerKubeconfigSecretNameKey]
+	namespace := parameters[prefixedPeerKubeconfigSecretNamespaceKey]
+
+	return types.NamespacedName{Name: name, Namespace: namespace}, name != "" && namespace != ""
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VolumeReplicationClassReconciler) SetupWithManager(mgr ctrl.Manager, ctrlOptions controller.Options) error {
+	err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&replicationv1alpha1.VolumeReplicationClass{},
+		vrcSecretKey,
+		indexVolumeReplicationClassBySecret)
+	if err != nil {
+		return err
+	}
+
+	// the class of a VolumeReplication only changes when it is migrated,
+	// the old and the new class are both reconciled then.
+	vrPred := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldVR, ok := e.ObjectOld.(*replicationv1alpha1.VolumeReplication)
+			if !ok {
+				return false
+			}
+			newVR, ok := e.ObjectNew.(*replicationv1alpha1.VolumeReplication)
+			if !ok {
+				return false
+			}
+
+			return oldVR.Spec.VolumeReplicationClass != newVR.Spec.VolumeReplicationClass
+		},
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&replicationv1alpha1.VolumeReplicationClass{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(
+			&replicationv1alpha1.VolumeReplication{},
+			handler.EnqueueRequestsFromMapFunc(func(_ context.Context, obj client.Object) []reconcile.Request {
+				vr, ok := obj.(*replicationv1alpha1.VolumeReplication)
+				if !ok || vr.Spec.VolumeReplicationClass == "" {
+					return nil
+				}
+
+				return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: vr.Spec.VolumeReplicationClass}}}
+			}),
+			builder.WithPredicates(vrPred),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findVolumeReplicationClassesForSecret),
+			builder.OnlyMetadata,
+		).
+		WithOptions(ctrlOptions).
+		Complete(r)
+}
+
+// indexVolumeReplicationClassBySecret returns the namespaced names of the
+// secrets of the VolumeReplicationClass.
+func indexVolumeReplicationClassBySecret(rawObj client.Object) []string {
+	vrc, ok := rawObj.(*replicationv1alpha1.VolumeReplicationClass)
+	if !ok {
+		return nil
+	}
+	keys := []string{}
+	for _, ref := range getSecretRefs(vrc.Spec.Parameters) {
+		keys = append(keys, ref.String())
+	}
+
+	return keys
+}
+
+// findVolumeReplicationClassesForSecret returns the VolumeReplicationClasses
+// that refer to the secret.
+func (r *VolumeReplicationClassReconciler) findVolumeReplicationClassesForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	vrcs := &replicationv1alpha1.VolumeReplicationClassList{}
+	err := r.Client.List(ctx, vrcs,
+		client.MatchingFields{vrcSecretKey: types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}.String()})
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to list volumeReplicationClasses", "Secret", obj.GetName())
+
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(vrcs.Items))
+	for _, vrc := range vrcs.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: vrc.Name}})
+	}
+
+	return requests
+}
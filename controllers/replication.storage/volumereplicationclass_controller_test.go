@@ -0,0 +1,225 @@
+/*
+Copyright 2022 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	replicationv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/replication.storage/v1alpha1"
+	conn "github.com/csi-addons/kubernetes-csi-addons/internal/connection"
+
+	"github.com/csi-addons/spec/lib/go/identity"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestVolumeReplicationClassReconcile(t *testing.T) {
+	t.Parallel()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "replication-secret",
+			Namespace: mockNamespace,
+		},
+	}
+	replicationCapable := &conn.Connection{
+		DriverName: "test-driver",
+		Capabilities: []*identity.Capability{
+			{
+				Type: &identity.Capability_VolumeReplication_{
+					VolumeReplication: &identity.Capability_VolumeReplication{
+						Type: identity.Capability_VolumeReplication_VOLUME_REPLICATION,
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		parameters      map[string]string
+		mode            replicationv1alpha1.ReplicationMode
+		conn            *conn.Connection
+		wantValid       string
+		wantDriver      string
+		wantReplication int32
+	}{
+		{
+			name: "valid",
+			parameters: map[string]string{
+				prefixedReplicationSecretNameKey:      secret.Name,
+				prefixedReplicationSecretNamespaceKey: secret.Namespace,
+			},
+			conn:            replicationCapable,
+			wantValid:       Valid,
+			wantDriver:      DriverConnected,
+			wantReplication: 2,
+		},
+		{
+			name: "invalid parameters",
+			parameters: map[string]string{
+				replicationParameterPrefix + "unknown": "value",
+			},
+			conn:            replicationCapable,
+			wantValid:       InvalidParameters,
+			wantDriver:      DriverConnected,
+			wantReplication: 2,
+		},
+		{
+			name: "secret not found",
+			parameters: map[string]string{
+				prefixedReplicationSecretNameKey:      "missing",
+				prefixedReplicationSecretNamespaceKey: secret.Namespace,
+			},
+			wantValid:       SecretNotFound,
+			wantDriver:      DriverNotConnected,
+			wantReplication: 2,
+		},
+		{
+			name:            "unsupported mode",
+			mode:            replicationv1alpha1.SyncReplicationMode,
+			conn:            replicationCapable,
+			wantValid:       Valid,
+			wantDriver:      UnsupportedReplicationMode,
+			wantReplication: 2,
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			vrc := &replicationv1alpha1.VolumeReplicationClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "volume-replication-class"},
+				Spec: replicationv1alpha1.VolumeReplicationClassSpec{
+					Provisioner: "test-driver",
+					Parameters:  newtt.parameters,
+					Mode:        newtt.mode,
+				},
+			}
+			objects := []client.Object{vrc, secret.DeepCopy()}
+			for _, name := range []string{"vr-1", "vr-2"} {
+				vr := mockVolumeReplicationObj.DeepCopy()
+				vr.Name = name
+				vr.Spec.VolumeReplicationClass = vrc.Name
+				objects = append(objects, vr)
+			}
+			other := mockVolumeReplicationObj.DeepCopy()
+			other.Name = "vr-3"
+			other.Spec.VolumeReplicationClass = "other-class"
+			objects = append(objects, other)
+
+			scheme := createFakeScheme(t)
+			connPool := conn.NewConnectionPool()
+			if newtt.conn != nil {
+				connPool.Put("test-driver-conn", newtt.conn)
+			}
+			r := &VolumeReplicationClassReconciler{
+				Client: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(objects...).
+					WithStatusSubresource(vrc).
+					WithIndex(&replicationv1alpha1.VolumeReplication{}, volumeReplicationClassKey, indexVolumeReplicationByClass).
+					Build(),
+				Scheme:   scheme,
+				Connpool: connPool,
+			}
+
+			_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Name: vrc.Name}})
+			assert.NoError(t, err)
+
+			updated := &replicationv1alpha1.VolumeReplicationClass{}
+			assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: vrc.Name}, updated))
+			assert.Equal(t, newtt.wantValid, meta.FindStatusCondition(updated.Status.Conditions, ConditionValid).Reason)
+			assert.Equal(t, newtt.wantDriver, meta.FindStatusCondition(updated.Status.Conditions, ConditionDriverConnected).Reason)
+			assert.Equal(t, newtt.wantReplication, updated.Status.VolumeReplications)
+			assert.Contains(t, updated.Finalizers, vrcReplicationFinalizer)
+		})
+	}
+}
+
+func TestVolumeReplicationClassDeletion(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		inUse         bool
+		wantFinalizer bool
+	}{
+		{
+			name:          "in use",
+			inUse:         true,
+			wantFinalizer: true,
+		},
+		{
+			name:          "not in use",
+			inUse:         false,
+			wantFinalizer: false,
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			now := metav1.Now()
+			vrc := &replicationv1alpha1.VolumeReplicationClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "volume-replication-class",
+					Finalizers:        []string{vrcReplicationFinalizer},
+					DeletionTimestamp: &now,
+				},
+				Spec: replicationv1alpha1.VolumeReplicationClassSpec{
+					Provisioner: "test-driver",
+				},
+			}
+			objects := []client.Object{vrc}
+			if newtt.inUse {
+				objects = append(objects, mockVolumeReplicationObj.DeepCopy())
+			}
+
+			scheme := createFakeScheme(t)
+			r := &VolumeReplicationClassReconciler{
+				Client: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(objects...).
+					WithStatusSubresource(vrc).
+					WithIndex(&replicationv1alpha1.VolumeReplication{}, volumeReplicationClassKey, indexVolumeReplicationByClass).
+					Build(),
+				Scheme:   scheme,
+				Connpool: conn.NewConnectionPool(),
+			}
+
+			_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Name: vrc.Name}})
+			assert.NoError(t, err)
+
+			updated := &replicationv1alpha1.VolumeReplicationClass{}
+			err = r.Client.Get(context.TODO(), types.NamespacedName{Name: vrc.Name}, updated)
+			if newtt.wantFinalizer {
+				assert.NoError(t, err)
+				assert.Contains(t, updated.Finalizers, vrcReplicationFinalizer)
+			} else {
+				// the object is deleted once its last finalizer is removed.
+				assert.True(t, errors.IsNotFound(err))
+			}
+		})
+	}
+}
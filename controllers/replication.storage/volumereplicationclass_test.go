@@ -26,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -76,3 +77,72 @@ func TestGetVolumeReplicaClass(t *testing.T) {
 		}
 	}
 }
+
+func TestMigrateVolumeReplicationClass(t *testing.T) {
+	t.Parallel()
+	newVRC := func(name, provisioner, replacedBy string) *replicationv1alpha1.VolumeReplicationClass {
+		vrc := &replicationv1alpha1.VolumeReplicationClass{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: replicationv1alpha1.VolumeReplicationClassSpec{
+				Provisioner: provisioner,
+			},
+		}
+		if replacedBy != "" {
+			vrc.Annotations = map[string]string{
+				replicationv1alpha1.VolumeReplicationClassReplacedByAnnotation: replacedBy,
+			}
+		}
+
+		return vrc
+	}
+
+	tests := []struct {
+		name      string
+		vrc       *replicationv1alpha1.VolumeReplicationClass
+		wantClass string
+		wantErr   bool
+	}{
+		{
+			name:      "not replaced",
+			vrc:       newVRC("volume-replication-class", "test-driver", ""),
+			wantClass: "volume-replication-class",
+		},
+		{
+			name:      "replaced",
+			vrc:       newVRC("volume-replication-class", "test-driver", "replacement"),
+			wantClass: "replacement",
+		},
+		{
+			name:      "replacement not found",
+			vrc:       newVRC("volume-replication-class", "test-driver", "missing"),
+			wantClass: "volume-replication-class",
+			wantErr:   true,
+		},
+		{
+			name:      "replacement of other provisioner",
+			vrc:       newVRC("volume-replication-class", "test-driver", "other-driver-class"),
+			wantClass: "volume-replication-class",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			instance := mockVolumeReplicationObj.DeepCopy()
+			r := createFakeVolumeReplicationReconciler(t,
+				instance,
+				newtt.vrc,
+				newVRC("replacement", "test-driver", ""),
+				newVRC("other-driver-class", "other-driver", ""))
+			r.Recorder = record.NewFakeRecorder(1)
+
+			vrc, err := r.migrateVolumeReplicationClass(log.FromContext(context.TODO()), instance, newtt.vrc)
+			assert.Equal(t, newtt.wantErr, err != nil)
+			assert.Equal(t, newtt.wantClass, instance.Spec.VolumeReplicationClass)
+			if err == nil {
+				assert.Equal(t, newtt.wantClass, vrc.Name)
+			}
+		})
+	}
+}
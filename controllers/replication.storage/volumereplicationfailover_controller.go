@@ -0,0 +1,716 @@
+/*
+Copyright 2022 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"time"
+
+	replicationv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/replication.storage/v1alpha1"
+	"github.com/csi-addons/kubernetes-csi-addons/controllers/replication.storage/replication"
+	conn "github.com/csi-addons/kubernetes-csi-addons/internal/connection"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/proto"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/util"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// failoverVolumeReplicationKey indexes VolumeReplicationFailovers by
+	// the names of their source and target VolumeReplications.
+	failoverVolumeReplicationKey = "spec.volumeReplication"
+	defaultFailoverPhaseTimeout  = 5 * time.Minute
+	failoverPollInterval         = 10 * time.Second
+)
+
+// errNoPeerCluster is returned when the target is to be resolved in the peer
+// cluster, but the VolumeReplicationClass of the source does not configure
+// one.
+var errNoPeerCluster = goerrors.New("no peer cluster is configured in the VolumeReplicationClass of the source")
+
+// VolumeReplicationFailoverReconciler reconciles a VolumeReplicationFailover object.
+type VolumeReplicationFailoverReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// ConnectionPool consists of map of Connection objects
+	Connpool *conn.ConnectionPool
+	// Timeout for the GetVolumeReplicationInfo operation.
+	Timeout time.Duration
+
+	// peerClients caches the clients for the peer clusters.
+	peerClients peerClientCache
+}
+
+// +kubebuilder:rbac:groups=replication.storage.openshift.io,resources=volumereplicationfailovers,verbs=get;list;watch
+// +kubebuilder:rbac:groups=replication.storage.openshift.io,resources=volumereplicationfailovers/status,verbs=update
+// +kubebuilder:rbac:groups=replication.storage.openshift.io,resources=volumereplications,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups=replication.storage.openshift.io,resources=volumereplicationclasses,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+
+// Reconcile moves a VolumeReplicationFailover through its phases. Each phase
+// updates the desired replicationState of a VolumeReplication and waits for
+// the VolumeReplication controller to report that the operation completed.
+func (r *VolumeReplicationFailoverReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx, "Request.Name", req.Name, "Request.Namespace", req.Namespace)
+
+	instance := &replicationv1alpha1.VolumeReplicationFailover{}
+	err := r.Client.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("volumeReplicationFailover resource not found")
+
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	if isFailoverFinished(instance.Status.Phase) {
+		logger.Info("volumeReplicationFailover is already finished", "Phase", instance.Status.Phase)
+
+		return ctrl.Result{}, nil
+	}
+
+	if instance.Status.StartTime == nil {
+		instance.Status.StartTime = getCurrentTime()
+		setFailoverPhase(instance, replicationv1alpha1.FailoverPending, "failover is pending")
+	}
+
+	switch instance.Status.Phase {
+	case replicationv1alpha1.FailoverPending:
+		err = r.startFailover(ctx, logger, instance)
+	case replicationv1alpha1.FailoverDemoting:
+		err = r.waitForDemotion(ctx, logger, instance)
+	case replicationv1alpha1.FailoverWaitingForSync:
+		err = r.waitForFinalSync(ctx, logger, instance)
+	case replicationv1alpha1.FailoverPromoting:
+		err = r.waitForPromotion(ctx, logger, instance)
+	case replicationv1alpha1.FailoverRollingBack:
+		err = r.waitForRollback(ctx, logger, instance)
+	}
+
+	uErr := r.Client.Status().Update(ctx, instance)
+	if uErr != nil {
+		logger.Error(uErr, "failed to update volumeReplicationFailover status")
+
+		return ctrl.Result{}, uErr
+	}
+
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if isFailoverFinished(instance.Status.Phase) {
+		logger.Info("volumeReplicationFailover finished", "Phase", instance.Status.Phase, "Message", instance.Status.Message)
+
+		return ctrl.Result{}, nil
+	}
+
+	return ctrl.Result{Requeue: true, RequeueAfter: failoverPollInterval}, nil
+}
+
+// startFailover checks the target and requests the first operation of the
+// workflow.
+func (r *VolumeReplicationFailoverReconciler) startFailover(
+	ctx context.Context,
+	logger logr.Logger,
+	instance *replicationv1alpha1.VolumeReplicationFailover) error {
+	target, targetClient, err := r.getTarget(ctx, instance)
+	if err != nil {
+		if isFailoverDependencyGone(err) {
+			setFailoverPhase(instance, replicationv1alpha1.FailoverFailed,
+				fmt.Sprintf("failed to get target VolumeReplication %q: %v", instance.Spec.Target, err))
+
+			return nil
+		}
+
+		return err
+	}
+
+	if target.Spec.ReplicationState == replicationv1alpha1.Primary {
+		setFailoverPhase(instance, replicationv1alpha1.FailoverFailed,
+			fmt.Sprintf("target VolumeReplication %q is already primary", target.Name))
+
+		return nil
+	}
+
+	if instance.Spec.Action == replicationv1alpha1.Failover {
+		return r.promoteTarget(ctx, logger, instance, target, targetClient)
+	}
+
+	source, err := r.getVolumeReplication(ctx, instance.Namespace, instance.Spec.Source)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			setFailoverPhase(instance, replicationv1alpha1.FailoverFailed,
+				fmt.Sprintf("source VolumeReplication %q not found", instance.Spec.Source))
+
+			return nil
+		}
+
+		return err
+	}
+
+	logger.Info("demoting source", "VolumeReplication", source.Name)
+	err = r.setReplicationState(ctx, r.Client, source, replicationv1alpha1.Secondary)
+	if err != nil {
+		return err
+	}
+
+	instance.Status.DemotionTime = getCurrentTime()
+	setFailoverPhase(instance, replicationv1alpha1.FailoverDemoting,
+		fmt.Sprintf("waiting for %q to be demoted", source.Name))
+
+	return nil
+}
+
+// waitForDemotion waits for the source to be demoted.
+func (r *VolumeReplicationFailoverReconciler) waitForDemotion(
+	ctx context.Context,
+	logger logr.Logger,
+	instance *replicationv1alpha1.VolumeReplicationFailover) error {
+	source, err := r.getVolumeReplication(ctx, instance.Namespace, instance.Spec.Source)
+	if err != nil {
+		return r.handleFailoverError(ctx, logger, instance, err)
+	}
+
+	if isVolumeReplicationCompleted(source, replicationv1alpha1.SecondaryState, Demoted) {
+		setFailoverPhase(instance, replicationv1alpha1.FailoverWaitingForSync,
+			fmt.Sprintf("waiting for the final sync of %q", source.Name))
+
+		return nil
+	}
+
+	if isFailoverPhaseTimedOut(instance) {
+		return r.failFailover(ctx, logger, instance,
+			fmt.Sprintf("timed out waiting for %q to be demoted: %s", source.Name, source.Status.Message))
+	}
+
+	return nil
+}
+
+// waitForFinalSync waits for the source to report a sync that happened after
+// it was asked to be demoted. Drivers that do not implement
+// GetVolumeReplicationInfo skip the confirmation.
+func (r *VolumeReplicationFailoverReconciler) waitForFinalSync(
+	ctx context.Context,
+	logger logr.Logger,
+	instance *replicationv1alpha1.VolumeReplicationFailover) error {
+	source, err := r.getVolumeReplication(ctx, instance.Namespace, instance.Spec.Source)
+	if err != nil {
+		return r.handleFailoverError(ctx, logger, instance, err)
+	}
+
+	lastSyncTime, err := r.getLastSyncTime(ctx, logger, source)
+	if err != nil {
+		if util.IsUnimplementedError(err) {
+			logger.Info("driver does not report the sync status, skipping the final sync confirmation")
+
+			return r.promoteTarget(ctx, logger, instance, nil, nil)
+		}
+		logger.Error(err, "failed to get volume replication info", "VolumeReplication", source.Name)
+		if isFailoverPhaseTimedOut(instance) {
+			return r.failFailover(ctx, logger, instance,
+				fmt.Sprintf("timed out waiting for the final sync of %q: %s", source.Name, replication.GetMessageFromError(err)))
+		}
+		instance.Status.Message = replication.GetMessageFromError(err)
+
+		return nil
+	}
+
+	if lastSyncTime != nil {
+		instance.Status.LastSyncTime = lastSyncTime
+		if !lastSyncTime.Before(instance.Status.DemotionTime) {
+			return r.promoteTarget(ctx, logger, instance, nil, nil)
+		}
+	}
+
+	if isFailoverPhaseTimedOut(instance) {
+		return r.failFailover(ctx, logger, instance,
+			fmt.Sprintf("timed out waiting for the final sync of %q", source.Name))
+	}
+
+	return nil
+}
+
+// promoteTarget asks for the target to be promoted. The target and the
+// client of its cluster are fetched when they are not passed.
+func (r *VolumeReplicationFailoverReconciler) promoteTarget(
+	ctx context.Context,
+	logger logr.Logger,
+	instance *replicationv1alpha1.VolumeReplicationFailover,
+	target *replicationv1alpha1.VolumeReplication,
+	targetClient client.Client) error {
+	var err error
+	if target == nil {
+		target, targetClient, err = r.getTarget(ctx, instance)
+		if err != nil {
+			return r.handleFailoverError(ctx, logger, instance, err)
+		}
+	}
+
+	logger.Info("promoting target", "VolumeReplication", target.Name, "TargetCluster", getTargetCluster(instance))
+	err = r.setReplicationState(ctx, targetClient, target, replicationv1alpha1.Primary)
+	if err != nil {
+		return err
+	}
+
+	setFailoverPhase(instance, replicationv1alpha1.FailoverPromoting,
+		fmt.Sprintf("waiting for %q to be promoted", target.Name))
+
+	return nil
+}
+
+// waitForPromotion waits for the target to be promoted.
+func (r *VolumeReplicationFailoverReconciler) waitForPromotion(
+	ctx context.Context,
+	logger logr.Logger,
+	instance *replicationv1alpha1.VolumeReplicationFailover) error {
+	target, _, err := r.getTarget(ctx, instance)
+	if err != nil {
+		return r.handleFailoverError(ctx, logger, instance, err)
+	}
+
+	if isVolumeReplicationCompleted(target, replicationv1alpha1.PrimaryState, Promoted) {
+		instance.Status.CompletionTime = getCurrentTime()
+		setFailoverPhase(instance, replicationv1alpha1.FailoverCompleted,
+			fmt.Sprintf("%q is promoted", target.Name))
+
+		return nil
+	}
+
+	if isFailoverPhaseTimedOut(instance) {
+		return r.failFailover(ctx, logger, instance,
+			fmt.Sprintf("timed out waiting for %q to be promoted: %s", target.Name, target.Status.Message))
+	}
+
+	return nil
+}
+
+// waitForRollback waits for the source to be promoted again.
+func (r *VolumeReplicationFailoverReconciler) waitForRollback(
+	ctx context.Context,
+	logger logr.Logger,
+	instance *replicationv1alpha1.VolumeReplicationFailover) error {
+	source, err := r.getVolumeReplication(ctx, instance.Namespace, instance.Spec.Source)
+	if err != nil {
+		return r.handleFailoverError(ctx, logger, instance, err)
+	}
+
+	if isVolumeReplicationCompleted(source, replicationv1alpha1.PrimaryState, Promoted) {
+		instance.Status.CompletionTime = getCurrentTime()
+		setFailoverPhase(instance, replicationv1alpha1.FailoverRolledBack,
+			fmt.Sprintf("%q is promoted again: %s", source.Name, instance.Status.Message))
+
+		return nil
+	}
+
+	if isFailoverPhaseTimedOut(instance) {
+		instance.Status.CompletionTime = getCurrentTime()
+		setFailoverPhase(instance, replicationv1alpha1.FailoverFailed,
+			fmt.Sprintf("timed out waiting for %q to be promoted again: %s", source.Name, source.Status.Message))
+	}
+
+	return nil
+}
+
+// failFailover rolls back a failed Relocate when rollback is enabled, and
+// marks the workflow as failed otherwise.
+func (r *VolumeReplicationFailoverReconciler) failFailover(
+	ctx context.Context,
+	logger logr.Logger,
+	instance *replicationv1alpha1.VolumeReplicationFailover,
+	message string) error {
+	logger.Info("volumeReplicationFailover phase failed", "Phase", instance.Status.Phase, "Message", message)
+
+	if instance.Spec.Action == replicationv1alpha1.Failover || !instance.Spec.Rollback {
+		instance.Status.CompletionTime = getCurrentTime()
+		setFailoverPhase(instance, replicationv1alpha1.FailoverFailed, message)
+
+		return nil
+	}
+
+	// a target that can not be resolved anymore can not be demoted, the
+	// source is promoted again regardless.
+	target, targetClient, err := r.getTarget(ctx, instance)
+	if err != nil && !isFailoverDependencyGone(err) {
+		return err
+	}
+	if err == nil {
+		logger.Info("demoting target", "VolumeReplication", target.Name, "TargetCluster", getTargetCluster(instance))
+		err = r.setReplicationState(ctx, targetClient, target, replicationv1alpha1.Secondary)
+		if err != nil {
+			return err
+		}
+	}
+
+	source, err := r.getVolumeReplication(ctx, instance.Namespace, instance.Spec.Source)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			instance.Status.CompletionTime = getCurrentTime()
+			setFailoverPhase(instance, replicationv1alpha1.FailoverFailed,
+				fmt.Sprintf("%s: source VolumeReplication %q not found", message, instance.Spec.Source))
+
+			return nil
+		}
+
+		return err
+	}
+
+	logger.Info("promoting source", "VolumeReplication", source.Name)
+	err = r.setReplicationState(ctx, r.Client, source, replicationv1alpha1.Primary)
+	if err != nil {
+		return err
+	}
+
+	setFailoverPhase(instance, replicationv1alpha1.FailoverRollingBack, message)
+
+	return nil
+}
+
+// handleFailoverError fails the workflow when a VolumeReplication it depends
+// on was deleted or can not be resolved anymore, and returns other errors to
+// be retried.
+func (r *VolumeReplicationFailoverReconciler) handleFailoverError(
+	ctx context.Context,
+	logger logr.Logger,
+	instance *replicationv1alpha1.VolumeReplicationFailover,
+	err error) error {
+	if !isFailoverDependencyGone(err) {
+		return err
+	}
+
+	if instance.Status.Phase == replicationv1alpha1.FailoverRollingBack {
+		instance.Status.CompletionTime = getCurrentTime()
+		setFailoverPhase(instance, replicationv1alpha1.FailoverFailed, err.Error())
+
+		return nil
+	}
+
+	return r.failFailover(ctx, logger, instance, err.Error())
+}
+
+// getVolumeReplication returns the VolumeReplication with the given name.
+func (r *VolumeReplicationFailoverReconciler) getVolumeReplication(
+	ctx context.Context,
+	namespace, name string) (*replicationv1alpha1.VolumeReplication, error) {
+	vr := &replicationv1alpha1.VolumeReplication{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, vr)
+	if err != nil {
+		return nil, err
+	}
+
+	return vr, nil
+}
+
+// getTarget returns the target VolumeReplication and the client of the
+// cluster it is in. A target in the peer cluster is resolved with the peer
+// kubeconfig of the VolumeReplicationClass of the source.
+func (r *VolumeReplicationFailoverReconciler) getTarget(
+	ctx context.Context,
+	instance *replicationv1alpha1.VolumeReplicationFailover) (
+	*replicationv1alpha1.VolumeReplication, client.Client, error) {
+	c := r.Client
+	if getTargetCluster(instance) == replicationv1alpha1.PeerCluster {
+		var err error
+		c, err = r.getPeerClient(ctx, instance)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	target := &replicationv1alpha1.VolumeReplication{}
+	err := c.Get(ctx, types.NamespacedName{Name: instance.Spec.Target, Namespace: instance.Namespace}, target)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return target, c, nil
+}
+
+// getPeerClient returns a client for the peer cluster that is configured in
+// the VolumeReplicationClass of the source.
+func (r *VolumeReplicationFailoverReconciler) getPeerClient(
+	ctx context.Context,
+	instance *replicationv1alpha1.VolumeReplicationFailover) (client.Client, error) {
+	source, err := r.getVolumeReplication(ctx, instance.Namespace, instance.Spec.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	vrc := &replicationv1alpha1.VolumeReplicationClass{}
+	err = r.Client.Get(ctx, types.NamespacedName{Name: source.Spec.VolumeReplicationClass}, vrc)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasPeerCluster(vrc) {
+		return nil, errNoPeerCluster
+	}
+
+	return r.peerClients.get(ctx, r.Client, r.Scheme, r.Timeout, types.NamespacedName{
+		Name:      vrc.Spec.Parameters[prefixedPeerKubeconfigSecretNameKey],
+		Namespace: vrc.Spec.Parameters[prefixedPeerKubeconfigSecretNamespaceKey],
+	})
+}
+
+// setReplicationState updates the desired replicationState of the
+// VolumeReplication with the client of its cluster, if it is different.
+func (r *VolumeReplicationFailoverReconciler) setReplicationState(
+	ctx context.Context,
+	c client.Client,
+	vr *replicationv1alpha1.VolumeReplication,
+	state replicationv1alpha1.ReplicationState) error {
+	if vr.Spec.ReplicationState == state {
+		return nil
+	}
+
+	vr.Spec.ReplicationState = state
+
+	return c.Update(ctx, vr)
+}
+
+// getLastSyncTime returns the last sync time of the volume of the
+// VolumeReplication. The last sync time of a Pod or StatefulSet is the one of
+// its least recently synced member, and is nil until all members report one.
+func (r *VolumeReplicationFailoverReconciler) getLastSyncTime(
+	ctx context.Context,
+	logger logr.Logger,
+	vr *replicationv1alpha1.VolumeReplication) (*metav1.Time, error) {
+	if vr.Spec.DataSource.Kind == pvcDataSource {
+		info, err := r.getReplicationInfo(logger, vr)
+		if err != nil {
+			return nil, err
+		}
+		if info.GetLastSyncTime() == nil {
+			return nil, nil
+		}
+		lastSyncTime := metav1.NewTime(info.GetLastSyncTime().AsTime())
+
+		return &lastSyncTime, nil
+	}
+
+	members := &replicationv1alpha1.VolumeReplicationList{}
+	err := r.Client.List(ctx, members,
+		client.InNamespace(vr.Namespace),
+		client.MatchingLabels{memberOfLabel: vr.Name})
+	if err != nil {
+		return nil, err
+	}
+	if len(members.Items) == 0 {
+		return nil, nil
+	}
+
+	var oldest *metav1.Time
+	for i := range members.Items {
+		member := &members.Items[i]
+		if !metav1.IsControlledBy(member, vr) {
+			continue
+		}
+		lastSyncTime, err := r.getLastSyncTime(ctx, logger, member)
+		if err != nil {
+			return nil, fmt.Errorf("member %q: %w", member.Name, err)
+		}
+		if lastSyncTime == nil {
+			return nil, nil
+		}
+		if oldest == nil || lastSyncTime.Before(oldest) {
+			oldest = lastSyncTime
+		}
+	}
+
+	return oldest, nil
+}
+
+// getReplicationInfo returns the replication info of the volume of the
+// VolumeReplication of a PVC.
+func (r *VolumeReplicationFailoverReconciler) getReplicationInfo(
+	logger logr.Logger,
+	vr *replicationv1alpha1.VolumeReplication) (*proto.GetVolumeReplicationInfoResponse, error) {
+	vrr := &VolumeReplicationReconciler{
+		Client:   r.Client,
+		Scheme:   r.Scheme,
+		Connpool: r.Connpool,
+		Timeout:  r.Timeout,
+	}
+
+	vrcObj, err := vrr.getVolumeReplicationClass(logger, vr.Spec.VolumeReplicationClass)
+	if err != nil {
+		return nil, err
+	}
+
+	_, pv, err := vrr.getPVCDataSource(logger, types.NamespacedName{Name: vr.Spec.DataSource.Name, Namespace: vr.Namespace})
+	if err != nil {
+		return nil, err
+	}
+
+	replicationClient, err := vrr.getReplicationClient(vrcObj.Spec.Provisioner)
+	if err != nil {
+		return nil, err
+	}
+
+	return vrr.getVolumeReplicationInfo(&volumeReplicationInstance{
+		logger:   logger,
+		instance: vr,
+		commonRequestParameters: replication.CommonRequestParameters{
+			VolumeID:        pv.Spec.CSI.VolumeHandle,
+			ReplicationID:   vr.Spec.ReplicationHandle,
+			Parameters:      getDriverParameters(vrcObj),
+			SecretName:      vrcObj.Spec.Parameters[prefixedReplicationSecretNameKey],
+			SecretNamespace: vrcObj.Spec.Parameters[prefixedReplicationSecretNamespaceKey],
+			Replication:     replicationClient,
+		},
+	})
+}
+
+// isVolumeReplicationCompleted returns true once the VolumeReplication
+// controller reports the given state for the current generation.
+func isVolumeReplicationCompleted(vr *replicationv1alpha1.VolumeReplication, state replicationv1alpha1.State, reason string) bool {
+	if vr.Status.ObservedGeneration != vr.Generation || vr.Status.State != state {
+		return false
+	}
+
+	completed := meta.FindStatusCondition(vr.Status.Conditions, ConditionCompleted)
+
+	return completed != nil &&
+		completed.Status == metav1.ConditionTrue &&
+		completed.Reason == reason &&
+		completed.ObservedGeneration == vr.Generation
+}
+
+// getTargetCluster returns the cluster in which the target is resolved.
+func getTargetCluster(instance *replicationv1alpha1.VolumeReplicationFailover) replicationv1alpha1.TargetCluster {
+	if instance.Spec.TargetCluster == "" {
+		return replicationv1alpha1.LocalCluster
+	}
+
+	return instance.Spec.TargetCluster
+}
+
+// isFailoverDependencyGone returns true when a VolumeReplication the workflow
+// depends on does not exist, or the peer cluster of the target is not
+// configured anymore. Retrying does not help in both cases.
+func isFailoverDependencyGone(err error) bool {
+	return errors.IsNotFound(err) || goerrors.Is(err, errNoPeerCluster)
+}
+
+// isFailoverFinished returns true when the phase will not change anymore.
+func isFailoverFinished(phase replicationv1alpha1.FailoverPhase) bool {
+	switch phase {
+	case replicationv1alpha1.FailoverCompleted,
+		replicationv1alpha1.FailoverRolledBack,
+		replicationv1alpha1.FailoverFailed:
+		return true
+	}
+
+	return false
+}
+
+// isFailoverPhaseTimedOut returns true when the current phase has been
+// running for longer than the timeout.
+func isFailoverPhaseTimedOut(instance *replicationv1alpha1.VolumeReplicationFailover) bool {
+	timeout := defaultFailoverPhaseTimeout
+	if instance.Spec.Timeout != nil {
+		timeout = time.Duration(*instance.Spec.Timeout) * time.Second
+	}
+
+	if instance.Status.PhaseStartTime == nil {
+		return false
+	}
+
+	return time.Since(instance.Status.PhaseStartTime.Time) > timeout
+}
+
+// setFailoverPhase sets the phase and message, and resets the phase start time
+// when the phase changes.
+func setFailoverPhase(instance *replicationv1alpha1.VolumeReplicationFailover, phase replicationv1alpha1.FailoverPhase, message string) {
+	if instance.Status.Phase != phase {
+		instance.Status.Phase = phase
+		instance.Status.PhaseStartTime = getCurrentTime()
+	}
+	instance.Status.Message = message
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VolumeReplicationFailoverReconciler) SetupWithManager(mgr ctrl.Manager, ctrlOptions controller.Options) error {
+	err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&replicationv1alpha1.VolumeReplicationFailover{},
+		failoverVolumeReplicationKey,
+		func(rawObj client.Object) []string {
+			failover, ok := rawObj.(*replicationv1alpha1.VolumeReplicationFailover)
+			if !ok {
+				return nil
+			}
+			names := []string{failover.Spec.Target}
+			if failover.Spec.Source != "" {
+				names = append(names, failover.Spec.Source)
+			}
+
+			return names
+		})
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&replicationv1alpha1.VolumeReplicationFailover{}).
+		Watches(
+			&replicationv1alpha1.VolumeReplication{},
+			handler.EnqueueRequestsFromMapFunc(r.findFailoversForVolumeReplication),
+		).
+		WithOptions(ctrlOptions).
+		Complete(r)
+}
+
+// findFailoversForVolumeReplication returns the VolumeReplicationFailovers
+// that use the VolumeReplication as source or target.
+func (r *VolumeReplicationFailoverReconciler) findFailoversForVolumeReplication(ctx context.Context, obj client.Object) []reconcile.Request {
+	failovers := &replicationv1alpha1.VolumeReplicationFailoverList{}
+	err := r.Client.List(ctx, failovers,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{failoverVolumeReplicationKey: obj.GetName()})
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to list volumeReplicationFailovers", "VolumeReplication", obj.GetName())
+
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, failover := range failovers.Items {
+		if isFailoverFinished(failover.Status.Phase) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: failover.Name, Namespace: failover.Namespace},
+		})
+	}
+
+	return requests
+}
@@ -0,0 +1,554 @@
+/*
+Copyright 2022 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	replicationv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/replication.storage/v1alpha1"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestIsVolumeReplicationCompleted(t *testing.T) {
+	t.Parallel()
+	newVR := func(generation, observedGeneration int64, state replicationv1alpha1.State, reason string) *replicationv1alpha1.VolumeReplication {
+		vr := &replicationv1alpha1.VolumeReplication{
+			ObjectMeta: metav1.ObjectMeta{Generation: generation},
+			Status: replicationv1alpha1.VolumeReplicationStatus{
+				State:              state,
+				ObservedGeneration: observedGeneration,
+			},
+		}
+		setStatusCondition(&vr.Status.Conditions, &metav1.Condition{
+			Type:               ConditionCompleted,
+			Reason:             reason,
+			ObservedGeneration: observedGeneration,
+			Status:             metav1.ConditionTrue,
+		})
+
+		return vr
+	}
+
+	tests := []struct {
+		name   string
+		vr     *replicationv1alpha1.VolumeReplication
+		state  replicationv1alpha1.State
+		reason string
+		want   bool
+	}{
+		{
+			name:   "demoted",
+			vr:     newVR(2, 2, replicationv1alpha1.SecondaryState, Demoted),
+			state:  replicationv1alpha1.SecondaryState,
+			reason: Demoted,
+			want:   true,
+		},
+		{
+			name:   "old generation",
+			vr:     newVR(3, 2, replicationv1alpha1.SecondaryState, Demoted),
+			state:  replicationv1alpha1.SecondaryState,
+			reason: Demoted,
+			want:   false,
+		},
+		{
+			name:   "different state",
+			vr:     newVR(2, 2, replicationv1alpha1.PrimaryState, Promoted),
+			state:  replicationv1alpha1.SecondaryState,
+			reason: Demoted,
+			want:   false,
+		},
+		{
+			name:   "no conditions",
+			vr:     &replicationv1alpha1.VolumeReplication{},
+			state:  replicationv1alpha1.PrimaryState,
+			reason: Promoted,
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, newtt.want, isVolumeReplicationCompleted(newtt.vr, newtt.state, newtt.reason))
+		})
+	}
+}
+
+func TestIsFailoverPhaseTimedOut(t *testing.T) {
+	t.Parallel()
+	timeout := int64(60)
+	tests := []struct {
+		name           string
+		timeout        *int64
+		phaseStartTime *metav1.Time
+		want           bool
+	}{
+		{
+			name:           "phase not started",
+			phaseStartTime: nil,
+			want:           false,
+		},
+		{
+			name:           "within default timeout",
+			phaseStartTime: &metav1.Time{Time: time.Now().Add(-time.Minute)},
+			want:           false,
+		},
+		{
+			name:           "default timeout exceeded",
+			phaseStartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+			want:           true,
+		},
+		{
+			name:           "timeout exceeded",
+			timeout:        &timeout,
+			phaseStartTime: &metav1.Time{Time: time.Now().Add(-2 * time.Minute)},
+			want:           true,
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			instance := &replicationv1alpha1.VolumeReplicationFailover{
+				Spec: replicationv1alpha1.VolumeReplicationFailoverSpec{
+					Timeout: newtt.timeout,
+				},
+				Status: replicationv1alpha1.VolumeReplicationFailoverStatus{
+					PhaseStartTime: newtt.phaseStartTime,
+				},
+			}
+			assert.Equal(t, newtt.want, isFailoverPhaseTimedOut(instance))
+		})
+	}
+}
+
+// newFailoverVolumeReplication returns a VolumeReplication with the desired
+// replicationState, that reports the state as completed for the reason.
+func newFailoverVolumeReplication(
+	name string,
+	replicationState replicationv1alpha1.ReplicationState,
+	state replicationv1alpha1.State,
+	reason string) *replicationv1alpha1.VolumeReplication {
+	vr := &replicationv1alpha1.VolumeReplication{}
+	mockVolumeReplicationObj.DeepCopyInto(vr)
+	vr.Name = name
+	vr.Spec.ReplicationState = replicationState
+	vr.Status.State = state
+	setStatusCondition(&vr.Status.Conditions, &metav1.Condition{
+		Type:   ConditionCompleted,
+		Reason: reason,
+		Status: metav1.ConditionTrue,
+	})
+
+	return vr
+}
+
+func TestVolumeReplicationFailoverReconcile(t *testing.T) {
+	t.Parallel()
+	const (
+		sourceName = "source"
+		targetName = "target"
+	)
+	timedOut := &metav1.Time{Time: time.Now().Add(-time.Hour)}
+	primary := func(name string) *replicationv1alpha1.VolumeReplication {
+		return newFailoverVolumeReplication(name, replicationv1alpha1.Primary, replicationv1alpha1.PrimaryState, Promoted)
+	}
+	secondary := func(name string) *replicationv1alpha1.VolumeReplication {
+		return newFailoverVolumeReplication(name, replicationv1alpha1.Secondary, replicationv1alpha1.SecondaryState, Demoted)
+	}
+	// promoting has been asked to be promoted, and is not promoted yet.
+	promoting := func(name string) *replicationv1alpha1.VolumeReplication {
+		vr := secondary(name)
+		vr.Spec.ReplicationState = replicationv1alpha1.Primary
+
+		return vr
+	}
+
+	tests := []struct {
+		name           string
+		action         replicationv1alpha1.FailoverAction
+		rollback       bool
+		phase          replicationv1alpha1.FailoverPhase
+		phaseStartTime *metav1.Time
+		source         *replicationv1alpha1.VolumeReplication
+		target         *replicationv1alpha1.VolumeReplication
+		wantPhase      replicationv1alpha1.FailoverPhase
+		wantSource     replicationv1alpha1.ReplicationState
+		wantTarget     replicationv1alpha1.ReplicationState
+	}{
+		{
+			name:       "relocate demotes the source",
+			action:     replicationv1alpha1.Relocate,
+			source:     primary(sourceName),
+			target:     secondary(targetName),
+			wantPhase:  replicationv1alpha1.FailoverDemoting,
+			wantSource: replicationv1alpha1.Secondary,
+			wantTarget: replicationv1alpha1.Secondary,
+		},
+		{
+			name:       "target is already primary",
+			action:     replicationv1alpha1.Relocate,
+			source:     primary(sourceName),
+			target:     primary(targetName),
+			wantPhase:  replicationv1alpha1.FailoverFailed,
+			wantSource: replicationv1alpha1.Primary,
+			wantTarget: replicationv1alpha1.Primary,
+		},
+		{
+			name:       "target not found",
+			action:     replicationv1alpha1.Relocate,
+			source:     primary(sourceName),
+			wantPhase:  replicationv1alpha1.FailoverFailed,
+			wantSource: replicationv1alpha1.Primary,
+		},
+		{
+			name:       "failover promotes the target",
+			action:     replicationv1alpha1.Failover,
+			target:     secondary(targetName),
+			wantPhase:  replicationv1alpha1.FailoverPromoting,
+			wantTarget: replicationv1alpha1.Primary,
+		},
+		{
+			name:       "source is demoted",
+			action:     replicationv1alpha1.Relocate,
+			phase:      replicationv1alpha1.FailoverDemoting,
+			source:     secondary(sourceName),
+			target:     secondary(targetName),
+			wantPhase:  replicationv1alpha1.FailoverWaitingForSync,
+			wantSource: replicationv1alpha1.Secondary,
+			wantTarget: replicationv1alpha1.Secondary,
+		},
+		{
+			name:   "source is being demoted",
+			action: replicationv1alpha1.Relocate,
+			phase:  replicationv1alpha1.FailoverDemoting,
+			source: newFailoverVolumeReplication(sourceName,
+				replicationv1alpha1.Secondary, replicationv1alpha1.PrimaryState, Promoted),
+			target:     secondary(targetName),
+			wantPhase:  replicationv1alpha1.FailoverDemoting,
+			wantSource: replicationv1alpha1.Secondary,
+			wantTarget: replicationv1alpha1.Secondary,
+		},
+		{
+			name:           "final sync timed out without rollback",
+			action:         replicationv1alpha1.Relocate,
+			phase:          replicationv1alpha1.FailoverWaitingForSync,
+			phaseStartTime: timedOut,
+			source:         secondary(sourceName),
+			target:         secondary(targetName),
+			wantPhase:      replicationv1alpha1.FailoverFailed,
+			wantSource:     replicationv1alpha1.Secondary,
+			wantTarget:     replicationv1alpha1.Secondary,
+		},
+		{
+			name:           "final sync timed out with rollback",
+			action:         replicationv1alpha1.Relocate,
+			rollback:       true,
+			phase:          replicationv1alpha1.FailoverWaitingForSync,
+			phaseStartTime: timedOut,
+			source:         secondary(sourceName),
+			target:         secondary(targetName),
+			wantPhase:      replicationv1alpha1.FailoverRollingBack,
+			wantSource:     replicationv1alpha1.Primary,
+			wantTarget:     replicationv1alpha1.Secondary,
+		},
+		{
+			name:       "target is promoted",
+			action:     replicationv1alpha1.Relocate,
+			rollback:   true,
+			phase:      replicationv1alpha1.FailoverPromoting,
+			source:     secondary(sourceName),
+			target:     primary(targetName),
+			wantPhase:  replicationv1alpha1.FailoverCompleted,
+			wantSource: replicationv1alpha1.Secondary,
+			wantTarget: replicationv1alpha1.Primary,
+		},
+		{
+			name:           "promotion timed out with rollback",
+			action:         replicationv1alpha1.Relocate,
+			rollback:       true,
+			phase:          replicationv1alpha1.FailoverPromoting,
+			phaseStartTime: timedOut,
+			source:         secondary(sourceName),
+			target:         promoting(targetName),
+			wantPhase:      replicationv1alpha1.FailoverRollingBack,
+			wantSource:     replicationv1alpha1.Primary,
+			wantTarget:     replicationv1alpha1.Secondary,
+		},
+		{
+			name:           "failover promotion timed out is not rolled back",
+			action:         replicationv1alpha1.Failover,
+			rollback:       true,
+			phase:          replicationv1alpha1.FailoverPromoting,
+			phaseStartTime: timedOut,
+			target:         promoting(targetName),
+			wantPhase:      replicationv1alpha1.FailoverFailed,
+			wantTarget:     replicationv1alpha1.Primary,
+		},
+		{
+			name:       "source is promoted again",
+			action:     replicationv1alpha1.Relocate,
+			rollback:   true,
+			phase:      replicationv1alpha1.FailoverRollingBack,
+			source:     primary(sourceName),
+			target:     secondary(targetName),
+			wantPhase:  replicationv1alpha1.FailoverRolledBack,
+			wantSource: replicationv1alpha1.Primary,
+			wantTarget: replicationv1alpha1.Secondary,
+		},
+		{
+			name:           "rollback timed out",
+			action:         replicationv1alpha1.Relocate,
+			rollback:       true,
+			phase:          replicationv1alpha1.FailoverRollingBack,
+			phaseStartTime: timedOut,
+			source:         promoting(sourceName),
+			target:         secondary(targetName),
+			wantPhase:      replicationv1alpha1.FailoverFailed,
+			wantSource:     replicationv1alpha1.Primary,
+			wantTarget:     replicationv1alpha1.Secondary,
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			instance := &replicationv1alpha1.VolumeReplicationFailover{
+				ObjectMeta: metav1.ObjectMeta{Name: "failover", Namespace: mockNamespace},
+				Spec: replicationv1alpha1.VolumeReplicationFailoverSpec{
+					Action:   newtt.action,
+					Target:   targetName,
+					Rollback: newtt.rollback,
+				},
+			}
+			if newtt.action == replicationv1alpha1.Relocate {
+				instance.Spec.Source = sourceName
+			}
+			if newtt.phase != "" {
+				instance.Status = replicationv1alpha1.VolumeReplicationFailoverStatus{
+					Phase:          newtt.phase,
+					StartTime:      timedOut,
+					PhaseStartTime: &metav1.Time{Time: time.Now()},
+					DemotionTime:   timedOut,
+				}
+				if newtt.phaseStartTime != nil {
+					instance.Status.PhaseStartTime = newtt.phaseStartTime
+				}
+			}
+
+			objects := []client.Object{instance}
+			for _, vr := range []*replicationv1alpha1.VolumeReplication{newtt.source, newtt.target} {
+				if vr != nil {
+					objects = append(objects, vr)
+				}
+			}
+			r := &VolumeReplicationFailoverReconciler{
+				Client: fake.NewClientBuilder().
+					WithScheme(createFakeScheme(t)).
+					WithObjects(objects...).
+					WithStatusSubresource(instance).
+					Build(),
+				Scheme: createFakeScheme(t),
+			}
+
+			_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(instance)})
+			require.NoError(t, err)
+
+			updated := &replicationv1alpha1.VolumeReplicationFailover{}
+			require.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(instance), updated))
+			assert.Equal(t, newtt.wantPhase, updated.Status.Phase, updated.Status.Message)
+
+			for name, want := range map[string]replicationv1alpha1.ReplicationState{
+				sourceName: newtt.wantSource,
+				targetName: newtt.wantTarget,
+			} {
+				if want == "" {
+					continue
+				}
+				vr := &replicationv1alpha1.VolumeReplication{}
+				require.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: mockNamespace}, vr))
+				assert.Equal(t, want, vr.Spec.ReplicationState, name)
+			}
+		})
+	}
+}
+
+func TestVolumeReplicationFailoverPeerTarget(t *testing.T) {
+	t.Parallel()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "peer-kubeconfig",
+			Namespace: mockNamespace,
+		},
+	}
+	vrc := &replicationv1alpha1.VolumeReplicationClass{}
+	mockVolumeReplicationClassObj.DeepCopyInto(vrc)
+	vrc.Spec.Parameters = map[string]string{
+		prefixedPeerKubeconfigSecretNameKey:      secret.Name,
+		prefixedPeerKubeconfigSecretNamespaceKey: secret.Namespace,
+	}
+
+	tests := []struct {
+		name        string
+		peerCluster bool
+		wantPhase   replicationv1alpha1.FailoverPhase
+		wantPeer    replicationv1alpha1.ReplicationState
+	}{
+		{
+			name:        "target is promoted in the peer cluster",
+			peerCluster: true,
+			wantPhase:   replicationv1alpha1.FailoverPromoting,
+			wantPeer:    replicationv1alpha1.Primary,
+		},
+		{
+			name:        "no peer cluster",
+			peerCluster: false,
+			wantPhase:   replicationv1alpha1.FailoverFailed,
+			wantPeer:    replicationv1alpha1.Secondary,
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			// the source and target have the same name in their clusters.
+			source := newFailoverVolumeReplication(mockVolumeReplicationObj.Name,
+				replicationv1alpha1.Secondary, replicationv1alpha1.SecondaryState, Demoted)
+			target := newFailoverVolumeReplication(mockVolumeReplicationObj.Name,
+				replicationv1alpha1.Secondary, replicationv1alpha1.SecondaryState, Demoted)
+			instance := &replicationv1alpha1.VolumeReplicationFailover{
+				ObjectMeta: metav1.ObjectMeta{Name: "failover", Namespace: mockNamespace},
+				Spec: replicationv1alpha1.VolumeReplicationFailoverSpec{
+					Action:        replicationv1alpha1.Relocate,
+					Source:        source.Name,
+					Target:        target.Name,
+					TargetCluster: replicationv1alpha1.PeerCluster,
+				},
+				Status: replicationv1alpha1.VolumeReplicationFailoverStatus{
+					Phase:          replicationv1alpha1.FailoverWaitingForSync,
+					StartTime:      &metav1.Time{Time: time.Now()},
+					PhaseStartTime: &metav1.Time{Time: time.Now()},
+					DemotionTime:   &metav1.Time{Time: time.Now()},
+				},
+			}
+
+			localVRC := vrc.DeepCopy()
+			if !newtt.peerCluster {
+				localVRC.Spec.Parameters = nil
+			}
+			scheme := createFakeScheme(t)
+			r := &VolumeReplicationFailoverReconciler{
+				Client: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(instance, source, localVRC, secret.DeepCopy()).
+					WithStatusSubresource(instance).
+					Build(),
+				Scheme: scheme,
+			}
+			peer := fake.NewClientBuilder().WithScheme(scheme).WithObjects(target).Build()
+
+			// the peer client is cached for the current version of the
+			// Secret, so that no kubeconfig is needed.
+			s := &corev1.Secret{}
+			require.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(secret), s))
+			r.peerClients.clients = map[string]*peerClient{
+				client.ObjectKeyFromObject(secret).String(): {
+					resourceVersion: s.ResourceVersion,
+					client:          peer,
+				},
+			}
+
+			err := r.promoteTarget(context.TODO(), testr.New(t), instance, nil, nil)
+			require.NoError(t, err)
+			assert.Equal(t, newtt.wantPhase, instance.Status.Phase, instance.Status.Message)
+
+			updated := &replicationv1alpha1.VolumeReplication{}
+			require.NoError(t, peer.Get(context.TODO(), client.ObjectKeyFromObject(target), updated))
+			assert.Equal(t, newtt.wantPeer, updated.Spec.ReplicationState)
+
+			// the local source is never promoted by the promotion of the
+			// target.
+			require.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(source), updated))
+			assert.Equal(t, replicationv1alpha1.Secondary, updated.Spec.ReplicationState)
+		})
+	}
+}
+
+func TestVolumeReplicationFailoverGetLastSyncTime(t *testing.T) {
+	t.Parallel()
+	group := &replicationv1alpha1.VolumeReplication{}
+	mockVolumeReplicationObj.DeepCopyInto(group)
+	group.UID = "group-uid"
+	group.Spec.DataSource = corev1.TypedLocalObjectReference{Kind: statefulSetDataSource, Name: "sts"}
+
+	scheme := createFakeScheme(t)
+	member := newFailoverVolumeReplication(getMemberName(group.Name, mockPVCName),
+		replicationv1alpha1.Secondary, replicationv1alpha1.SecondaryState, Demoted)
+	member.Labels = map[string]string{memberOfLabel: group.Name}
+	member.Spec.DataSource = corev1.TypedLocalObjectReference{Kind: pvcDataSource, Name: mockPVCName}
+	require.NoError(t, ctrl.SetControllerReference(group, member, scheme))
+
+	tests := []struct {
+		name    string
+		members []client.Object
+		wantErr bool
+	}{
+		{
+			name:    "no members",
+			members: nil,
+			wantErr: false,
+		},
+		{
+			// the VolumeReplicationClass of the member does not exist.
+			name:    "member fails",
+			members: []client.Object{member},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			r := &VolumeReplicationFailoverReconciler{
+				Client: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(append(newtt.members, group.DeepCopy())...).
+					Build(),
+				Scheme: scheme,
+			}
+
+			lastSyncTime, err := r.getLastSyncTime(context.TODO(), testr.New(t), group)
+			if newtt.wantErr {
+				assert.ErrorContains(t, err, member.Name)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Nil(t, lastSyncTime)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/csi-addons/spec/lib/go/identity"
+)
+
+// NewServiceCapability returns the capability of the specification that
+// advertises the Service of type t.
+func NewServiceCapability(t Capability_Service_Type) *identity.Capability {
+	return &identity.Capability{
+		Type: &identity.Capability_Service_{
+			Service: &identity.Capability_Service{
+				Type: identity.Capability_Service_Type(t),
+			},
+		},
+	}
+}
+
+// NewNetworkFenceCapability returns the capability of the specification
+// that advertises the NetworkFence of type t.
+func NewNetworkFenceCapability(t Capability_NetworkFence_Type) *identity.Capability {
+	return &identity.Capability{
+		Type: &identity.Capability_NetworkFence_{
+			NetworkFence: &identity.Capability_NetworkFence{
+				Type: identity.Capability_NetworkFence_Type(t),
+			},
+		},
+	}
+}
+
+// NewVolumeReplicationCapability returns the capability of the
+// specification that advertises the VolumeReplication of type t.
+func NewVolumeReplicationCapability(t Capability_VolumeReplication_Type) *identity.Capability {
+	return &identity.Capability{
+		Type: &identity.Capability_VolumeReplication_{
+			VolumeReplication: &identity.Capability_VolumeReplication{
+				Type: identity.Capability_VolumeReplication_Type(t),
+			},
+		},
+	}
+}
+
+// HasServiceCapability returns true if the capabilities contain the Service
+// capability of type t.
+func HasServiceCapability(caps []*identity.Capability, t Capability_Service_Type) bool {
+	for _, cap := range caps {
+		if cap.GetService().GetType() == identity.Capability_Service_Type(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasNetworkFenceCapability returns true if the capabilities contain the
+// NetworkFence capability of type t.
+func HasNetworkFenceCapability(caps []*identity.Capability, t Capability_NetworkFence_Type) bool {
+	for _, cap := range caps {
+		if cap.GetNetworkFence().GetType() == identity.Capability_NetworkFence_Type(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasVolumeReplicationCapability returns true if the capabilities contain
+// the VolumeReplication capability of type t.
+func HasVolumeReplicationCapability(caps []*identity.Capability, t Capability_VolumeReplication_Type) bool {
+	for _, cap := range caps {
+		if cap.GetVolumeReplication().GetType() == identity.Capability_VolumeReplication_Type(t) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,547 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.19.6
+// source: capabilities.proto
+
+package v1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Capability_Service_Type int32
+
+const (
+	// UNKNOWN is never advertised.
+	Capability_Service_UNKNOWN Capability_Service_Type = 0
+	// VOLUME_HEALTH is advertised by the CSI-Addons side-car, and not
+	// by drivers, when the CSI Node service of the driver has the
+	// GET_VOLUME_STATS and VOLUME_CONDITION capabilities.
+	Capability_Service_VOLUME_HEALTH Capability_Service_Type = 1001
+	// ENCRYPTION_KEY_ROTATION is advertised by node plugins that
+	// implement the EncryptionKeyRotationNode service.
+	Capability_Service_ENCRYPTION_KEY_ROTATION Capability_Service_Type = 1002
+	// FILESYSTEM_MAINTENANCE is advertised by node plugins that
+	// implement the FilesystemMaintenanceNode service.
+	Capability_Service_FILESYSTEM_MAINTENANCE Capability_Service_Type = 1003
+)
+
+// Enum value maps for Capability_Service_Type.
+var (
+	Capability_Service_Type_name = map[int32]string{
+		0:    "UNKNOWN",
+		1001: "VOLUME_HEALTH",
+		1002: "ENCRYPTION_KEY_ROTATION",
+		1003: "FILESYSTEM_MAINTENANCE",
+	}
+	Capability_Service_Type_value = map[string]int32{
+		"UNKNOWN":                 0,
+		"VOLUME_HEALTH":           1001,
+		"ENCRYPTION_KEY_ROTATION": 1002,
+		"FILESYSTEM_MAINTENANCE":  1003,
+	}
+)
+
+func (x Capability_Service_Type) Enum() *Capability_Service_Type {
+	p := new(Capability_Service_Type)
+	*p = x
+	return p
+}
+
+func (x Capability_Service_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Capability_Service_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_capabilities_proto_enumTypes[0].Descriptor()
+}
+
+func (Capability_Service_Type) Type() protoreflect.EnumType {
+	return &file_capabilities_proto_enumTypes[0]
+}
+
+func (x Capability_Service_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Capability_Service_Type.Descriptor instead.
+func (Capability_Service_Type) EnumDescriptor() ([]byte, []int) {
+	return file_capabilities_proto_rawDescGZIP(), []int{0, 0, 0}
+}
+
+type Capability_NetworkFence_Type int32
+
+const (
+	// UNKNOWN is never advertised.
+	Capability_NetworkFence_UNKNOWN Capability_NetworkFence_Type = 0
+	// FENCE_TARGETS is advertised by drivers that fence clients by
+	// their identity, which is passed in the FenceTargetsParameter
+	// of the FenceClusterNetwork and UnfenceClusterNetwork requests.
+	Capability_NetworkFence_FENCE_TARGETS Capability_NetworkFence_Type = 1001
+)
+
+// Enum value maps for Capability_NetworkFence_Type.
+var (
+	Capability_NetworkFence_Type_name = map[int32]string{
+		0:    "UNKNOWN",
+		1001: "FENCE_TARGETS",
+	}
+	Capability_NetworkFence_Type_value = map[string]int32{
+		"UNKNOWN":       0,
+		"FENCE_TARGETS": 1001,
+	}
+)
+
+func (x Capability_NetworkFence_Type) Enum() *Capability_NetworkFence_Type {
+	p := new(Capability_NetworkFence_Type)
+	*p = x
+	return p
+}
+
+func (x Capability_NetworkFence_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Capability_NetworkFence_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_capabilities_proto_enumTypes[1].Descriptor()
+}
+
+func (Capability_NetworkFence_Type) Type() protoreflect.EnumType {
+	return &file_capabilities_proto_enumTypes[1]
+}
+
+func (x Capability_NetworkFence_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Capability_NetworkFence_Type.Descriptor instead.
+func (Capability_NetworkFence_Type) EnumDescriptor() ([]byte, []int) {
+	return file_capabilities_proto_rawDescGZIP(), []int{0, 1, 0}
+}
+
+type Capability_VolumeReplication_Type int32
+
+const (
+	// UNKNOWN is never advertised.
+	Capability_VolumeReplication_UNKNOWN Capability_VolumeReplication_Type = 0
+	// SYNC_MODE is advertised by drivers that replicate volumes
+	// synchronously.
+	Capability_VolumeReplication_SYNC_MODE Capability_VolumeReplication_Type = 1001
+	// ASYNC_MODE is advertised by drivers that replicate volumes
+	// asynchronously.
+	Capability_VolumeReplication_ASYNC_MODE Capability_VolumeReplication_Type = 1002
+	// SNAPSHOT_MODE is advertised by drivers that replicate
+	// volumes with scheduled snapshots.
+	Capability_VolumeReplication_SNAPSHOT_MODE Capability_VolumeReplication_Type = 1003
+	// RESYNC_PROGRESS is advertised by drivers that implement the
+	// ResyncProgressController service.
+	Capability_VolumeReplication_RESYNC_PROGRESS Capability_VolumeReplication_Type = 1004
+)
+
+// Enum value maps for Capability_VolumeReplication_Type.
+var (
+	Capability_VolumeReplication_Type_name = map[int32]string{
+		0:    "UNKNOWN",
+		1001: "SYNC_MODE",
+		1002: "ASYNC_MODE",
+		1003: "SNAPSHOT_MODE",
+		1004: "RESYNC_PROGRESS",
+	}
+	Capability_VolumeReplication_Type_value = map[string]int32{
+		"UNKNOWN":         0,
+		"SYNC_MODE":       1001,
+		"ASYNC_MODE":      1002,
+		"SNAPSHOT_MODE":   1003,
+		"RESYNC_PROGRESS": 1004,
+	}
+)
+
+func (x Capability_VolumeReplication_Type) Enum() *Capability_VolumeReplication_Type {
+	p := new(Capability_VolumeReplication_Type)
+	*p = x
+	return p
+}
+
+func (x Capability_VolumeReplication_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Capability_VolumeReplication_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_capabilities_proto_enumTypes[2].Descriptor()
+}
+
+func (Capability_VolumeReplication_Type) Type() protoreflect.EnumType {
+	return &file_capabilities_proto_enumTypes[2]
+}
+
+func (x Capability_VolumeReplication_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Capability_VolumeReplication_Type.Descriptor instead.
+func (Capability_VolumeReplication_Type) EnumDescriptor() ([]byte, []int) {
+	return file_capabilities_proto_rawDescGZIP(), []int{0, 2, 0}
+}
+
+// Capability is the registry of the capabilities of the operations that are
+// not part of the CSI-Addons specification yet. The types mirror the
+// Capability message of the identity service of the specification, drivers
+// advertise an operation by returning a capability of the specification,
+// with the value below as its type, from the GetCapabilities procedure of
+// their CSI-Addons identity service.
+//
+// The values start at 1001 to stay clear of the values that the
+// specification defines. A value is never re-used, and is deprecated once
+// the specification defines a capability for the operation.
+type Capability struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Capability) Reset() {
+	*x = Capability{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_capabilities_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Capability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Capability) ProtoMessage() {}
+
+func (x *Capability) ProtoReflect() protoreflect.Message {
+	mi := &file_capabilities_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Capability.ProtoReflect.Descriptor instead.
+func (*Capability) Descriptor() ([]byte, []int) {
+	return file_capabilities_proto_rawDescGZIP(), []int{0}
+}
+
+// Service contains the types for the Service capability.
+type Capability_Service struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type Capability_Service_Type `protobuf:"varint,1,opt,name=type,proto3,enum=csiaddons.extensions.v1alpha1.Capability_Service_Type" json:"type,omitempty"`
+}
+
+func (x *Capability_Service) Reset() {
+	*x = Capability_Service{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_capabilities_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Capability_Service) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Capability_Service) ProtoMessage() {}
+
+func (x *Capability_Service) ProtoReflect() protoreflect.Message {
+	mi := &file_capabilities_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Capability_Service.ProtoReflect.Descriptor instead.
+func (*Capability_Service) Descriptor() ([]byte, []int) {
+	return file_capabilities_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *Capability_Service) GetType() Capability_Service_Type {
+	if x != nil {
+		return x.Type
+	}
+	return Capability_Service_UNKNOWN
+}
+
+// NetworkFence contains the types for the NetworkFence capability.
+// Drivers advertise them in addition to NETWORK_FENCE.
+type Capability_NetworkFence struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type Capability_NetworkFence_Type `protobuf:"varint,1,opt,name=type,proto3,enum=csiaddons.extensions.v1alpha1.Capability_NetworkFence_Type" json:"type,omitempty"`
+}
+
+func (x *Capability_NetworkFence) Reset() {
+	*x = Capability_NetworkFence{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_capabilities_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Capability_NetworkFence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Capability_NetworkFence) ProtoMessage() {}
+
+func (x *Capability_NetworkFence) ProtoReflect() protoreflect.Message {
+	mi := &file_capabilities_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Capability_NetworkFence.ProtoReflect.Descriptor instead.
+func (*Capability_NetworkFence) Descriptor() ([]byte, []int) {
+	return file_capabilities_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *Capability_NetworkFence) GetType() Capability_NetworkFence_Type {
+	if x != nil {
+		return x.Type
+	}
+	return Capability_NetworkFence_UNKNOWN
+}
+
+// VolumeReplication contains the types for the VolumeReplication
+// capability. Drivers advertise the replication modes that they
+// support in addition to VOLUME_REPLICATION.
+type Capability_VolumeReplication struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type Capability_VolumeReplication_Type `protobuf:"varint,1,opt,name=type,proto3,enum=csiaddons.extensions.v1alpha1.Capability_VolumeReplication_Type" json:"type,omitempty"`
+}
+
+func (x *Capability_VolumeReplication) Reset() {
+	*x = Capability_VolumeReplication{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_capabilities_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Capability_VolumeReplication) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Capability_VolumeReplication) ProtoMessage() {}
+
+func (x *Capability_VolumeReplication) ProtoReflect() protoreflect.Message {
+	mi := &file_capabilities_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Capability_VolumeReplication.ProtoReflect.Descriptor instead.
+func (*Capability_VolumeReplication) Descriptor() ([]byte, []int) {
+	return file_capabilities_proto_rawDescGZIP(), []int{0, 2}
+}
+
+func (x *Capability_VolumeReplication) GetType() Capability_VolumeReplication_Type {
+	if x != nil {
+		return x.Type
+	}
+	return Capability_VolumeReplication_UNKNOWN
+}
+
+var File_capabilities_proto protoreflect.FileDescriptor
+
+var file_capabilities_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1d, 0x63, 0x73, 0x69, 0x61, 0x64, 0x64, 0x6f, 0x6e, 0x73, 0x2e,
+	0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x22, 0x9f, 0x04, 0x0a, 0x0a, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x79, 0x1a, 0xb9, 0x01, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4a,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x36, 0x2e, 0x63,
+	0x73, 0x69, 0x61, 0x64, 0x64, 0x6f, 0x6e, 0x73, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x61, 0x70,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x62, 0x0a, 0x04, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12,
+	0x12, 0x0a, 0x0d, 0x56, 0x4f, 0x4c, 0x55, 0x4d, 0x45, 0x5f, 0x48, 0x45, 0x41, 0x4c, 0x54, 0x48,
+	0x10, 0xe9, 0x07, 0x12, 0x1c, 0x0a, 0x17, 0x45, 0x4e, 0x43, 0x52, 0x59, 0x50, 0x54, 0x49, 0x4f,
+	0x4e, 0x5f, 0x4b, 0x45, 0x59, 0x5f, 0x52, 0x4f, 0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0xea,
+	0x07, 0x12, 0x1b, 0x0a, 0x16, 0x46, 0x49, 0x4c, 0x45, 0x53, 0x59, 0x53, 0x54, 0x45, 0x4d, 0x5f,
+	0x4d, 0x41, 0x49, 0x4e, 0x54, 0x45, 0x4e, 0x41, 0x4e, 0x43, 0x45, 0x10, 0xeb, 0x07, 0x1a, 0x88,
+	0x01, 0x0a, 0x0c, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x46, 0x65, 0x6e, 0x63, 0x65, 0x12,
+	0x4f, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x3b, 0x2e,
+	0x63, 0x73, 0x69, 0x61, 0x64, 0x64, 0x6f, 0x6e, 0x73, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x61,
+	0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x46, 0x65, 0x6e, 0x63, 0x65, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x22, 0x27, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e,
+	0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0d, 0x46, 0x45, 0x4e, 0x43, 0x45, 0x5f, 0x54,
+	0x41, 0x52, 0x47, 0x45, 0x54, 0x53, 0x10, 0xe9, 0x07, 0x1a, 0xc9, 0x01, 0x0a, 0x11, 0x56, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x54, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x40, 0x2e,
+	0x63, 0x73, 0x69, 0x61, 0x64, 0x64, 0x6f, 0x6e, 0x73, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x61,
+	0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52,
+	0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x5e, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a,
+	0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x09, 0x53, 0x59,
+	0x4e, 0x43, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x10, 0xe9, 0x07, 0x12, 0x0f, 0x0a, 0x0a, 0x41, 0x53,
+	0x59, 0x4e, 0x43, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x10, 0xea, 0x07, 0x12, 0x12, 0x0a, 0x0d, 0x53,
+	0x4e, 0x41, 0x50, 0x53, 0x48, 0x4f, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x10, 0xeb, 0x07, 0x12,
+	0x14, 0x0a, 0x0f, 0x52, 0x45, 0x53, 0x59, 0x4e, 0x43, 0x5f, 0x50, 0x52, 0x4f, 0x47, 0x52, 0x45,
+	0x53, 0x53, 0x10, 0xec, 0x07, 0x42, 0x41, 0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x73, 0x69, 0x2d, 0x61, 0x64, 0x64, 0x6f, 0x6e, 0x73, 0x2f, 0x6b,
+	0x75, 0x62, 0x65, 0x72, 0x6e, 0x65, 0x74, 0x65, 0x73, 0x2d, 0x63, 0x73, 0x69, 0x2d, 0x61, 0x64,
+	0x64, 0x6f, 0x6e, 0x73, 0x2f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2f,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_capabilities_proto_rawDescOnce sync.Once
+	file_capabilities_proto_rawDescData = file_capabilities_proto_rawDesc
+)
+
+func file_capabilities_proto_rawDescGZIP() []byte {
+	file_capabilities_proto_rawDescOnce.Do(func() {
+		file_capabilities_proto_rawDescData = protoimpl.X.CompressGZIP(file_capabilities_proto_rawDescData)
+	})
+	return file_capabilities_proto_rawDescData
+}
+
+var file_capabilities_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_capabilities_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_capabilities_proto_goTypes = []interface{}{
+	(Capability_Service_Type)(0),           // 0: csiaddons.extensions.v1alpha1.Capability.Service.Type
+	(Capability_NetworkFence_Type)(0),      // 1: csiaddons.extensions.v1alpha1.Capability.NetworkFence.Type
+	(Capability_VolumeReplication_Type)(0), // 2: csiaddons.extensions.v1alpha1.Capability.VolumeReplication.Type
+	(*Capability)(nil),                     // 3: csiaddons.extensions.v1alpha1.Capability
+	(*Capability_Service)(nil),             // 4: csiaddons.extensions.v1alpha1.Capability.Service
+	(*Capability_NetworkFence)(nil),        // 5: csiaddons.extensions.v1alpha1.Capability.NetworkFence
+	(*Capability_VolumeReplication)(nil),   // 6: csiaddons.extensions.v1alpha1.Capability.VolumeReplication
+}
+var file_capabilities_proto_depIdxs = []int32{
+	0, // 0: csiaddons.extensions.v1alpha1.Capability.Service.type:type_name -> csiaddons.extensions.v1alpha1.Capability.Service.Type
+	1, // 1: csiaddons.extensions.v1alpha1.Capability.NetworkFence.type:type_name -> csiaddons.extensions.v1alpha1.Capability.NetworkFence.Type
+	2, // 2: csiaddons.extensions.v1alpha1.Capability.VolumeReplication.type:type_name -> csiaddons.extensions.v1alpha1.Capability.VolumeReplication.Type
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_capabilities_proto_init() }
+func file_capabilities_proto_init() {
+	if File_capabilities_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_capabilities_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Capability); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_capabilities_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Capability_Service); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_capabilities_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Capability_NetworkFence); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_capabilities_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Capability_VolumeReplication); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_capabilities_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_capabilities_proto_goTypes,
+		DependencyIndexes: file_capabilities_proto_depIdxs,
+		EnumInfos:         file_capabilities_proto_enumTypes,
+		MessageInfos:      file_capabilities_proto_msgTypes,
+	}.Build()
+	File_capabilities_proto = out.File
+	file_capabilities_proto_rawDesc = nil
+	file_capabilities_proto_goTypes = nil
+	file_capabilities_proto_depIdxs = nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/csi-addons/spec/lib/go/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasServiceCapability(t *testing.T) {
+	t.Parallel()
+	caps := []*identity.Capability{
+		{
+			Type: &identity.Capability_Service_{
+				Service: &identity.Capability_Service{Type: identity.Capability_Service_NODE_SERVICE},
+			},
+		},
+		NewServiceCapability(Capability_Service_ENCRYPTION_KEY_ROTATION),
+	}
+
+	assert.True(t, HasServiceCapability(caps, Capability_Service_ENCRYPTION_KEY_ROTATION))
+	assert.False(t, HasServiceCapability(caps, Capability_Service_FILESYSTEM_MAINTENANCE))
+	assert.False(t, HasServiceCapability(nil, Capability_Service_ENCRYPTION_KEY_ROTATION))
+}
+
+func TestHasNetworkFenceCapability(t *testing.T) {
+	t.Parallel()
+	caps := []*identity.Capability{
+		{
+			Type: &identity.Capability_NetworkFence_{
+				NetworkFence: &identity.Capability_NetworkFence{Type: identity.Capability_NetworkFence_NETWORK_FENCE},
+			},
+		},
+	}
+
+	assert.False(t, HasNetworkFenceCapability(caps, Capability_NetworkFence_FENCE_TARGETS))
+
+	caps = append(caps, NewNetworkFenceCapability(Capability_NetworkFence_FENCE_TARGETS))
+	assert.True(t, HasNetworkFenceCapability(caps, Capability_NetworkFence_FENCE_TARGETS))
+}
+
+func TestHasVolumeReplicationCapability(t *testing.T) {
+	t.Parallel()
+	caps := []*identity.Capability{
+		NewServiceCapability(Capability_Service_ENCRYPTION_KEY_ROTATION),
+		NewVolumeReplicationCapability(Capability_VolumeReplication_ASYNC_MODE),
+	}
+
+	assert.True(t, HasVolumeReplicationCapability(caps, Capability_VolumeReplication_ASYNC_MODE))
+	assert.False(t, HasVolumeReplicationCapability(caps, Capability_VolumeReplication_SYNC_MODE))
+
+	// a capability of another kind with the same value does not match.
+	assert.False(t, HasVolumeReplicationCapability(caps[:1], Capability_VolumeReplication_ASYNC_MODE))
+}
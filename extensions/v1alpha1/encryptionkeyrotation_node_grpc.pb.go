@@ -0,0 +1,114 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.19.6
+// source: encryptionkeyrotation_node.proto
+
+package v1alpha1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	EncryptionKeyRotationNode_NodeEncryptionKeyRotate_FullMethodName = "/csiaddons.extensions.v1alpha1.EncryptionKeyRotationNode/NodeEncryptionKeyRotate"
+)
+
+// EncryptionKeyRotationNodeClient is the client API for EncryptionKeyRotationNode service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EncryptionKeyRotationNodeClient interface {
+	// NodeEncryptionKeyRotate rotates the encryption key of the volume on
+	// the node where it is staged or published.
+	NodeEncryptionKeyRotate(ctx context.Context, in *NodeEncryptionKeyRotateRequest, opts ...grpc.CallOption) (*NodeEncryptionKeyRotateResponse, error)
+}
+
+type encryptionKeyRotationNodeClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEncryptionKeyRotationNodeClient(cc grpc.ClientConnInterface) EncryptionKeyRotationNodeClient {
+	return &encryptionKeyRotationNodeClient{cc}
+}
+
+func (c *encryptionKeyRotationNodeClient) NodeEncryptionKeyRotate(ctx context.Context, in *NodeEncryptionKeyRotateRequest, opts ...grpc.CallOption) (*NodeEncryptionKeyRotateResponse, error) {
+	out := new(NodeEncryptionKeyRotateResponse)
+	err := c.cc.Invoke(ctx, EncryptionKeyRotationNode_NodeEncryptionKeyRotate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EncryptionKeyRotationNodeServer is the server API for EncryptionKeyRotationNode service.
+// All implementations must embed UnimplementedEncryptionKeyRotationNodeServer
+// for forward compatibility
+type EncryptionKeyRotationNodeServer interface {
+	// NodeEncryptionKeyRotate rotates the encryption key of the volume on
+	// the node where it is staged or published.
+	NodeEncryptionKeyRotate(context.Context, *NodeEncryptionKeyRotateRequest) (*NodeEncryptionKeyRotateResponse, error)
+	mustEmbedUnimplementedEncryptionKeyRotationNodeServer()
+}
+
+// UnimplementedEncryptionKeyRotationNodeServer must be embedded to have forward compatible implementations.
+type UnimplementedEncryptionKeyRotationNodeServer struct {
+}
+
+func (UnimplementedEncryptionKeyRotationNodeServer) NodeEncryptionKeyRotate(context.Context, *NodeEncryptionKeyRotateRequest) (*NodeEncryptionKeyRotateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NodeEncryptionKeyRotate not implemented")
+}
+func (UnimplementedEncryptionKeyRotationNodeServer) mustEmbedUnimplementedEncryptionKeyRotationNodeServer() {
+}
+
+// UnsafeEncryptionKeyRotationNodeServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EncryptionKeyRotationNodeServer will
+// result in compilation errors.
+type UnsafeEncryptionKeyRotationNodeServer interface {
+	mustEmbedUnimplementedEncryptionKeyRotationNodeServer()
+}
+
+func RegisterEncryptionKeyRotationNodeServer(s grpc.ServiceRegistrar, srv EncryptionKeyRotationNodeServer) {
+	s.RegisterService(&EncryptionKeyRotationNode_ServiceDesc, srv)
+}
+
+func _EncryptionKeyRotationNode_NodeEncryptionKeyRotate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeEncryptionKeyRotateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EncryptionKeyRotationNodeServer).NodeEncryptionKeyRotate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EncryptionKeyRotationNode_NodeEncryptionKeyRotate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EncryptionKeyRotationNodeServer).NodeEncryptionKeyRotate(ctx, req.(*NodeEncryptionKeyRotateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EncryptionKeyRotationNode_ServiceDesc is the grpc.ServiceDesc for EncryptionKeyRotationNode service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EncryptionKeyRotationNode_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "csiaddons.extensions.v1alpha1.EncryptionKeyRotationNode",
+	HandlerType: (*EncryptionKeyRotationNodeServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "NodeEncryptionKeyRotate",
+			Handler:    _EncryptionKeyRotationNode_NodeEncryptionKeyRotate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "encryptionkeyrotation_node.proto",
+}
@@ -0,0 +1,409 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.19.6
+// source: filesystemmaintenance_node.proto
+
+package v1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Operation is the maintenance operation on the filesystem. Drivers
+// return UNIMPLEMENTED for operations that they do not support.
+type NodeFilesystemMaintenanceRequest_Operation int32
+
+const (
+	// UNKNOWN indicates that the operation is not set.
+	NodeFilesystemMaintenanceRequest_UNKNOWN NodeFilesystemMaintenanceRequest_Operation = 0
+	// DEFRAGMENT reorganizes the filesystem to reduce its
+	// fragmentation, like e4defrag or xfs_fsr do.
+	NodeFilesystemMaintenanceRequest_DEFRAGMENT NodeFilesystemMaintenanceRequest_Operation = 1
+	// CHECK checks the consistency of the filesystem, like fsck in
+	// read-only mode does. The filesystem is in use by Pods while it
+	// is checked, drivers MUST NOT modify the filesystem or repair
+	// errors for a CHECK, and return FAILED_PRECONDITION when they
+	// can not check the filesystem without modifying it.
+	NodeFilesystemMaintenanceRequest_CHECK NodeFilesystemMaintenanceRequest_Operation = 2
+)
+
+// Enum value maps for NodeFilesystemMaintenanceRequest_Operation.
+var (
+	NodeFilesystemMaintenanceRequest_Operation_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "DEFRAGMENT",
+		2: "CHECK",
+	}
+	NodeFilesystemMaintenanceRequest_Operation_value = map[string]int32{
+		"UNKNOWN":    0,
+		"DEFRAGMENT": 1,
+		"CHECK":      2,
+	}
+)
+
+func (x NodeFilesystemMaintenanceRequest_Operation) Enum() *NodeFilesystemMaintenanceRequest_Operation {
+	p := new(NodeFilesystemMaintenanceRequest_Operation)
+	*p = x
+	return p
+}
+
+func (x NodeFilesystemMaintenanceRequest_Operation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NodeFilesystemMaintenanceRequest_Operation) Descriptor() protoreflect.EnumDescriptor {
+	return file_filesystemmaintenance_node_proto_enumTypes[0].Descriptor()
+}
+
+func (NodeFilesystemMaintenanceRequest_Operation) Type() protoreflect.EnumType {
+	return &file_filesystemmaintenance_node_proto_enumTypes[0]
+}
+
+func (x NodeFilesystemMaintenanceRequest_Operation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NodeFilesystemMaintenanceRequest_Operation.Descriptor instead.
+func (NodeFilesystemMaintenanceRequest_Operation) EnumDescriptor() ([]byte, []int) {
+	return file_filesystemmaintenance_node_proto_rawDescGZIP(), []int{0, 0}
+}
+
+// NodeFilesystemMaintenanceRequest contains the details of the volume and
+// the operation that is run on its filesystem.
+type NodeFilesystemMaintenanceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ID of the volume. This field is REQUIRED.
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	// The path where the volume is published for a Pod on the node. Either
+	// volume_path or staging_target_path is REQUIRED.
+	VolumePath string `protobuf:"bytes,2,opt,name=volume_path,json=volumePath,proto3" json:"volume_path,omitempty"`
+	// The path where the volume is staged on the node. Either volume_path
+	// or staging_target_path is REQUIRED.
+	StagingTargetPath string `protobuf:"bytes,3,opt,name=staging_target_path,json=stagingTargetPath,proto3" json:"staging_target_path,omitempty"`
+	// The filesystem type of the PersistentVolume, empty when it is not
+	// set. This field is OPTIONAL.
+	FsType string `protobuf:"bytes,4,opt,name=fs_type,json=fsType,proto3" json:"fs_type,omitempty"`
+	// The operation to run. This field is REQUIRED.
+	Operation NodeFilesystemMaintenanceRequest_Operation `protobuf:"varint,5,opt,name=operation,proto3,enum=csiaddons.extensions.v1alpha1.NodeFilesystemMaintenanceRequest_Operation" json:"operation,omitempty"`
+	// The volume attributes of the PersistentVolume. This field is
+	// OPTIONAL.
+	Parameters map[string]string `protobuf:"bytes,6,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The secrets of the node stage secret reference of the
+	// PersistentVolume. This field is OPTIONAL.
+	Secrets map[string]string `protobuf:"bytes,7,rep,name=secrets,proto3" json:"secrets,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *NodeFilesystemMaintenanceRequest) Reset() {
+	*x = NodeFilesystemMaintenanceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filesystemmaintenance_node_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodeFilesystemMaintenanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeFilesystemMaintenanceRequest) ProtoMessage() {}
+
+func (x *NodeFilesystemMaintenanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_filesystemmaintenance_node_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeFilesystemMaintenanceRequest.ProtoReflect.Descriptor instead.
+func (*NodeFilesystemMaintenanceRequest) Descriptor() ([]byte, []int) {
+	return file_filesystemmaintenance_node_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *NodeFilesystemMaintenanceRequest) GetVolumeId() string {
+	if x != nil {
+		return x.VolumeId
+	}
+	return ""
+}
+
+func (x *NodeFilesystemMaintenanceRequest) GetVolumePath() string {
+	if x != nil {
+		return x.VolumePath
+	}
+	return ""
+}
+
+func (x *NodeFilesystemMaintenanceRequest) GetStagingTargetPath() string {
+	if x != nil {
+		return x.StagingTargetPath
+	}
+	return ""
+}
+
+func (x *NodeFilesystemMaintenanceRequest) GetFsType() string {
+	if x != nil {
+		return x.FsType
+	}
+	return ""
+}
+
+func (x *NodeFilesystemMaintenanceRequest) GetOperation() NodeFilesystemMaintenanceRequest_Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return NodeFilesystemMaintenanceRequest_UNKNOWN
+}
+
+func (x *NodeFilesystemMaintenanceRequest) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *NodeFilesystemMaintenanceRequest) GetSecrets() map[string]string {
+	if x != nil {
+		return x.Secrets
+	}
+	return nil
+}
+
+// NodeFilesystemMaintenanceResponse is returned when the operation
+// completed.
+type NodeFilesystemMaintenanceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// errors_found is true when a CHECK operation found errors in the
+	// filesystem.
+	ErrorsFound bool `protobuf:"varint,1,opt,name=errors_found,json=errorsFound,proto3" json:"errors_found,omitempty"`
+	// message contains the output of the operation for the user.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *NodeFilesystemMaintenanceResponse) Reset() {
+	*x = NodeFilesystemMaintenanceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filesystemmaintenance_node_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodeFilesystemMaintenanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeFilesystemMaintenanceResponse) ProtoMessage() {}
+
+func (x *NodeFilesystemMaintenanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_filesystemmaintenance_node_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeFilesystemMaintenanceResponse.ProtoReflect.Descriptor instead.
+func (*NodeFilesystemMaintenanceResponse) Descriptor() ([]byte, []int) {
+	return file_filesystemmaintenance_node_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *NodeFilesystemMaintenanceResponse) GetErrorsFound() bool {
+	if x != nil {
+		return x.ErrorsFound
+	}
+	return false
+}
+
+func (x *NodeFilesystemMaintenanceResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_filesystemmaintenance_node_proto protoreflect.FileDescriptor
+
+var file_filesystemmaintenance_node_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x6d, 0x61, 0x69, 0x6e,
+	0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x1d, 0x63, 0x73, 0x69, 0x61, 0x64, 0x64, 0x6f, 0x6e, 0x73, 0x2e, 0x65, 0x78,
+	0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x22, 0x9b, 0x05, 0x0a, 0x20, 0x4e, 0x6f, 0x64, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x76, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x70, 0x61,
+	0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65,
+	0x50, 0x61, 0x74, 0x68, 0x12, 0x2e, 0x0a, 0x13, 0x73, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x5f,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x11, 0x73, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x50, 0x61, 0x74, 0x68, 0x12, 0x17, 0x0a, 0x07, 0x66, 0x73, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x73, 0x54, 0x79, 0x70, 0x65, 0x12, 0x67, 0x0a,
+	0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x49, 0x2e, 0x63, 0x73, 0x69, 0x61, 0x64, 0x64, 0x6f, 0x6e, 0x73, 0x2e, 0x65, 0x78, 0x74,
+	0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4d,
+	0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x6f, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65,
+	0x74, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x4f, 0x2e, 0x63, 0x73, 0x69,
+	0x61, 0x64, 0x64, 0x6f, 0x6e, 0x73, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x46,
+	0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e,
+	0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x70, 0x61, 0x72,
+	0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x66, 0x0a, 0x07, 0x73, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x4c, 0x2e, 0x63, 0x73, 0x69, 0x61, 0x64,
+	0x64, 0x6f, 0x6e, 0x73, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x46, 0x69, 0x6c,
+	0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e,
+	0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x1a,
+	0x3d, 0x0a, 0x0f, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3a,
+	0x0a, 0x0c, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x33, 0x0a, 0x09, 0x4f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
+	0x57, 0x4e, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x45, 0x46, 0x52, 0x41, 0x47, 0x4d, 0x45,
+	0x4e, 0x54, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x43, 0x48, 0x45, 0x43, 0x4b, 0x10, 0x02, 0x22,
+	0x60, 0x0a, 0x21, 0x4e, 0x6f, 0x64, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x5f, 0x66,
+	0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x73, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x32, 0xbe, 0x01, 0x0a, 0x19, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x12,
+	0xa0, 0x01, 0x0a, 0x19, 0x4e, 0x6f, 0x64, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x3f, 0x2e,
+	0x63, 0x73, 0x69, 0x61, 0x64, 0x64, 0x6f, 0x6e, 0x73, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4e, 0x6f,
+	0x64, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4d, 0x61, 0x69, 0x6e,
+	0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x40,
+	0x2e, 0x63, 0x73, 0x69, 0x61, 0x64, 0x64, 0x6f, 0x6e, 0x73, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4e,
+	0x6f, 0x64, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4d, 0x61, 0x69,
+	0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x42, 0x41, 0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x63, 0x73, 0x69, 0x2d, 0x61, 0x64, 0x64, 0x6f, 0x6e, 0x73, 0x2f, 0x6b, 0x75, 0x62, 0x65,
+	0x72, 0x6e, 0x65, 0x74, 0x65, 0x73, 0x2d, 0x63, 0x73, 0x69, 0x2d, 0x61, 0x64, 0x64, 0x6f, 0x6e,
+	0x73, 0x2f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_filesystemmaintenance_node_proto_rawDescOnce sync.Once
+	file_filesystemmaintenance_node_proto_rawDescData = file_filesystemmaintenance_node_proto_rawDesc
+)
+
+func file_filesystemmaintenance_node_proto_rawDescGZIP() []byte {
+	file_filesystemmaintenance_node_proto_rawDescOnce.Do(func() {
+		file_filesystemmaintenance_node_proto_rawDescData = protoimpl.X.CompressGZIP(file_filesystemmaintenance_node_proto_rawDescData)
+	})
+	return file_filesystemmaintenance_node_proto_rawDescData
+}
+
+var file_filesystemmaintenance_node_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_filesystemmaintenance_node_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_filesystemmaintenance_node_proto_goTypes = []interface{}{
+	(NodeFilesystemMaintenanceRequest_Operation)(0), // 0: csiaddons.extensions.v1alpha1.NodeFilesystemMaintenanceRequest.Operation
+	(*NodeFilesystemMaintenanceRequest)(nil),        // 1: csiaddons.extensions.v1alpha1.NodeFilesystemMaintenanceRequest
+	(*NodeFilesystemMaintenanceResponse)(nil),       // 2: csiaddons.extensions.v1alpha1.NodeFilesystemMaintenanceResponse
+	nil, // 3: csiaddons.extensions.v1alpha1.NodeFilesystemMaintenanceRequest.ParametersEntry
+	nil, // 4: csiaddons.extensions.v1alpha1.NodeFilesystemMaintenanceRequest.SecretsEntry
+}
+var file_filesystemmaintenance_node_proto_depIdxs = []int32{
+	0, // 0: csiaddons.extensions.v1alpha1.NodeFilesystemMaintenanceRequest.operation:type_name -> csiaddons.extensions.v1alpha1.NodeFilesystemMaintenanceRequest.Operation
+	3, // 1: csiaddons.extensions.v1alpha1.NodeFilesystemMaintenanceRequest.parameters:type_name -> csiaddons.extensions.v1alpha1.NodeFilesystemMaintenanceRequest.ParametersEntry
+	4, // 2: csiaddons.extensions.v1alpha1.NodeFilesystemMaintenanceRequest.secrets:type_name -> csiaddons.extensions.v1alpha1.NodeFilesystemMaintenanceRequest.SecretsEntry
+	1, // 3: csiaddons.extensions.v1alpha1.FilesystemMaintenanceNode.NodeFilesystemMaintenance:input_type -> csiaddons.extensions.v1alpha1.NodeFilesystemMaintenanceRequest
+	2, // 4: csiaddons.extensions.v1alpha1.FilesystemMaintenanceNode.NodeFilesystemMaintenance:output_type -> csiaddons.extensions.v1alpha1.NodeFilesystemMaintenanceResponse
+	4, // [4:5] is the sub-list for method output_type
+	3, // [3:4] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_filesystemmaintenance_node_proto_init() }
+func file_filesystemmaintenance_node_proto_init() {
+	if File_filesystemmaintenance_node_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_filesystemmaintenance_node_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeFilesystemMaintenanceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filesystemmaintenance_node_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeFilesystemMaintenanceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_filesystemmaintenance_node_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_filesystemmaintenance_node_proto_goTypes,
+		DependencyIndexes: file_filesystemmaintenance_node_proto_depIdxs,
+		EnumInfos:         file_filesystemmaintenance_node_proto_enumTypes,
+		MessageInfos:      file_filesystemmaintenance_node_proto_msgTypes,
+	}.Build()
+	File_filesystemmaintenance_node_proto = out.File
+	file_filesystemmaintenance_node_proto_rawDesc = nil
+	file_filesystemmaintenance_node_proto_goTypes = nil
+	file_filesystemmaintenance_node_proto_depIdxs = nil
+}
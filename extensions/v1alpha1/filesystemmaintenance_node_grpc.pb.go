@@ -0,0 +1,116 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.19.6
+// source: filesystemmaintenance_node.proto
+
+package v1alpha1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	FilesystemMaintenanceNode_NodeFilesystemMaintenance_FullMethodName = "/csiaddons.extensions.v1alpha1.FilesystemMaintenanceNode/NodeFilesystemMaintenance"
+)
+
+// FilesystemMaintenanceNodeClient is the client API for FilesystemMaintenanceNode service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FilesystemMaintenanceNodeClient interface {
+	// NodeFilesystemMaintenance runs the maintenance operation on the
+	// filesystem of the volume, on the node where it is staged or
+	// published.
+	NodeFilesystemMaintenance(ctx context.Context, in *NodeFilesystemMaintenanceRequest, opts ...grpc.CallOption) (*NodeFilesystemMaintenanceResponse, error)
+}
+
+type filesystemMaintenanceNodeClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFilesystemMaintenanceNodeClient(cc grpc.ClientConnInterface) FilesystemMaintenanceNodeClient {
+	return &filesystemMaintenanceNodeClient{cc}
+}
+
+func (c *filesystemMaintenanceNodeClient) NodeFilesystemMaintenance(ctx context.Context, in *NodeFilesystemMaintenanceRequest, opts ...grpc.CallOption) (*NodeFilesystemMaintenanceResponse, error) {
+	out := new(NodeFilesystemMaintenanceResponse)
+	err := c.cc.Invoke(ctx, FilesystemMaintenanceNode_NodeFilesystemMaintenance_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FilesystemMaintenanceNodeServer is the server API for FilesystemMaintenanceNode service.
+// All implementations must embed UnimplementedFilesystemMaintenanceNodeServer
+// for forward compatibility
+type FilesystemMaintenanceNodeServer interface {
+	// NodeFilesystemMaintenance runs the maintenance operation on the
+	// filesystem of the volume, on the node where it is staged or
+	// published.
+	NodeFilesystemMaintenance(context.Context, *NodeFilesystemMaintenanceRequest) (*NodeFilesystemMaintenanceResponse, error)
+	mustEmbedUnimplementedFilesystemMaintenanceNodeServer()
+}
+
+// UnimplementedFilesystemMaintenanceNodeServer must be embedded to have forward compatible implementations.
+type UnimplementedFilesystemMaintenanceNodeServer struct {
+}
+
+func (UnimplementedFilesystemMaintenanceNodeServer) NodeFilesystemMaintenance(context.Context, *NodeFilesystemMaintenanceRequest) (*NodeFilesystemMaintenanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NodeFilesystemMaintenance not implemented")
+}
+func (UnimplementedFilesystemMaintenanceNodeServer) mustEmbedUnimplementedFilesystemMaintenanceNodeServer() {
+}
+
+// UnsafeFilesystemMaintenanceNodeServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FilesystemMaintenanceNodeServer will
+// result in compilation errors.
+type UnsafeFilesystemMaintenanceNodeServer interface {
+	mustEmbedUnimplementedFilesystemMaintenanceNodeServer()
+}
+
+func RegisterFilesystemMaintenanceNodeServer(s grpc.ServiceRegistrar, srv FilesystemMaintenanceNodeServer) {
+	s.RegisterService(&FilesystemMaintenanceNode_ServiceDesc, srv)
+}
+
+func _FilesystemMaintenanceNode_NodeFilesystemMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeFilesystemMaintenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilesystemMaintenanceNodeServer).NodeFilesystemMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FilesystemMaintenanceNode_NodeFilesystemMaintenance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilesystemMaintenanceNodeServer).NodeFilesystemMaintenance(ctx, req.(*NodeFilesystemMaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FilesystemMaintenanceNode_ServiceDesc is the grpc.ServiceDesc for FilesystemMaintenanceNode service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FilesystemMaintenanceNode_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "csiaddons.extensions.v1alpha1.FilesystemMaintenanceNode",
+	HandlerType: (*FilesystemMaintenanceNodeServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "NodeFilesystemMaintenance",
+			Handler:    _FilesystemMaintenanceNode_NodeFilesystemMaintenance_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "filesystemmaintenance_node.proto",
+}
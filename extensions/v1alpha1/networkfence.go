@@ -0,0 +1,33 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// FenceTargetsParameter is the parameter of the FenceClusterNetwork and
+// UnfenceClusterNetwork requests that contains the clients to fence, as the
+// fence service of the specification only supports CIDR blocks. The value is
+// a JSON list of FenceTargets. It is only passed to drivers that advertise
+// the FENCE_TARGETS capability.
+const FenceTargetsParameter = "csiaddons.openshift.io/fence-targets"
+
+// FenceTarget is a client in the FenceTargetsParameter.
+type FenceTarget struct {
+	// Type is the kind of identity of the client, one of CLIENT_ID,
+	// NODE_ID, ISCSI_INITIATOR, NVME_HOST_NQN or CLIENT_ADDRESS.
+	Type string `json:"type"`
+	// Value is the identity of the client.
+	Value string `json:"value"`
+}
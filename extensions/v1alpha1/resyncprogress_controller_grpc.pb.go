@@ -0,0 +1,116 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.19.6
+// source: resyncprogress_controller.proto
+
+package v1alpha1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ResyncProgressController_ControllerGetResyncProgress_FullMethodName = "/csiaddons.extensions.v1alpha1.ResyncProgressController/ControllerGetResyncProgress"
+)
+
+// ResyncProgressControllerClient is the client API for ResyncProgressController service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ResyncProgressControllerClient interface {
+	// ControllerGetResyncProgress returns the progress of the resync of the
+	// volume. It is called after a ResyncVolume request that returned that
+	// the volume is not ready yet.
+	ControllerGetResyncProgress(ctx context.Context, in *ControllerGetResyncProgressRequest, opts ...grpc.CallOption) (*ControllerGetResyncProgressResponse, error)
+}
+
+type resyncProgressControllerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewResyncProgressControllerClient(cc grpc.ClientConnInterface) ResyncProgressControllerClient {
+	return &resyncProgressControllerClient{cc}
+}
+
+func (c *resyncProgressControllerClient) ControllerGetResyncProgress(ctx context.Context, in *ControllerGetResyncProgressRequest, opts ...grpc.CallOption) (*ControllerGetResyncProgressResponse, error) {
+	out := new(ControllerGetResyncProgressResponse)
+	err := c.cc.Invoke(ctx, ResyncProgressController_ControllerGetResyncProgress_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ResyncProgressControllerServer is the server API for ResyncProgressController service.
+// All implementations must embed UnimplementedResyncProgressControllerServer
+// for forward compatibility
+type ResyncProgressControllerServer interface {
+	// ControllerGetResyncProgress returns the progress of the resync of the
+	// volume. It is called after a ResyncVolume request that returned that
+	// the volume is not ready yet.
+	ControllerGetResyncProgress(context.Context, *ControllerGetResyncProgressRequest) (*ControllerGetResyncProgressResponse, error)
+	mustEmbedUnimplementedResyncProgressControllerServer()
+}
+
+// UnimplementedResyncProgressControllerServer must be embedded to have forward compatible implementations.
+type UnimplementedResyncProgressControllerServer struct {
+}
+
+func (UnimplementedResyncProgressControllerServer) ControllerGetResyncProgress(context.Context, *ControllerGetResyncProgressRequest) (*ControllerGetResyncProgressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ControllerGetResyncProgress not implemented")
+}
+func (UnimplementedResyncProgressControllerServer) mustEmbedUnimplementedResyncProgressControllerServer() {
+}
+
+// UnsafeResyncProgressControllerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ResyncProgressControllerServer will
+// result in compilation errors.
+type UnsafeResyncProgressControllerServer interface {
+	mustEmbedUnimplementedResyncProgressControllerServer()
+}
+
+func RegisterResyncProgressControllerServer(s grpc.ServiceRegistrar, srv ResyncProgressControllerServer) {
+	s.RegisterService(&ResyncProgressController_ServiceDesc, srv)
+}
+
+func _ResyncProgressController_ControllerGetResyncProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ControllerGetResyncProgressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResyncProgressControllerServer).ControllerGetResyncProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResyncProgressController_ControllerGetResyncProgress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResyncProgressControllerServer).ControllerGetResyncProgress(ctx, req.(*ControllerGetResyncProgressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ResyncProgressController_ServiceDesc is the grpc.ServiceDesc for ResyncProgressController service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ResyncProgressController_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "csiaddons.extensions.v1alpha1.ResyncProgressController",
+	HandlerType: (*ResyncProgressControllerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ControllerGetResyncProgress",
+			Handler:    _ResyncProgressController_ControllerGetResyncProgress_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "resyncprogress_controller.proto",
+}
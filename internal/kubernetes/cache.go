@@ -0,0 +1,262 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// cacheSyncTimeout is the time to wait for an informer to sync, before the
+// object is fetched from the API server instead.
+const cacheSyncTimeout = 10 * time.Second
+
+// errNotSynced is returned by the informers that did not sync.
+var errNotSynced = errors.New("cache did not sync")
+
+// Cache serves Secrets and StorageClasses from shared informers, so that they
+// are not fetched from the API server on every request. PersistentVolumes are
+// fetched from the API server, as watching all of them from every node is
+// more expensive than the requests for the few volumes that are used.
+//
+// The informers are started on first use. Each Secret is watched on its own,
+// with a field selector on its name, so that only the Secrets of the drivers
+// are cached, and not the other Secrets in their namespaces. Objects that are
+// not in the cache yet are fetched from the API server, so that a newly
+// created object is found before the informer has received it. An informer
+// that is not allowed to list and watch the resource, or does not sync in
+// time, is stopped and the objects are fetched from the API server from then
+// on.
+type Cache struct {
+	client kubernetes.Interface
+	stopCh <-chan struct{}
+
+	// newListWatch returns the ListerWatcher for the resource in the
+	// namespace, an empty namespace is used for cluster scoped resources.
+	// Only the object with the name is watched, unless name is empty.
+	newListWatch func(resource, namespace, name string) cache.ListerWatcher
+	// syncTimeout is the time to wait for an informer to sync.
+	syncTimeout time.Duration
+
+	mu sync.Mutex
+	// secretInformers are the informers of the Secrets, by their
+	// namespace/name key.
+	secretInformers map[string]*informer
+	scInformer      *informer
+}
+
+// informer is a shared informer of the Cache.
+type informer struct {
+	cache.SharedIndexInformer
+	resource string
+	stop     context.CancelFunc
+	// unsynced is set once the informer did not sync, it is not waited
+	// for anymore.
+	unsynced atomic.Bool
+	// failed is closed once the informer is not allowed to list or watch
+	// the resource, so that the requests do not wait for it to sync.
+	failed chan struct{}
+}
+
+// NewCache returns a Cache that uses the client to watch the objects. The
+// informers are stopped when stopCh is closed.
+func NewCache(client kubernetes.Interface, stopCh <-chan struct{}) *Cache {
+	return &Cache{
+		client: client,
+		stopCh: stopCh,
+		newListWatch: func(resource, namespace, name string) cache.ListerWatcher {
+			var c cache.Getter = client.CoreV1().RESTClient()
+			if resource == "storageclasses" {
+				c = client.StorageV1().RESTClient()
+			}
+			selector := fields.Everything()
+			if name != "" {
+				selector = fields.OneTermEqualSelector("metadata.name", name)
+			}
+
+			return cache.NewListWatchFromClient(c, resource, namespace, selector)
+		},
+		syncTimeout:     cacheSyncTimeout,
+		secretInformers: map[string]*informer{},
+	}
+}
+
+// GetSecret returns the data of the secret.
+func (c *Cache) GetSecret(ctx context.Context, name, namespace string) (map[string]string, error) {
+	key := namespace + "/" + name
+	c.mu.Lock()
+	inf, ok := c.secretInformers[key]
+	if !ok {
+		inf = c.startInformer("secrets", namespace, name, &corev1.Secret{})
+		c.secretInformers[key] = inf
+	}
+	c.mu.Unlock()
+
+	obj, err := inf.get(ctx, key, c.syncTimeout)
+	if err != nil {
+		klog.V(4).Infof("secret %s/%s not cached: %v", namespace, name, err)
+
+		return GetSecret(ctx, c.client, name, namespace)
+	}
+
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object of type %T in the secret cache", obj)
+	}
+
+	return getSecretData(secret), nil
+}
+
+// GetPersistentVolume returns the PersistentVolume from the API server.
+func (c *Cache) GetPersistentVolume(ctx context.Context, name string) (*corev1.PersistentVolume, error) {
+	return c.client.CoreV1().PersistentVolumes().Get(ctx, name, metav1.GetOptions{})
+}
+
+// GetStorageClass returns the StorageClass. The returned object is shared
+// with the cache, and must not be modified.
+func (c *Cache) GetStorageClass(ctx context.Context, name string) (*storagev1.StorageClass, error) {
+	c.mu.Lock()
+	if c.scInformer == nil {
+		c.scInformer = c.startInformer("storageclasses", metav1.NamespaceAll, "", &storagev1.StorageClass{})
+	}
+	inf := c.scInformer
+	c.mu.Unlock()
+
+	obj, err := inf.get(ctx, name, c.syncTimeout)
+	if err != nil {
+		klog.V(4).Infof("storageclass %s not cached: %v", name, err)
+
+		return c.client.StorageV1().StorageClasses().Get(ctx, name, metav1.GetOptions{})
+	}
+
+	sc, ok := obj.(*storagev1.StorageClass)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object of type %T in the storageclass cache", obj)
+	}
+
+	return sc, nil
+}
+
+// startInformer starts an informer for the resource in the namespace, for
+// only the object with the name when it is not empty. It must be called with
+// the mutex held.
+func (c *Cache) startInformer(resource, namespace, name string, objType runtime.Object) *informer {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-c.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	inf := &informer{
+		SharedIndexInformer: cache.NewSharedIndexInformer(
+			c.newListWatch(resource, namespace, name),
+			objType,
+			0,
+			cache.Indexers{}),
+		resource: resource,
+		stop:     cancel,
+		failed:   make(chan struct{}),
+	}
+	// the informer retries forever when it is not allowed to list the
+	// resource, it is stopped instead.
+	err := inf.SetWatchErrorHandler(func(r *cache.Reflector, err error) {
+		cache.DefaultWatchErrorHandler(r, err)
+		if apierrors.IsForbidden(err) || apierrors.IsUnauthorized(err) {
+			inf.disable(fmt.Sprintf("was denied: %v", err))
+		}
+	})
+	if err != nil {
+		// the informer is not started yet, this can not happen.
+		klog.Errorf("Failed to set the watch error handler of the %s informer: %v", resource, err)
+	}
+	go inf.Run(ctx.Done())
+
+	return inf
+}
+
+// get waits for the informer to sync, and returns the object with the key.
+// An error is returned when the informer did not sync within syncTimeout, or
+// the object is not found. Once the informer did not sync, it is stopped and
+// errNotSynced is returned without waiting.
+func (inf *informer) get(ctx context.Context, key string, syncTimeout time.Duration) (interface{}, error) {
+	if inf.unsynced.Load() {
+		return nil, errNotSynced
+	}
+
+	syncCtx, cancel := context.WithTimeout(ctx, syncTimeout)
+	defer cancel()
+	go func() {
+		select {
+		case <-inf.failed:
+			cancel()
+		case <-syncCtx.Done():
+		}
+	}()
+
+	if !cache.WaitForCacheSync(syncCtx.Done(), inf.HasSynced) {
+		if inf.unsynced.Load() {
+			return nil, errNotSynced
+		}
+		// the request was canceled, the informer may still sync.
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("%w: %v", errNotSynced, ctx.Err())
+		}
+
+		inf.disable(fmt.Sprintf("did not sync within %v", syncTimeout))
+
+		return nil, fmt.Errorf("%w: %v", errNotSynced, syncCtx.Err())
+	}
+
+	obj, exists, err := inf.GetIndexer().GetByKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%q not found in cache", key)
+	}
+
+	return obj, nil
+}
+
+// disable stops the informer, the objects are fetched from the API server
+// from then on.
+func (inf *informer) disable(reason string) {
+	if inf.unsynced.Swap(true) {
+		return
+	}
+
+	klog.Warningf("%s informer %s, fetching %s from the API server instead: "+
+		"check that the RBAC rules allow to list and watch %s", inf.resource, reason, inf.resource, inf.resource)
+	inf.stop()
+	close(inf.failed)
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+)
+
+// newTestCache returns a Cache that lists the objects, without a client.
+func newTestCache(t *testing.T, secrets []corev1.Secret, scs []storagev1.StorageClass) *Cache {
+	t.Helper()
+	stopCh := make(chan struct{})
+	t.Cleanup(func() { close(stopCh) })
+
+	c := NewCache(nil, stopCh)
+	c.newListWatch = func(resource, namespace, name string) cache.ListerWatcher {
+		return &cache.ListWatch{
+			ListFunc: func(metav1.ListOptions) (runtime.Object, error) {
+				if resource == "storageclasses" {
+					return &storagev1.StorageClassList{Items: scs}, nil
+				}
+				list := &corev1.SecretList{}
+				for _, secret := range secrets {
+					if secret.Namespace == namespace && secret.Name == name {
+						list.Items = append(list.Items, secret)
+					}
+				}
+
+				return list, nil
+			},
+			WatchFunc: func(metav1.ListOptions) (watch.Interface, error) {
+				return watch.NewFake(), nil
+			},
+		}
+	}
+
+	return c
+}
+
+func TestCacheGetSecret(t *testing.T) {
+	t.Parallel()
+	c := newTestCache(t, []corev1.Secret{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "ns-1"},
+			Data:       map[string][]byte{"key": []byte("value-1")},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "ns-2"},
+			Data:       map[string][]byte{"key": []byte("value-2")},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "ns-2"},
+			Data:       map[string][]byte{"key": []byte("value-3")},
+		},
+	}, nil)
+
+	data, err := c.GetSecret(context.TODO(), "secret", "ns-1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"key": "value-1"}, data)
+
+	data, err = c.GetSecret(context.TODO(), "secret", "ns-2")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"key": "value-2"}, data)
+
+	// an informer is started for each secret, other secrets in the
+	// namespace are not cached.
+	assert.Len(t, c.secretInformers, 2)
+	assert.Len(t, c.secretInformers["ns-2/secret"].GetStore().List(), 1)
+}
+
+func TestCacheGetStorageClass(t *testing.T) {
+	t.Parallel()
+	c := newTestCache(t, nil, []storagev1.StorageClass{
+		{ObjectMeta: metav1.ObjectMeta{Name: "sc-1"}, Provisioner: "test.csi.io"},
+	})
+
+	sc, err := c.GetStorageClass(context.TODO(), "sc-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "test.csi.io", sc.Provisioner)
+}
+
+// newUnsyncedCache returns a Cache whose informers never sync, as listing
+// fails.
+func newUnsyncedCache(t *testing.T) *Cache {
+	t.Helper()
+	stopCh := make(chan struct{})
+	t.Cleanup(func() { close(stopCh) })
+
+	c := NewCache(nil, stopCh)
+	c.syncTimeout = 100 * time.Millisecond
+	c.newListWatch = func(resource, namespace, name string) cache.ListerWatcher {
+		return &cache.ListWatch{
+			ListFunc: func(metav1.ListOptions) (runtime.Object, error) {
+				return nil, errors.New(resource + " can not be listed")
+			},
+			WatchFunc: func(metav1.ListOptions) (watch.Interface, error) {
+				return nil, errors.New(resource + " can not be watched")
+			},
+		}
+	}
+
+	return c
+}
+
+func TestInformerNotSynced(t *testing.T) {
+	t.Parallel()
+	c := newUnsyncedCache(t)
+	inf := c.startInformer("storageclasses", metav1.NamespaceAll, "", &storagev1.StorageClass{})
+
+	_, err := inf.get(context.TODO(), "sc-1", c.syncTimeout)
+	assert.ErrorIs(t, err, errNotSynced)
+	assert.True(t, inf.unsynced.Load())
+
+	// the informer is not waited for again.
+	start := time.Now()
+	_, err = inf.get(context.TODO(), "sc-1", c.syncTimeout)
+	assert.ErrorIs(t, err, errNotSynced)
+	assert.Less(t, time.Since(start), c.syncTimeout)
+}
+
+func TestInformerCanceled(t *testing.T) {
+	t.Parallel()
+	c := newUnsyncedCache(t)
+	inf := c.startInformer("storageclasses", metav1.NamespaceAll, "", &storagev1.StorageClass{})
+
+	// a canceled request does not disable the informer.
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	_, err := inf.get(ctx, "sc-1", c.syncTimeout)
+	assert.ErrorIs(t, err, errNotSynced)
+	assert.False(t, inf.unsynced.Load())
+}
+
+func TestInformerForbidden(t *testing.T) {
+	t.Parallel()
+	stopCh := make(chan struct{})
+	t.Cleanup(func() { close(stopCh) })
+
+	c := NewCache(nil, stopCh)
+	c.newListWatch = func(resource, namespace, name string) cache.ListerWatcher {
+		forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: resource}, name, errors.New("rbac"))
+
+		return &cache.ListWatch{
+			ListFunc: func(metav1.ListOptions) (runtime.Object, error) {
+				return nil, forbidden
+			},
+			WatchFunc: func(metav1.ListOptions) (watch.Interface, error) {
+				return nil, forbidden
+			},
+		}
+	}
+	inf := c.startInformer("secrets", "ns-1", "secret", &corev1.Secret{})
+
+	// the request does not wait for the sync timeout.
+	start := time.Now()
+	_, err := inf.get(context.TODO(), "ns-1/secret", c.syncTimeout)
+	assert.ErrorIs(t, err, errNotSynced)
+	assert.True(t, inf.unsynced.Load())
+	assert.Less(t, time.Since(start), c.syncTimeout)
+}
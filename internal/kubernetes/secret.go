@@ -18,21 +18,27 @@ package kubernetes
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
 // GetSecret get the secret details by name and namespace.
-func GetSecret(ctx context.Context, kubeclient *kubernetes.Clientset, name, ns string) (map[string]string, error) {
-	data := make(map[string]string)
-
+func GetSecret(ctx context.Context, kubeclient kubernetes.Interface, name, ns string) (map[string]string, error) {
 	secret, err := kubeclient.CoreV1().Secrets(ns).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		return nil, err
 	}
+
+	return getSecretData(secret), nil
+}
+
+// getSecretData returns the data of the secret as strings.
+func getSecretData(secret *corev1.Secret) map[string]string {
+	data := make(map[string]string)
 	for k, v := range secret.Data {
 		data[k] = string(v)
 	}
 
-	return data, nil
+	return data
 }
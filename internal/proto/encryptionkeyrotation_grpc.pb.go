@@ -0,0 +1,113 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.19.6
+// source: encryptionkeyrotation.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	EncryptionKeyRotation_NodeEncryptionKeyRotate_FullMethodName = "/proto.EncryptionKeyRotation/NodeEncryptionKeyRotate"
+)
+
+// EncryptionKeyRotationClient is the client API for EncryptionKeyRotation service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EncryptionKeyRotationClient interface {
+	// NodeEncryptionKeyRotate is a procedure that gets called on the CSI
+	// sidecar on the node where the volume is attached.
+	NodeEncryptionKeyRotate(ctx context.Context, in *EncryptionKeyRotationRequest, opts ...grpc.CallOption) (*EncryptionKeyRotationResponse, error)
+}
+
+type encryptionKeyRotationClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEncryptionKeyRotationClient(cc grpc.ClientConnInterface) EncryptionKeyRotationClient {
+	return &encryptionKeyRotationClient{cc}
+}
+
+func (c *encryptionKeyRotationClient) NodeEncryptionKeyRotate(ctx context.Context, in *EncryptionKeyRotationRequest, opts ...grpc.CallOption) (*EncryptionKeyRotationResponse, error) {
+	out := new(EncryptionKeyRotationResponse)
+	err := c.cc.Invoke(ctx, EncryptionKeyRotation_NodeEncryptionKeyRotate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EncryptionKeyRotationServer is the server API for EncryptionKeyRotation service.
+// All implementations must embed UnimplementedEncryptionKeyRotationServer
+// for forward compatibility
+type EncryptionKeyRotationServer interface {
+	// NodeEncryptionKeyRotate is a procedure that gets called on the CSI
+	// sidecar on the node where the volume is attached.
+	NodeEncryptionKeyRotate(context.Context, *EncryptionKeyRotationRequest) (*EncryptionKeyRotationResponse, error)
+	mustEmbedUnimplementedEncryptionKeyRotationServer()
+}
+
+// UnimplementedEncryptionKeyRotationServer must be embedded to have forward compatible implementations.
+type UnimplementedEncryptionKeyRotationServer struct {
+}
+
+func (UnimplementedEncryptionKeyRotationServer) NodeEncryptionKeyRotate(context.Context, *EncryptionKeyRotationRequest) (*EncryptionKeyRotationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NodeEncryptionKeyRotate not implemented")
+}
+func (UnimplementedEncryptionKeyRotationServer) mustEmbedUnimplementedEncryptionKeyRotationServer() {}
+
+// UnsafeEncryptionKeyRotationServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EncryptionKeyRotationServer will
+// result in compilation errors.
+type UnsafeEncryptionKeyRotationServer interface {
+	mustEmbedUnimplementedEncryptionKeyRotationServer()
+}
+
+func RegisterEncryptionKeyRotationServer(s grpc.ServiceRegistrar, srv EncryptionKeyRotationServer) {
+	s.RegisterService(&EncryptionKeyRotation_ServiceDesc, srv)
+}
+
+func _EncryptionKeyRotation_NodeEncryptionKeyRotate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EncryptionKeyRotationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EncryptionKeyRotationServer).NodeEncryptionKeyRotate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EncryptionKeyRotation_NodeEncryptionKeyRotate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EncryptionKeyRotationServer).NodeEncryptionKeyRotate(ctx, req.(*EncryptionKeyRotationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EncryptionKeyRotation_ServiceDesc is the grpc.ServiceDesc for EncryptionKeyRotation service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EncryptionKeyRotation_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.EncryptionKeyRotation",
+	HandlerType: (*EncryptionKeyRotationServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "NodeEncryptionKeyRotate",
+			Handler:    _EncryptionKeyRotation_NodeEncryptionKeyRotate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "encryptionkeyrotation.proto",
+}
@@ -0,0 +1,316 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.19.6
+// source: filesystemmaintenance.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Operation is the maintenance operation on the filesystem.
+type FilesystemMaintenanceRequest_Operation int32
+
+const (
+	// UNKNOWN indicates that the operation is not set.
+	FilesystemMaintenanceRequest_UNKNOWN FilesystemMaintenanceRequest_Operation = 0
+	// DEFRAGMENT reorganizes the filesystem to reduce its
+	// fragmentation, like e4defrag or xfs_fsr do.
+	FilesystemMaintenanceRequest_DEFRAGMENT FilesystemMaintenanceRequest_Operation = 1
+	// CHECK checks the consistency of the filesystem without
+	// modifying it, like fsck in read-only mode does.
+	FilesystemMaintenanceRequest_CHECK FilesystemMaintenanceRequest_Operation = 2
+)
+
+// Enum value maps for FilesystemMaintenanceRequest_Operation.
+var (
+	FilesystemMaintenanceRequest_Operation_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "DEFRAGMENT",
+		2: "CHECK",
+	}
+	FilesystemMaintenanceRequest_Operation_value = map[string]int32{
+		"UNKNOWN":    0,
+		"DEFRAGMENT": 1,
+		"CHECK":      2,
+	}
+)
+
+func (x FilesystemMaintenanceRequest_Operation) Enum() *FilesystemMaintenanceRequest_Operation {
+	p := new(FilesystemMaintenanceRequest_Operation)
+	*p = x
+	return p
+}
+
+func (x FilesystemMaintenanceRequest_Operation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FilesystemMaintenanceRequest_Operation) Descriptor() protoreflect.EnumDescriptor {
+	return file_filesystemmaintenance_proto_enumTypes[0].Descriptor()
+}
+
+func (FilesystemMaintenanceRequest_Operation) Type() protoreflect.EnumType {
+	return &file_filesystemmaintenance_proto_enumTypes[0]
+}
+
+func (x FilesystemMaintenanceRequest_Operation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FilesystemMaintenanceRequest_Operation.Descriptor instead.
+func (FilesystemMaintenanceRequest_Operation) EnumDescriptor() ([]byte, []int) {
+	return file_filesystemmaintenance_proto_rawDescGZIP(), []int{0, 0}
+}
+
+// FilesystemMaintenanceRequest contains the information i.e., pv_name and
+// operation received from the CSIAddons controller for running a
+// maintenance operation.
+type FilesystemMaintenanceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the pv. This field is REQUIRED.
+	PvName string `protobuf:"bytes,1,opt,name=pv_name,json=pvName,proto3" json:"pv_name,omitempty"`
+	// The operation to run. This field is REQUIRED.
+	Operation FilesystemMaintenanceRequest_Operation `protobuf:"varint,2,opt,name=operation,proto3,enum=proto.FilesystemMaintenanceRequest_Operation" json:"operation,omitempty"`
+}
+
+func (x *FilesystemMaintenanceRequest) Reset() {
+	*x = FilesystemMaintenanceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filesystemmaintenance_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FilesystemMaintenanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilesystemMaintenanceRequest) ProtoMessage() {}
+
+func (x *FilesystemMaintenanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_filesystemmaintenance_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilesystemMaintenanceRequest.ProtoReflect.Descriptor instead.
+func (*FilesystemMaintenanceRequest) Descriptor() ([]byte, []int) {
+	return file_filesystemmaintenance_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FilesystemMaintenanceRequest) GetPvName() string {
+	if x != nil {
+		return x.PvName
+	}
+	return ""
+}
+
+func (x *FilesystemMaintenanceRequest) GetOperation() FilesystemMaintenanceRequest_Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return FilesystemMaintenanceRequest_UNKNOWN
+}
+
+// FilesystemMaintenanceResponse holds the information about the result of
+// the NodeFilesystemMaintenance call.
+type FilesystemMaintenanceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// errors_found is true when a CHECK operation found errors in the
+	// filesystem.
+	ErrorsFound bool `protobuf:"varint,1,opt,name=errors_found,json=errorsFound,proto3" json:"errors_found,omitempty"`
+	// message contains the output of the operation for the user.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *FilesystemMaintenanceResponse) Reset() {
+	*x = FilesystemMaintenanceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filesystemmaintenance_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FilesystemMaintenanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilesystemMaintenanceResponse) ProtoMessage() {}
+
+func (x *FilesystemMaintenanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_filesystemmaintenance_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilesystemMaintenanceResponse.ProtoReflect.Descriptor instead.
+func (*FilesystemMaintenanceResponse) Descriptor() ([]byte, []int) {
+	return file_filesystemmaintenance_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FilesystemMaintenanceResponse) GetErrorsFound() bool {
+	if x != nil {
+		return x.ErrorsFound
+	}
+	return false
+}
+
+func (x *FilesystemMaintenanceResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_filesystemmaintenance_proto protoreflect.FileDescriptor
+
+var file_filesystemmaintenance_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x6d, 0x61, 0x69, 0x6e,
+	0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb9, 0x01, 0x0a, 0x1c, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x76, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x76, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x4b,
+	0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x2d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x33, 0x0a, 0x09, 0x4f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e,
+	0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x45, 0x46, 0x52, 0x41, 0x47, 0x4d,
+	0x45, 0x4e, 0x54, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x43, 0x48, 0x45, 0x43, 0x4b, 0x10, 0x02,
+	0x22, 0x5c, 0x0a, 0x1d, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4d, 0x61,
+	0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x5f, 0x66, 0x6f, 0x75, 0x6e,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x46,
+	0x6f, 0x75, 0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0x81,
+	0x01, 0x0a, 0x15, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4d, 0x61, 0x69,
+	0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x68, 0x0a, 0x19, 0x4e, 0x6f, 0x64, 0x65,
+	0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65,
+	0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x69,
+	0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61,
+	0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4d, 0x61, 0x69,
+	0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x42, 0x3c, 0x5a, 0x3a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x63, 0x73, 0x69, 0x2d, 0x61, 0x64, 0x64, 0x6f, 0x6e, 0x73, 0x2f, 0x6b, 0x75, 0x62, 0x65,
+	0x72, 0x6e, 0x65, 0x74, 0x65, 0x73, 0x2d, 0x63, 0x73, 0x69, 0x2d, 0x61, 0x64, 0x64, 0x6f, 0x6e,
+	0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_filesystemmaintenance_proto_rawDescOnce sync.Once
+	file_filesystemmaintenance_proto_rawDescData = file_filesystemmaintenance_proto_rawDesc
+)
+
+func file_filesystemmaintenance_proto_rawDescGZIP() []byte {
+	file_filesystemmaintenance_proto_rawDescOnce.Do(func() {
+		file_filesystemmaintenance_proto_rawDescData = protoimpl.X.CompressGZIP(file_filesystemmaintenance_proto_rawDescData)
+	})
+	return file_filesystemmaintenance_proto_rawDescData
+}
+
+var file_filesystemmaintenance_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_filesystemmaintenance_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_filesystemmaintenance_proto_goTypes = []interface{}{
+	(FilesystemMaintenanceRequest_Operation)(0), // 0: proto.FilesystemMaintenanceRequest.Operation
+	(*FilesystemMaintenanceRequest)(nil),        // 1: proto.FilesystemMaintenanceRequest
+	(*FilesystemMaintenanceResponse)(nil),       // 2: proto.FilesystemMaintenanceResponse
+}
+var file_filesystemmaintenance_proto_depIdxs = []int32{
+	0, // 0: proto.FilesystemMaintenanceRequest.operation:type_name -> proto.FilesystemMaintenanceRequest.Operation
+	1, // 1: proto.FilesystemMaintenance.NodeFilesystemMaintenance:input_type -> proto.FilesystemMaintenanceRequest
+	2, // 2: proto.FilesystemMaintenance.NodeFilesystemMaintenance:output_type -> proto.FilesystemMaintenanceResponse
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_filesystemmaintenance_proto_init() }
+func file_filesystemmaintenance_proto_init() {
+	if File_filesystemmaintenance_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_filesystemmaintenance_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FilesystemMaintenanceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filesystemmaintenance_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FilesystemMaintenanceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_filesystemmaintenance_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_filesystemmaintenance_proto_goTypes,
+		DependencyIndexes: file_filesystemmaintenance_proto_depIdxs,
+		EnumInfos:         file_filesystemmaintenance_proto_enumTypes,
+		MessageInfos:      file_filesystemmaintenance_proto_msgTypes,
+	}.Build()
+	File_filesystemmaintenance_proto = out.File
+	file_filesystemmaintenance_proto_rawDesc = nil
+	file_filesystemmaintenance_proto_goTypes = nil
+	file_filesystemmaintenance_proto_depIdxs = nil
+}
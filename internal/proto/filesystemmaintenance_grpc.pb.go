@@ -0,0 +1,113 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.19.6
+// source: filesystemmaintenance.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	FilesystemMaintenance_NodeFilesystemMaintenance_FullMethodName = "/proto.FilesystemMaintenance/NodeFilesystemMaintenance"
+)
+
+// FilesystemMaintenanceClient is the client API for FilesystemMaintenance service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FilesystemMaintenanceClient interface {
+	// NodeFilesystemMaintenance is a procedure that gets called on the CSI
+	// sidecar on the node where the volume is attached.
+	NodeFilesystemMaintenance(ctx context.Context, in *FilesystemMaintenanceRequest, opts ...grpc.CallOption) (*FilesystemMaintenanceResponse, error)
+}
+
+type filesystemMaintenanceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFilesystemMaintenanceClient(cc grpc.ClientConnInterface) FilesystemMaintenanceClient {
+	return &filesystemMaintenanceClient{cc}
+}
+
+func (c *filesystemMaintenanceClient) NodeFilesystemMaintenance(ctx context.Context, in *FilesystemMaintenanceRequest, opts ...grpc.CallOption) (*FilesystemMaintenanceResponse, error) {
+	out := new(FilesystemMaintenanceResponse)
+	err := c.cc.Invoke(ctx, FilesystemMaintenance_NodeFilesystemMaintenance_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FilesystemMaintenanceServer is the server API for FilesystemMaintenance service.
+// All implementations must embed UnimplementedFilesystemMaintenanceServer
+// for forward compatibility
+type FilesystemMaintenanceServer interface {
+	// NodeFilesystemMaintenance is a procedure that gets called on the CSI
+	// sidecar on the node where the volume is attached.
+	NodeFilesystemMaintenance(context.Context, *FilesystemMaintenanceRequest) (*FilesystemMaintenanceResponse, error)
+	mustEmbedUnimplementedFilesystemMaintenanceServer()
+}
+
+// UnimplementedFilesystemMaintenanceServer must be embedded to have forward compatible implementations.
+type UnimplementedFilesystemMaintenanceServer struct {
+}
+
+func (UnimplementedFilesystemMaintenanceServer) NodeFilesystemMaintenance(context.Context, *FilesystemMaintenanceRequest) (*FilesystemMaintenanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NodeFilesystemMaintenance not implemented")
+}
+func (UnimplementedFilesystemMaintenanceServer) mustEmbedUnimplementedFilesystemMaintenanceServer() {}
+
+// UnsafeFilesystemMaintenanceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FilesystemMaintenanceServer will
+// result in compilation errors.
+type UnsafeFilesystemMaintenanceServer interface {
+	mustEmbedUnimplementedFilesystemMaintenanceServer()
+}
+
+func RegisterFilesystemMaintenanceServer(s grpc.ServiceRegistrar, srv FilesystemMaintenanceServer) {
+	s.RegisterService(&FilesystemMaintenance_ServiceDesc, srv)
+}
+
+func _FilesystemMaintenance_NodeFilesystemMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FilesystemMaintenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilesystemMaintenanceServer).NodeFilesystemMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FilesystemMaintenance_NodeFilesystemMaintenance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilesystemMaintenanceServer).NodeFilesystemMaintenance(ctx, req.(*FilesystemMaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FilesystemMaintenance_ServiceDesc is the grpc.ServiceDesc for FilesystemMaintenance service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FilesystemMaintenance_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.FilesystemMaintenance",
+	HandlerType: (*FilesystemMaintenanceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "NodeFilesystemMaintenance",
+			Handler:    _FilesystemMaintenance_NodeFilesystemMaintenance_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "filesystemmaintenance.proto",
+}
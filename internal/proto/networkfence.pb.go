@@ -20,6 +20,71 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Type is the type of the client identity.
+type FenceTarget_Type int32
+
+const (
+	// UNKNOWN indicates that the type is not set.
+	FenceTarget_UNKNOWN FenceTarget_Type = 0
+	// CLIENT_ID identifies a client by its storage client ID.
+	FenceTarget_CLIENT_ID FenceTarget_Type = 1
+	// NODE_ID identifies a client by the CSI node identifier.
+	FenceTarget_NODE_ID FenceTarget_Type = 2
+	// ISCSI_INITIATOR identifies a client by its iSCSI initiator name (IQN).
+	FenceTarget_ISCSI_INITIATOR FenceTarget_Type = 3
+	// NVME_HOST_NQN identifies a client by its NVMe host NQN.
+	FenceTarget_NVME_HOST_NQN FenceTarget_Type = 4
+	// CLIENT_ADDRESS identifies a client by its storage specific address.
+	FenceTarget_CLIENT_ADDRESS FenceTarget_Type = 5
+)
+
+// Enum value maps for FenceTarget_Type.
+var (
+	FenceTarget_Type_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "CLIENT_ID",
+		2: "NODE_ID",
+		3: "ISCSI_INITIATOR",
+		4: "NVME_HOST_NQN",
+		5: "CLIENT_ADDRESS",
+	}
+	FenceTarget_Type_value = map[string]int32{
+		"UNKNOWN":         0,
+		"CLIENT_ID":       1,
+		"NODE_ID":         2,
+		"ISCSI_INITIATOR": 3,
+		"NVME_HOST_NQN":   4,
+		"CLIENT_ADDRESS":  5,
+	}
+)
+
+func (x FenceTarget_Type) Enum() *FenceTarget_Type {
+	p := new(FenceTarget_Type)
+	*p = x
+	return p
+}
+
+func (x FenceTarget_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FenceTarget_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_networkfence_proto_enumTypes[0].Descriptor()
+}
+
+func (FenceTarget_Type) Type() protoreflect.EnumType {
+	return &file_networkfence_proto_enumTypes[0]
+}
+
+func (x FenceTarget_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FenceTarget_Type.Descriptor instead.
+func (FenceTarget_Type) EnumDescriptor() ([]byte, []int) {
+	return file_networkfence_proto_rawDescGZIP(), []int{1, 0}
+}
+
 // NetworkFenceRequest holds the required information to fence/unfence
 // the cluster network.
 type NetworkFenceRequest struct {
@@ -35,6 +100,9 @@ type NetworkFenceRequest struct {
 	// list of CIDR blocks on which the fencing/unfencing operation is expected
 	// to be performed.
 	Cidrs []string `protobuf:"bytes,4,rep,name=cidrs,proto3" json:"cidrs,omitempty"`
+	// list of client identities on which the fencing/unfencing operation is
+	// expected to be performed.
+	Targets []*FenceTarget `protobuf:"bytes,5,rep,name=targets,proto3" json:"targets,omitempty"`
 }
 
 func (x *NetworkFenceRequest) Reset() {
@@ -97,6 +165,72 @@ func (x *NetworkFenceRequest) GetCidrs() []string {
 	return nil
 }
 
+func (x *NetworkFenceRequest) GetTargets() []*FenceTarget {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+// FenceTarget identifies a client by its identity rather than its network
+// address.
+type FenceTarget struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// type of the client identity.
+	Type FenceTarget_Type `protobuf:"varint,1,opt,name=type,proto3,enum=proto.FenceTarget_Type" json:"type,omitempty"`
+	// value of the client identity.
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *FenceTarget) Reset() {
+	*x = FenceTarget{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_networkfence_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FenceTarget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FenceTarget) ProtoMessage() {}
+
+func (x *FenceTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_networkfence_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FenceTarget.ProtoReflect.Descriptor instead.
+func (*FenceTarget) Descriptor() ([]byte, []int) {
+	return file_networkfence_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FenceTarget) GetType() FenceTarget_Type {
+	if x != nil {
+		return x.Type
+	}
+	return FenceTarget_UNKNOWN
+}
+
+func (x *FenceTarget) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
 // NetworkFenceResponse is returned by the CSI-driver as a result of
 // the FenceRequest call.
 type NetworkFenceResponse struct {
@@ -108,7 +242,7 @@ type NetworkFenceResponse struct {
 func (x *NetworkFenceResponse) Reset() {
 	*x = NetworkFenceResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_networkfence_proto_msgTypes[1]
+		mi := &file_networkfence_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -121,7 +255,7 @@ func (x *NetworkFenceResponse) String() string {
 func (*NetworkFenceResponse) ProtoMessage() {}
 
 func (x *NetworkFenceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_networkfence_proto_msgTypes[1]
+	mi := &file_networkfence_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -134,14 +268,14 @@ func (x *NetworkFenceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NetworkFenceResponse.ProtoReflect.Descriptor instead.
 func (*NetworkFenceResponse) Descriptor() ([]byte, []int) {
-	return file_networkfence_proto_rawDescGZIP(), []int{1}
+	return file_networkfence_proto_rawDescGZIP(), []int{2}
 }
 
 var File_networkfence_proto protoreflect.FileDescriptor
 
 var file_networkfence_proto_rawDesc = []byte{
 	0x0a, 0x12, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x66, 0x65, 0x6e, 0x63, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x82, 0x02, 0x0a, 0x13,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb0, 0x02, 0x0a, 0x13,
 	0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x46, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x12, 0x4a, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72,
 	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
@@ -154,28 +288,43 @@ var file_networkfence_proto_rawDesc = []byte{
 	0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x73, 0x65, 0x63, 0x72,
 	0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63,
 	0x69, 0x64, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x63, 0x69, 0x64, 0x72,
-	0x73, 0x1a, 0x3d, 0x0a, 0x0f, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
-	0x22, 0x16, 0x0a, 0x14, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x46, 0x65, 0x6e, 0x63, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xb4, 0x01, 0x0a, 0x0c, 0x4e, 0x65, 0x74,
-	0x77, 0x6f, 0x72, 0x6b, 0x46, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x50, 0x0a, 0x13, 0x46, 0x65, 0x6e,
-	0x63, 0x65, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
-	0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
-	0x46, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x46, 0x65, 0x6e, 0x63,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x15, 0x55,
-	0x6e, 0x46, 0x65, 0x6e, 0x63, 0x65, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x65, 0x74,
-	0x77, 0x6f, 0x72, 0x6b, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x65, 0x74,
-	0x77, 0x6f, 0x72, 0x6b, 0x46, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
-	0x46, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42,
-	0x3c, 0x5a, 0x3a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x73,
-	0x69, 0x2d, 0x61, 0x64, 0x64, 0x6f, 0x6e, 0x73, 0x2f, 0x6b, 0x75, 0x62, 0x65, 0x72, 0x6e, 0x65,
-	0x74, 0x65, 0x73, 0x2d, 0x63, 0x73, 0x69, 0x2d, 0x61, 0x64, 0x64, 0x6f, 0x6e, 0x73, 0x2f, 0x69,
-	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x12, 0x2c, 0x0a, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x65, 0x6e, 0x63, 0x65,
+	0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x1a,
+	0x3d, 0x0a, 0x0f, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xbd,
+	0x01, 0x0a, 0x0b, 0x46, 0x65, 0x6e, 0x63, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x2b,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x65, 0x6e, 0x63, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x22, 0x6b, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b,
+	0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x4c, 0x49, 0x45, 0x4e, 0x54,
+	0x5f, 0x49, 0x44, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x4e, 0x4f, 0x44, 0x45, 0x5f, 0x49, 0x44,
+	0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x49, 0x53, 0x43, 0x53, 0x49, 0x5f, 0x49, 0x4e, 0x49, 0x54,
+	0x49, 0x41, 0x54, 0x4f, 0x52, 0x10, 0x03, 0x12, 0x11, 0x0a, 0x0d, 0x4e, 0x56, 0x4d, 0x45, 0x5f,
+	0x48, 0x4f, 0x53, 0x54, 0x5f, 0x4e, 0x51, 0x4e, 0x10, 0x04, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x4c,
+	0x49, 0x45, 0x4e, 0x54, 0x5f, 0x41, 0x44, 0x44, 0x52, 0x45, 0x53, 0x53, 0x10, 0x05, 0x22, 0x16,
+	0x0a, 0x14, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x46, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xb4, 0x01, 0x0a, 0x0c, 0x4e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x46, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x50, 0x0a, 0x13, 0x46, 0x65, 0x6e, 0x63, 0x65,
+	0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x1a,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x46, 0x65,
+	0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x46, 0x65, 0x6e, 0x63, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x15, 0x55, 0x6e, 0x46,
+	0x65, 0x6e, 0x63, 0x65, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x46, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x46, 0x65,
+	0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x3c, 0x5a,
+	0x3a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x73, 0x69, 0x2d,
+	0x61, 0x64, 0x64, 0x6f, 0x6e, 0x73, 0x2f, 0x6b, 0x75, 0x62, 0x65, 0x72, 0x6e, 0x65, 0x74, 0x65,
+	0x73, 0x2d, 0x63, 0x73, 0x69, 0x2d, 0x61, 0x64, 0x64, 0x6f, 0x6e, 0x73, 0x2f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -190,23 +339,28 @@ func file_networkfence_proto_rawDescGZIP() []byte {
 	return file_networkfence_proto_rawDescData
 }
 
-var file_networkfence_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_networkfence_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_networkfence_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_networkfence_proto_goTypes = []interface{}{
-	(*NetworkFenceRequest)(nil),  // 0: proto.NetworkFenceRequest
-	(*NetworkFenceResponse)(nil), // 1: proto.NetworkFenceResponse
-	nil,                          // 2: proto.NetworkFenceRequest.ParametersEntry
+	(FenceTarget_Type)(0),        // 0: proto.FenceTarget.Type
+	(*NetworkFenceRequest)(nil),  // 1: proto.NetworkFenceRequest
+	(*FenceTarget)(nil),          // 2: proto.FenceTarget
+	(*NetworkFenceResponse)(nil), // 3: proto.NetworkFenceResponse
+	nil,                          // 4: proto.NetworkFenceRequest.ParametersEntry
 }
 var file_networkfence_proto_depIdxs = []int32{
-	2, // 0: proto.NetworkFenceRequest.parameters:type_name -> proto.NetworkFenceRequest.ParametersEntry
-	0, // 1: proto.NetworkFence.FenceClusterNetwork:input_type -> proto.NetworkFenceRequest
-	0, // 2: proto.NetworkFence.UnFenceClusterNetwork:input_type -> proto.NetworkFenceRequest
-	1, // 3: proto.NetworkFence.FenceClusterNetwork:output_type -> proto.NetworkFenceResponse
-	1, // 4: proto.NetworkFence.UnFenceClusterNetwork:output_type -> proto.NetworkFenceResponse
-	3, // [3:5] is the sub-list for method output_type
-	1, // [1:3] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	4, // 0: proto.NetworkFenceRequest.parameters:type_name -> proto.NetworkFenceRequest.ParametersEntry
+	2, // 1: proto.NetworkFenceRequest.targets:type_name -> proto.FenceTarget
+	0, // 2: proto.FenceTarget.type:type_name -> proto.FenceTarget.Type
+	1, // 3: proto.NetworkFence.FenceClusterNetwork:input_type -> proto.NetworkFenceRequest
+	1, // 4: proto.NetworkFence.UnFenceClusterNetwork:input_type -> proto.NetworkFenceRequest
+	3, // 5: proto.NetworkFence.FenceClusterNetwork:output_type -> proto.NetworkFenceResponse
+	3, // 6: proto.NetworkFence.UnFenceClusterNetwork:output_type -> proto.NetworkFenceResponse
+	5, // [5:7] is the sub-list for method output_type
+	3, // [3:5] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_networkfence_proto_init() }
@@ -228,6 +382,18 @@ func file_networkfence_proto_init() {
 			}
 		}
 		file_networkfence_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FenceTarget); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_networkfence_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*NetworkFenceResponse); i {
 			case 0:
 				return &v.state
@@ -245,13 +411,14 @@ func file_networkfence_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_networkfence_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   3,
+			NumEnums:      1,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_networkfence_proto_goTypes,
 		DependencyIndexes: file_networkfence_proto_depIdxs,
+		EnumInfos:         file_networkfence_proto_enumTypes,
 		MessageInfos:      file_networkfence_proto_msgTypes,
 	}.Build()
 	File_networkfence_proto = out.File
@@ -0,0 +1,236 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.19.6
+// source: volumehealth.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// VolumeHealthRequest contains the information i.e., pv_name received from
+// the CSIAddons controller for checking the health of the volume.
+type VolumeHealthRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the pv. This field is REQUIRED.
+	PvName string `protobuf:"bytes,1,opt,name=pv_name,json=pvName,proto3" json:"pv_name,omitempty"`
+}
+
+func (x *VolumeHealthRequest) Reset() {
+	*x = VolumeHealthRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_volumehealth_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VolumeHealthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumeHealthRequest) ProtoMessage() {}
+
+func (x *VolumeHealthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_volumehealth_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumeHealthRequest.ProtoReflect.Descriptor instead.
+func (*VolumeHealthRequest) Descriptor() ([]byte, []int) {
+	return file_volumehealth_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *VolumeHealthRequest) GetPvName() string {
+	if x != nil {
+		return x.PvName
+	}
+	return ""
+}
+
+// VolumeHealthResponse holds the condition of the volume as reported by the
+// driver.
+type VolumeHealthResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// This field is REQUIRED. abnormal is true when the volume is in an
+	// abnormal condition.
+	Abnormal bool `protobuf:"varint,1,opt,name=abnormal,proto3" json:"abnormal,omitempty"`
+	// This field is OPTIONAL. message describes the condition of the
+	// volume.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *VolumeHealthResponse) Reset() {
+	*x = VolumeHealthResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_volumehealth_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VolumeHealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumeHealthResponse) ProtoMessage() {}
+
+func (x *VolumeHealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_volumehealth_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumeHealthResponse.ProtoReflect.Descriptor instead.
+func (*VolumeHealthResponse) Descriptor() ([]byte, []int) {
+	return file_volumehealth_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *VolumeHealthResponse) GetAbnormal() bool {
+	if x != nil {
+		return x.Abnormal
+	}
+	return false
+}
+
+func (x *VolumeHealthResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_volumehealth_proto protoreflect.FileDescriptor
+
+var file_volumehealth_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x2e, 0x0a, 0x13, 0x56,
+	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x76, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x76, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x4c, 0x0a, 0x14, 0x56,
+	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x62, 0x6e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x62, 0x6e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0x60, 0x0a, 0x0c, 0x56, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x50, 0x0a, 0x13, 0x4e, 0x6f, 0x64,
+	0x65, 0x47, 0x65, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68,
+	0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x48,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x48, 0x65, 0x61, 0x6c, 0x74,
+	0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x3c, 0x5a, 0x3a, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x73, 0x69, 0x2d, 0x61, 0x64,
+	0x64, 0x6f, 0x6e, 0x73, 0x2f, 0x6b, 0x75, 0x62, 0x65, 0x72, 0x6e, 0x65, 0x74, 0x65, 0x73, 0x2d,
+	0x63, 0x73, 0x69, 0x2d, 0x61, 0x64, 0x64, 0x6f, 0x6e, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_volumehealth_proto_rawDescOnce sync.Once
+	file_volumehealth_proto_rawDescData = file_volumehealth_proto_rawDesc
+)
+
+func file_volumehealth_proto_rawDescGZIP() []byte {
+	file_volumehealth_proto_rawDescOnce.Do(func() {
+		file_volumehealth_proto_rawDescData = protoimpl.X.CompressGZIP(file_volumehealth_proto_rawDescData)
+	})
+	return file_volumehealth_proto_rawDescData
+}
+
+var file_volumehealth_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_volumehealth_proto_goTypes = []interface{}{
+	(*VolumeHealthRequest)(nil),  // 0: proto.VolumeHealthRequest
+	(*VolumeHealthResponse)(nil), // 1: proto.VolumeHealthResponse
+}
+var file_volumehealth_proto_depIdxs = []int32{
+	0, // 0: proto.VolumeHealth.NodeGetVolumeHealth:input_type -> proto.VolumeHealthRequest
+	1, // 1: proto.VolumeHealth.NodeGetVolumeHealth:output_type -> proto.VolumeHealthResponse
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_volumehealth_proto_init() }
+func file_volumehealth_proto_init() {
+	if File_volumehealth_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_volumehealth_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VolumeHealthRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_volumehealth_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VolumeHealthResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_volumehealth_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_volumehealth_proto_goTypes,
+		DependencyIndexes: file_volumehealth_proto_depIdxs,
+		MessageInfos:      file_volumehealth_proto_msgTypes,
+	}.Build()
+	File_volumehealth_proto = out.File
+	file_volumehealth_proto_rawDesc = nil
+	file_volumehealth_proto_goTypes = nil
+	file_volumehealth_proto_depIdxs = nil
+}
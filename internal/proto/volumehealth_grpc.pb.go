@@ -0,0 +1,113 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.19.6
+// source: volumehealth.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	VolumeHealth_NodeGetVolumeHealth_FullMethodName = "/proto.VolumeHealth/NodeGetVolumeHealth"
+)
+
+// VolumeHealthClient is the client API for VolumeHealth service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type VolumeHealthClient interface {
+	// NodeGetVolumeHealth is a procedure that gets called on the CSI
+	// sidecar on the node where the volume is published.
+	NodeGetVolumeHealth(ctx context.Context, in *VolumeHealthRequest, opts ...grpc.CallOption) (*VolumeHealthResponse, error)
+}
+
+type volumeHealthClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVolumeHealthClient(cc grpc.ClientConnInterface) VolumeHealthClient {
+	return &volumeHealthClient{cc}
+}
+
+func (c *volumeHealthClient) NodeGetVolumeHealth(ctx context.Context, in *VolumeHealthRequest, opts ...grpc.CallOption) (*VolumeHealthResponse, error) {
+	out := new(VolumeHealthResponse)
+	err := c.cc.Invoke(ctx, VolumeHealth_NodeGetVolumeHealth_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VolumeHealthServer is the server API for VolumeHealth service.
+// All implementations must embed UnimplementedVolumeHealthServer
+// for forward compatibility
+type VolumeHealthServer interface {
+	// NodeGetVolumeHealth is a procedure that gets called on the CSI
+	// sidecar on the node where the volume is published.
+	NodeGetVolumeHealth(context.Context, *VolumeHealthRequest) (*VolumeHealthResponse, error)
+	mustEmbedUnimplementedVolumeHealthServer()
+}
+
+// UnimplementedVolumeHealthServer must be embedded to have forward compatible implementations.
+type UnimplementedVolumeHealthServer struct {
+}
+
+func (UnimplementedVolumeHealthServer) NodeGetVolumeHealth(context.Context, *VolumeHealthRequest) (*VolumeHealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NodeGetVolumeHealth not implemented")
+}
+func (UnimplementedVolumeHealthServer) mustEmbedUnimplementedVolumeHealthServer() {}
+
+// UnsafeVolumeHealthServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VolumeHealthServer will
+// result in compilation errors.
+type UnsafeVolumeHealthServer interface {
+	mustEmbedUnimplementedVolumeHealthServer()
+}
+
+func RegisterVolumeHealthServer(s grpc.ServiceRegistrar, srv VolumeHealthServer) {
+	s.RegisterService(&VolumeHealth_ServiceDesc, srv)
+}
+
+func _VolumeHealth_NodeGetVolumeHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeHealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeHealthServer).NodeGetVolumeHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VolumeHealth_NodeGetVolumeHealth_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeHealthServer).NodeGetVolumeHealth(ctx, req.(*VolumeHealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// VolumeHealth_ServiceDesc is the grpc.ServiceDesc for VolumeHealth service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var VolumeHealth_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.VolumeHealth",
+	HandlerType: (*VolumeHealthServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "NodeGetVolumeHealth",
+			Handler:    _VolumeHealth_NodeGetVolumeHealth_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "volumehealth.proto",
+}
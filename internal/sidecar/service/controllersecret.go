@@ -0,0 +1,164 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+)
+
+// errUnsupportedTemplate is returned when a secret parameter of a
+// StorageClass contains a variable that can not be resolved.
+var errUnsupportedTemplate = errors.New("unsupported template")
+
+// controllerSecretParameters are the StorageClass parameters with the
+// secret for controller operations, in order of preference. The secret for
+// expanding a volume is preferred, as reclaiming space is closest to it.
+var controllerSecretParameters = []struct {
+	name      string
+	namespace string
+}{
+	{
+		name:      "csi.storage.k8s.io/controller-expand-secret-name",
+		namespace: "csi.storage.k8s.io/controller-expand-secret-namespace",
+	},
+	{
+		name:      "csi.storage.k8s.io/controller-publish-secret-name",
+		namespace: "csi.storage.k8s.io/controller-publish-secret-namespace",
+	},
+	{
+		name:      "csi.storage.k8s.io/provisioner-secret-name",
+		namespace: "csi.storage.k8s.io/provisioner-secret-namespace",
+	},
+}
+
+// getControllerSecretRef returns the secret for the ControllerReclaimSpace
+// request. The secrets for controller operations in the PersistentVolume are
+// used first, then the ones in the parameters of its StorageClass. The secret
+// for staging the volume is used when neither has a secret, as drivers used
+// to get that one.
+func (rs *ReclaimSpaceServer) getControllerSecretRef(ctx context.Context, pv *corev1.PersistentVolume) (*corev1.SecretReference, error) {
+	if ref := getPVSecretRef(pv); ref != nil {
+		return ref, nil
+	}
+
+	if pv.Spec.StorageClassName != "" {
+		sc, err := rs.kubeCache.GetStorageClass(ctx, pv.Spec.StorageClassName)
+		switch {
+		case apierrors.IsNotFound(err):
+			klog.Infof("storageclass %q of pv %q not found", pv.Spec.StorageClassName, pv.Name)
+		case err != nil:
+			return nil, fmt.Errorf("failed to get storageclass %q: %w", pv.Spec.StorageClassName, err)
+		default:
+			ref, err := getStorageClassSecretRef(sc.Parameters, pv)
+			if err != nil || ref != nil {
+				return ref, err
+			}
+		}
+	}
+
+	return pv.Spec.CSI.NodeStageSecretRef, nil
+}
+
+// getPVSecretRef returns the secret for controller operations that is set in
+// the PersistentVolume, if any.
+func getPVSecretRef(pv *corev1.PersistentVolume) *corev1.SecretReference {
+	if pv.Spec.CSI.ControllerExpandSecretRef != nil {
+		return pv.Spec.CSI.ControllerExpandSecretRef
+	}
+
+	return pv.Spec.CSI.ControllerPublishSecretRef
+}
+
+// getStorageClassSecretRef returns the secret for controller operations from
+// the parameters of the StorageClass of the PersistentVolume, if any. The
+// templates in the parameters are resolved like the external-provisioner
+// does, ${pv.name}, ${pvc.name} and ${pvc.namespace} are supported. Secret
+// parameters with other variables are skipped with a warning.
+func getStorageClassSecretRef(parameters map[string]string, pv *corev1.PersistentVolume) (*corev1.SecretReference, error) {
+	for _, param := range controllerSecretParameters {
+		name, hasName := parameters[param.name]
+		namespace, hasNamespace := parameters[param.namespace]
+		if !hasName && !hasNamespace {
+			continue
+		}
+		if !hasName || !hasNamespace {
+			return nil, fmt.Errorf("either both or none of %q and %q must be set", param.name, param.namespace)
+		}
+
+		var err error
+		name, err = resolveSecretTemplate(name, pv)
+		if errors.Is(err, errUnsupportedTemplate) {
+			klog.Warningf("skipping %q of pv %q: %v", param.name, pv.Name, err)
+
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to resolve %q: %w", param.name, err)
+		}
+		namespace, err = resolveSecretTemplate(namespace, pv)
+		if errors.Is(err, errUnsupportedTemplate) {
+			klog.Warningf("skipping %q of pv %q: %v", param.namespace, pv.Name, err)
+
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to resolve %q: %w", param.namespace, err)
+		}
+
+		return &corev1.SecretReference{Name: name, Namespace: namespace}, nil
+	}
+
+	return nil, nil
+}
+
+// resolveSecretTemplate replaces the ${pv.name}, ${pvc.name} and
+// ${pvc.namespace} variables in the template.
+func resolveSecretTemplate(template string, pv *corev1.PersistentVolume) (string, error) {
+	var err error
+	resolved := os.Expand(template, func(variable string) string {
+		switch variable {
+		case "pv.name":
+			return pv.Name
+		case "pvc.name", "pvc.namespace":
+			if pv.Spec.ClaimRef == nil {
+				err = fmt.Errorf("pv %q is not bound to a pvc, can not resolve ${%s}", pv.Name, variable)
+
+				return ""
+			}
+			if variable == "pvc.name" {
+				return pv.Spec.ClaimRef.Name
+			}
+
+			return pv.Spec.ClaimRef.Namespace
+		}
+		err = fmt.Errorf("%w: unsupported variable ${%s}", errUnsupportedTemplate, variable)
+
+		return ""
+	})
+	if err != nil {
+		return "", err
+	}
+	if resolved == "" {
+		return "", fmt.Errorf("template %q resolves to an empty string", template)
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestGetPVSecretRef(t *testing.T) {
+	t.Parallel()
+	pv := newCSIPersistentVolume("pv-1", "handle-1")
+	assert.Nil(t, getPVSecretRef(pv))
+
+	publish := &corev1.SecretReference{Name: "publish", Namespace: "ns"}
+	pv.Spec.CSI.ControllerPublishSecretRef = publish
+	assert.Equal(t, publish, getPVSecretRef(pv))
+
+	expand := &corev1.SecretReference{Name: "expand", Namespace: "ns"}
+	pv.Spec.CSI.ControllerExpandSecretRef = expand
+	assert.Equal(t, expand, getPVSecretRef(pv))
+}
+
+func TestGetStorageClassSecretRef(t *testing.T) {
+	t.Parallel()
+	boundPV := newCSIPersistentVolume("pv-1", "handle-1")
+	boundPV.Spec.ClaimRef = &corev1.ObjectReference{Name: "pvc-1", Namespace: "app"}
+	unboundPV := newCSIPersistentVolume("pv-2", "handle-2")
+
+	tests := []struct {
+		name       string
+		parameters map[string]string
+		pv         *corev1.PersistentVolume
+		want       *corev1.SecretReference
+		wantErr    bool
+	}{
+		{
+			name:       "no secret",
+			parameters: map[string]string{"pool": "replicapool"},
+			pv:         boundPV,
+		},
+		{
+			name: "expand secret is preferred",
+			parameters: map[string]string{
+				"csi.storage.k8s.io/provisioner-secret-name":             "provisioner",
+				"csi.storage.k8s.io/provisioner-secret-namespace":        "ns",
+				"csi.storage.k8s.io/controller-expand-secret-name":       "expand",
+				"csi.storage.k8s.io/controller-expand-secret-namespace":  "ns",
+				"csi.storage.k8s.io/controller-publish-secret-name":      "publish",
+				"csi.storage.k8s.io/controller-publish-secret-namespace": "ns",
+			},
+			pv:   boundPV,
+			want: &corev1.SecretReference{Name: "expand", Namespace: "ns"},
+		},
+		{
+			name: "provisioner secret",
+			parameters: map[string]string{
+				"csi.storage.k8s.io/provisioner-secret-name":      "provisioner",
+				"csi.storage.k8s.io/provisioner-secret-namespace": "ns",
+			},
+			pv:   boundPV,
+			want: &corev1.SecretReference{Name: "provisioner", Namespace: "ns"},
+		},
+		{
+			name: "templates",
+			parameters: map[string]string{
+				"csi.storage.k8s.io/controller-expand-secret-name":      "${pvc.name}-${pv.name}",
+				"csi.storage.k8s.io/controller-expand-secret-namespace": "${pvc.namespace}",
+			},
+			pv:   boundPV,
+			want: &corev1.SecretReference{Name: "pvc-1-pv-1", Namespace: "app"},
+		},
+		{
+			name: "pvc template of unbound pv",
+			parameters: map[string]string{
+				"csi.storage.k8s.io/controller-expand-secret-name":      "secret",
+				"csi.storage.k8s.io/controller-expand-secret-namespace": "${pvc.namespace}",
+			},
+			pv:      unboundPV,
+			wantErr: true,
+		},
+		{
+			name: "unsupported template",
+			parameters: map[string]string{
+				"csi.storage.k8s.io/controller-expand-secret-name":      "${pvc.annotations['secret']}",
+				"csi.storage.k8s.io/controller-expand-secret-namespace": "ns",
+			},
+			pv: boundPV,
+		},
+		{
+			name: "unsupported template falls back to the next secret",
+			parameters: map[string]string{
+				"csi.storage.k8s.io/controller-expand-secret-name":      "expand",
+				"csi.storage.k8s.io/controller-expand-secret-namespace": "${pvc.annotations['namespace']}",
+				"csi.storage.k8s.io/provisioner-secret-name":            "provisioner",
+				"csi.storage.k8s.io/provisioner-secret-namespace":       "ns",
+			},
+			pv:   boundPV,
+			want: &corev1.SecretReference{Name: "provisioner", Namespace: "ns"},
+		},
+		{
+			name: "namespace missing",
+			parameters: map[string]string{
+				"csi.storage.k8s.io/controller-expand-secret-name": "expand",
+			},
+			pv:      boundPV,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := getStorageClassSecretRef(newtt.parameters, newtt.pv)
+			if newtt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, newtt.want, got)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"context"
+
+	extensions "github.com/csi-addons/kubernetes-csi-addons/extensions/v1alpha1"
+	kube "github.com/csi-addons/kubernetes-csi-addons/internal/kubernetes"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// EncryptionKeyRotationServer struct of sidecar with supported methods of
+// proto encryption key rotation server spec and also containing the
+// encryption key rotation node client to csi driver.
+type EncryptionKeyRotationServer struct {
+	proto.UnimplementedEncryptionKeyRotationServer
+	nodeClient  extensions.EncryptionKeyRotationNodeClient
+	kubeCache   *kube.Cache
+	stagingPath string
+	podsPath    string
+	staging     *stagingLayoutDetector
+}
+
+// NewEncryptionKeyRotationServer creates a new EncryptionKeyRotationServer
+// which handles the proto.EncryptionKeyRotation Service requests.
+func NewEncryptionKeyRotationServer(c *grpc.ClientConn, kc *kube.Cache, sp, pp string) *EncryptionKeyRotationServer {
+	return &EncryptionKeyRotationServer{
+		nodeClient:  extensions.NewEncryptionKeyRotationNodeClient(c),
+		kubeCache:   kc,
+		stagingPath: sp,
+		podsPath:    pp,
+		staging:     &stagingLayoutDetector{stagingPath: sp},
+	}
+}
+
+// RegisterService registers service with the server.
+func (ks *EncryptionKeyRotationServer) RegisterService(server grpc.ServiceRegistrar) {
+	proto.RegisterEncryptionKeyRotationServer(server, ks)
+}
+
+// NodeEncryptionKeyRotate fetches required information from kubernetes
+// cluster and calls the NodeEncryptionKeyRotate procedure of the driver.
+func (ks *EncryptionKeyRotationServer) NodeEncryptionKeyRotate(
+	ctx context.Context,
+	req *proto.EncryptionKeyRotationRequest) (*proto.EncryptionKeyRotationResponse, error) {
+
+	pvName := req.GetPvName()
+	klog.Info(pvName)
+
+	pv, err := ks.kubeCache.GetPersistentVolume(ctx, pvName)
+	if err != nil {
+		klog.Errorf("Failed to get pv: %v", err)
+		return nil, status.Errorf(codes.InvalidArgument, "failed to get pv %q", pvName)
+	}
+
+	if pv.Spec.CSI == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "pv %q is not a CSI volume", pvName)
+	}
+
+	csiReq, err := ks.newNodeEncryptionKeyRotateRequest(pv)
+	if err != nil {
+		return nil, err
+	}
+
+	if pv.Spec.CSI.NodeStageSecretRef != nil {
+		// Get the secrets from the k8s cluster
+		csiReq.Secrets, err = ks.kubeCache.GetSecret(ctx, pv.Spec.CSI.NodeStageSecretRef.Name, pv.Spec.CSI.NodeStageSecretRef.Namespace)
+		if err != nil {
+			klog.Errorf("Failed to get secret: %v", err)
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	_, err = ks.nodeClient.NodeEncryptionKeyRotate(ctx, csiReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.EncryptionKeyRotationResponse{}, nil
+}
+
+// newNodeEncryptionKeyRotateRequest returns the request for the driver, with
+// the paths where the volume is staged and published on this node. An error
+// is returned when the volume is neither staged nor published.
+func (ks *EncryptionKeyRotationServer) newNodeEncryptionKeyRotateRequest(
+	pv *corev1.PersistentVolume) (*extensions.NodeEncryptionKeyRotateRequest, error) {
+	csiReq := &extensions.NodeEncryptionKeyRotateRequest{
+		VolumeId:   pv.Spec.CSI.VolumeHandle,
+		VolumePath: findPublishPath(ks.podsPath, ks.stagingPath, pv),
+		Parameters: pv.Spec.CSI.VolumeAttributes,
+	}
+	if !isBlockVolume(pv) {
+		if stagingPath, staged := ks.staging.getStagingTargetPath(pv); staged {
+			csiReq.StagingTargetPath = stagingPath
+		}
+	}
+	if csiReq.VolumePath == "" && csiReq.StagingTargetPath == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "pv %q is not staged or published on this node", pv.Name)
+	}
+	klog.V(4).Infof("rotating encryption key of pv %q with volume path %q and staging path %q",
+		pv.Name, csiReq.VolumePath, csiReq.StagingTargetPath)
+
+	return csiReq, nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewNodeEncryptionKeyRotateRequest(t *testing.T) {
+	t.Parallel()
+	podsPath := t.TempDir()
+	ks := &EncryptionKeyRotationServer{
+		stagingPath: t.TempDir(),
+		podsPath:    podsPath,
+	}
+	ks.staging = &stagingLayoutDetector{stagingPath: ks.stagingPath}
+
+	publishedPV := newCSIPersistentVolume("pv-published", "handle-published")
+	stagedPV := newCSIPersistentVolume("pv-staged", "handle-staged")
+	missingPV := newCSIPersistentVolume("pv-missing", "handle-missing")
+
+	publishPath := filepath.Join(podsPath, "pod-uid", "volumes", "kubernetes.io~csi", publishedPV.Name, "mount")
+	assert.NoError(t, os.MkdirAll(publishPath, 0o750))
+	stagingPath := ks.staging.getPath(stagingLayoutHashed, stagedPV)
+	assert.NoError(t, os.MkdirAll(stagingPath, 0o750))
+
+	req, err := ks.newNodeEncryptionKeyRotateRequest(publishedPV)
+	assert.NoError(t, err)
+	assert.Equal(t, "handle-published", req.GetVolumeId())
+	assert.Equal(t, publishPath, req.GetVolumePath())
+	assert.Empty(t, req.GetStagingTargetPath())
+
+	// a volume that is only staged can be rotated too.
+	req, err = ks.newNodeEncryptionKeyRotateRequest(stagedPV)
+	assert.NoError(t, err)
+	assert.Empty(t, req.GetVolumePath())
+	assert.Equal(t, stagingPath, req.GetStagingTargetPath())
+
+	_, err = ks.newNodeEncryptionKeyRotateRequest(missingPV)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
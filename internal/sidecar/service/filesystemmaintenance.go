@@ -0,0 +1,143 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"context"
+
+	extensions "github.com/csi-addons/kubernetes-csi-addons/extensions/v1alpha1"
+	kube "github.com/csi-addons/kubernetes-csi-addons/internal/kubernetes"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// FilesystemMaintenanceServer struct of sidecar with supported methods of
+// proto filesystem maintenance server spec and also containing the
+// filesystem maintenance node client to csi driver.
+type FilesystemMaintenanceServer struct {
+	proto.UnimplementedFilesystemMaintenanceServer
+	nodeClient  extensions.FilesystemMaintenanceNodeClient
+	kubeCache   *kube.Cache
+	stagingPath string
+	podsPath    string
+	staging     *stagingLayoutDetector
+}
+
+// NewFilesystemMaintenanceServer creates a new FilesystemMaintenanceServer
+// which handles the proto.FilesystemMaintenance Service requests.
+func NewFilesystemMaintenanceServer(c *grpc.ClientConn, kc *kube.Cache, sp, pp string) *FilesystemMaintenanceServer {
+	return &FilesystemMaintenanceServer{
+		nodeClient:  extensions.NewFilesystemMaintenanceNodeClient(c),
+		kubeCache:   kc,
+		stagingPath: sp,
+		podsPath:    pp,
+		staging:     &stagingLayoutDetector{stagingPath: sp},
+	}
+}
+
+// RegisterService registers service with the server.
+func (fs *FilesystemMaintenanceServer) RegisterService(server grpc.ServiceRegistrar) {
+	proto.RegisterFilesystemMaintenanceServer(server, fs)
+}
+
+// NodeFilesystemMaintenance fetches required information from kubernetes
+// cluster and calls the NodeFilesystemMaintenance procedure of the driver.
+func (fs *FilesystemMaintenanceServer) NodeFilesystemMaintenance(
+	ctx context.Context,
+	req *proto.FilesystemMaintenanceRequest) (*proto.FilesystemMaintenanceResponse, error) {
+
+	pvName := req.GetPvName()
+	klog.Info(pvName)
+
+	pv, err := fs.kubeCache.GetPersistentVolume(ctx, pvName)
+	if err != nil {
+		klog.Errorf("Failed to get pv: %v", err)
+		return nil, status.Errorf(codes.InvalidArgument, "failed to get pv %q", pvName)
+	}
+
+	if pv.Spec.CSI == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "pv %q is not a CSI volume", pvName)
+	}
+
+	csiReq, err := fs.newNodeFilesystemMaintenanceRequest(pv, req.GetOperation())
+	if err != nil {
+		return nil, err
+	}
+
+	if pv.Spec.CSI.NodeStageSecretRef != nil {
+		// Get the secrets from the k8s cluster
+		csiReq.Secrets, err = fs.kubeCache.GetSecret(ctx, pv.Spec.CSI.NodeStageSecretRef.Name, pv.Spec.CSI.NodeStageSecretRef.Namespace)
+		if err != nil {
+			klog.Errorf("Failed to get secret: %v", err)
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	res, err := fs.nodeClient.NodeFilesystemMaintenance(ctx, csiReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.FilesystemMaintenanceResponse{
+		ErrorsFound: res.GetErrorsFound(),
+		Message:     res.GetMessage(),
+	}, nil
+}
+
+// newNodeFilesystemMaintenanceRequest returns the request for the driver,
+// with the paths where the volume is staged and published on this node. An
+// error is returned for block volumes, unknown operations and when the
+// volume is neither staged nor published.
+func (fs *FilesystemMaintenanceServer) newNodeFilesystemMaintenanceRequest(
+	pv *corev1.PersistentVolume,
+	op proto.FilesystemMaintenanceRequest_Operation) (*extensions.NodeFilesystemMaintenanceRequest, error) {
+	var csiOp extensions.NodeFilesystemMaintenanceRequest_Operation
+	switch op {
+	case proto.FilesystemMaintenanceRequest_DEFRAGMENT:
+		csiOp = extensions.NodeFilesystemMaintenanceRequest_DEFRAGMENT
+	case proto.FilesystemMaintenanceRequest_CHECK:
+		csiOp = extensions.NodeFilesystemMaintenanceRequest_CHECK
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown filesystem maintenance operation %q", op)
+	}
+
+	if isBlockVolume(pv) {
+		return nil, status.Errorf(codes.InvalidArgument, "pv %q is a block volume without filesystem", pv.Name)
+	}
+
+	csiReq := &extensions.NodeFilesystemMaintenanceRequest{
+		VolumeId:   pv.Spec.CSI.VolumeHandle,
+		VolumePath: findPublishPath(fs.podsPath, fs.stagingPath, pv),
+		FsType:     pv.Spec.CSI.FSType,
+		Operation:  csiOp,
+		Parameters: pv.Spec.CSI.VolumeAttributes,
+	}
+	if stagingPath, staged := fs.staging.getStagingTargetPath(pv); staged {
+		csiReq.StagingTargetPath = stagingPath
+	}
+	if csiReq.VolumePath == "" && csiReq.StagingTargetPath == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "pv %q is not staged or published on this node", pv.Name)
+	}
+	klog.V(4).Infof("running %s on the filesystem of pv %q with volume path %q and staging path %q",
+		op, pv.Name, csiReq.VolumePath, csiReq.StagingTargetPath)
+
+	return csiReq, nil
+}
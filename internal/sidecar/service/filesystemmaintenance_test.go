@@ -0,0 +1,76 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	extensions "github.com/csi-addons/kubernetes-csi-addons/extensions/v1alpha1"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestNewNodeFilesystemMaintenanceRequest(t *testing.T) {
+	t.Parallel()
+	podsPath := t.TempDir()
+	fs := &FilesystemMaintenanceServer{
+		stagingPath: t.TempDir(),
+		podsPath:    podsPath,
+	}
+	fs.staging = &stagingLayoutDetector{stagingPath: fs.stagingPath}
+
+	publishedPV := newCSIPersistentVolume("pv-published", "handle-published")
+	publishedPV.Spec.CSI.FSType = "xfs"
+	stagedPV := newCSIPersistentVolume("pv-staged", "handle-staged")
+	missingPV := newCSIPersistentVolume("pv-missing", "handle-missing")
+	blockPV := newCSIPersistentVolume("pv-block", "handle-block")
+	blockMode := corev1.PersistentVolumeBlock
+	blockPV.Spec.VolumeMode = &blockMode
+
+	publishPath := filepath.Join(podsPath, "pod-uid", "volumes", "kubernetes.io~csi", publishedPV.Name, "mount")
+	assert.NoError(t, os.MkdirAll(publishPath, 0o750))
+	stagingPath := fs.staging.getPath(stagingLayoutHashed, stagedPV)
+	assert.NoError(t, os.MkdirAll(stagingPath, 0o750))
+
+	req, err := fs.newNodeFilesystemMaintenanceRequest(publishedPV, proto.FilesystemMaintenanceRequest_DEFRAGMENT)
+	assert.NoError(t, err)
+	assert.Equal(t, "handle-published", req.GetVolumeId())
+	assert.Equal(t, publishPath, req.GetVolumePath())
+	assert.Empty(t, req.GetStagingTargetPath())
+	assert.Equal(t, "xfs", req.GetFsType())
+	assert.Equal(t, extensions.NodeFilesystemMaintenanceRequest_DEFRAGMENT, req.GetOperation())
+
+	req, err = fs.newNodeFilesystemMaintenanceRequest(stagedPV, proto.FilesystemMaintenanceRequest_CHECK)
+	assert.NoError(t, err)
+	assert.Empty(t, req.GetVolumePath())
+	assert.Equal(t, stagingPath, req.GetStagingTargetPath())
+	assert.Equal(t, extensions.NodeFilesystemMaintenanceRequest_CHECK, req.GetOperation())
+
+	_, err = fs.newNodeFilesystemMaintenanceRequest(publishedPV, proto.FilesystemMaintenanceRequest_UNKNOWN)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = fs.newNodeFilesystemMaintenanceRequest(blockPV, proto.FilesystemMaintenanceRequest_CHECK)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = fs.newNodeFilesystemMaintenanceRequest(missingPV, proto.FilesystemMaintenanceRequest_CHECK)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
@@ -28,13 +28,19 @@ import (
 type IdentityServer struct {
 	*identity.UnimplementedIdentityServer
 	identityClient identity.IdentityClient
+	// sidecarCapabilities are the capabilities of operations that the
+	// sidecar provides itself, they are added to the capabilities of the
+	// driver.
+	sidecarCapabilities []*identity.Capability
 }
 
 // NewIdentityServer creates a new IdentityServer which handles the Identity
-// Service requests from the CSI-Addons specification.
-func NewIdentityServer(client *grpc.ClientConn) *IdentityServer {
+// Service requests from the CSI-Addons specification. The sidecarCaps are
+// advertised in addition to the capabilities of the driver.
+func NewIdentityServer(client *grpc.ClientConn, sidecarCaps ...*identity.Capability) *IdentityServer {
 	return &IdentityServer{
-		identityClient: identity.NewIdentityClient(client),
+		identityClient:      identity.NewIdentityClient(client),
+		sidecarCapabilities: sidecarCaps,
 	}
 }
 
@@ -49,11 +55,21 @@ func (is *IdentityServer) GetIdentity(
 	return is.identityClient.GetIdentity(ctx, req)
 }
 
-// GetCapabilities returns available capabilities from the driver.
+// GetCapabilities returns available capabilities from the driver, and the
+// capabilities of the sidecar.
 func (is *IdentityServer) GetCapabilities(
 	ctx context.Context,
 	req *identity.GetCapabilitiesRequest) (*identity.GetCapabilitiesResponse, error) {
-	return is.identityClient.GetCapabilities(ctx, req)
+	res, err := is.identityClient.GetCapabilities(ctx, req)
+	if err != nil || len(is.sidecarCapabilities) == 0 {
+		return res, err
+	}
+
+	caps := make([]*identity.Capability, 0, len(res.GetCapabilities())+len(is.sidecarCapabilities))
+	caps = append(caps, res.GetCapabilities()...)
+	caps = append(caps, is.sidecarCapabilities...)
+
+	return &identity.GetCapabilitiesResponse{Capabilities: caps}, nil
 }
 
 // Probe is called by the CO plugin to validate that the CSI-Addons Node is
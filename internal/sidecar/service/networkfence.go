@@ -17,7 +17,9 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 
+	extensions "github.com/csi-addons/kubernetes-csi-addons/extensions/v1alpha1"
 	kube "github.com/csi-addons/kubernetes-csi-addons/internal/kubernetes"
 	"github.com/csi-addons/kubernetes-csi-addons/internal/proto"
 
@@ -25,7 +27,6 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 )
 
@@ -34,15 +35,15 @@ import (
 type NetworkFenceServer struct {
 	proto.UnimplementedNetworkFenceServer
 	controllerClient fence.FenceControllerClient
-	kubeClient       *kubernetes.Clientset
+	kubeCache        *kube.Cache
 }
 
 // NewNetworkFenceServer creates a new NetworkFenceServer which handles the proto.NetworkFence
 // Service requests.
-func NewNetworkFenceServer(c *grpc.ClientConn, kc *kubernetes.Clientset) *NetworkFenceServer {
+func NewNetworkFenceServer(c *grpc.ClientConn, kc *kube.Cache) *NetworkFenceServer {
 	return &NetworkFenceServer{
 		controllerClient: fence.NewFenceControllerClient(c),
-		kubeClient:       kc,
+		kubeCache:        kc,
 	}
 }
 
@@ -57,15 +58,21 @@ func (ns *NetworkFenceServer) FenceClusterNetwork(
 	ctx context.Context,
 	req *proto.NetworkFenceRequest) (*proto.NetworkFenceResponse, error) {
 	// Get the secrets from the k8s cluster
-	data, err := kube.GetSecret(ctx, ns.kubeClient, req.GetSecretName(), req.GetSecretNamespace())
+	data, err := ns.kubeCache.GetSecret(ctx, req.GetSecretName(), req.GetSecretNamespace())
 	if err != nil {
 		klog.Errorf("Failed to get secret %s in namespace %s: %v", req.GetSecretName(), req.GetSecretNamespace(), err)
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	parameters, err := getParameters(req)
+	if err != nil {
+		klog.Errorf("Failed to get parameters: %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
 	cidr := req.GetCidrs()
 	fenceRequest := fence.FenceClusterNetworkRequest{
-		Parameters: req.GetParameters(),
+		Parameters: parameters,
 		Cidrs:      getCIDRS(cidr),
 		Secrets:    data,
 	}
@@ -85,15 +92,21 @@ func (ns *NetworkFenceServer) UnFenceClusterNetwork(
 	ctx context.Context,
 	req *proto.NetworkFenceRequest) (*proto.NetworkFenceResponse, error) {
 	// Get the secrets from the k8s cluster
-	data, err := kube.GetSecret(ctx, ns.kubeClient, req.GetSecretName(), req.GetSecretNamespace())
+	data, err := ns.kubeCache.GetSecret(ctx, req.GetSecretName(), req.GetSecretNamespace())
 	if err != nil {
 		klog.Errorf("Failed to get secret %s in namespace %s: %v", req.GetSecretName(), req.GetSecretNamespace(), err)
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	parameters, err := getParameters(req)
+	if err != nil {
+		klog.Errorf("Failed to get parameters: %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
 	cidr := req.GetCidrs()
 	fenceRequest := fence.UnfenceClusterNetworkRequest{
-		Parameters: req.GetParameters(),
+		Parameters: parameters,
 		Cidrs:      getCIDRS(cidr),
 		Secrets:    data,
 	}
@@ -115,3 +128,30 @@ func getCIDRS(cidr []string) []*fence.CIDR {
 	}
 	return cidrs
 }
+
+// getParameters returns the parameters of the request. The fence targets
+// are added to the parameters under the extensions.FenceTargetsParameter
+// key, the controller only sends targets to drivers that advertise the
+// FENCE_TARGETS capability.
+func getParameters(req *proto.NetworkFenceRequest) (map[string]string, error) {
+	if len(req.GetTargets()) == 0 {
+		return req.GetParameters(), nil
+	}
+
+	targets := make([]extensions.FenceTarget, 0, len(req.GetTargets()))
+	for _, t := range req.GetTargets() {
+		targets = append(targets, extensions.FenceTarget{Type: t.GetType().String(), Value: t.GetValue()})
+	}
+	data, err := json.Marshal(targets)
+	if err != nil {
+		return nil, err
+	}
+
+	parameters := make(map[string]string, len(req.GetParameters())+1)
+	for k, v := range req.GetParameters() {
+		parameters[k] = v
+	}
+	parameters[extensions.FenceTargetsParameter] = string(data)
+
+	return parameters, nil
+}
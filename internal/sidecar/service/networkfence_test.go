@@ -0,0 +1,113 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	extensions "github.com/csi-addons/kubernetes-csi-addons/extensions/v1alpha1"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetParameters(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		req         *proto.NetworkFenceRequest
+		want        map[string]string
+		wantTargets []extensions.FenceTarget
+	}{
+		{
+			name: "cidrs only",
+			req: &proto.NetworkFenceRequest{
+				Parameters: map[string]string{"clusterID": "cluster-1"},
+				Cidrs:      []string{"10.90.89.66/32"},
+			},
+			want: map[string]string{"clusterID": "cluster-1"},
+		},
+		{
+			name: "targets",
+			req: &proto.NetworkFenceRequest{
+				Parameters: map[string]string{"clusterID": "cluster-1"},
+				Targets: []*proto.FenceTarget{
+					{Type: proto.FenceTarget_ISCSI_INITIATOR, Value: "iqn.2001-04.com.example:node1"},
+					{Type: proto.FenceTarget_CLIENT_ID, Value: "client.1234"},
+				},
+			},
+			want: map[string]string{"clusterID": "cluster-1"},
+			wantTargets: []extensions.FenceTarget{
+				{Type: "ISCSI_INITIATOR", Value: "iqn.2001-04.com.example:node1"},
+				{Type: "CLIENT_ID", Value: "client.1234"},
+			},
+		},
+		{
+			name: "targets without parameters",
+			req: &proto.NetworkFenceRequest{
+				Targets: []*proto.FenceTarget{
+					{Type: proto.FenceTarget_NODE_ID, Value: "node-1"},
+				},
+			},
+			want: map[string]string{},
+			wantTargets: []extensions.FenceTarget{
+				{Type: "NODE_ID", Value: "node-1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			params, err := getParameters(newtt.req)
+			require.NoError(t, err)
+
+			data, ok := params[extensions.FenceTargetsParameter]
+			assert.Equal(t, newtt.wantTargets != nil, ok)
+			if ok {
+				targets := []extensions.FenceTarget{}
+				require.NoError(t, json.Unmarshal([]byte(data), &targets))
+				assert.Equal(t, newtt.wantTargets, targets)
+				delete(params, extensions.FenceTargetsParameter)
+			}
+			assert.Equal(t, newtt.want, params)
+		})
+	}
+}
+
+func TestGetParametersDoesNotModifyRequest(t *testing.T) {
+	t.Parallel()
+	req := &proto.NetworkFenceRequest{
+		Parameters: map[string]string{"clusterID": "cluster-1"},
+		Targets:    []*proto.FenceTarget{{Type: proto.FenceTarget_NODE_ID, Value: "node-1"}},
+	}
+
+	_, err := getParameters(req)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"clusterID": "cluster-1"}, req.GetParameters())
+}
+
+func TestGetCIDRS(t *testing.T) {
+	t.Parallel()
+	cidrs := getCIDRS([]string{"10.90.89.66/32", "11.67.12.42/24"})
+	require.Len(t, cidrs, 2)
+	assert.Equal(t, "10.90.89.66/32", cidrs[0].GetCidr())
+	assert.Equal(t, "11.67.12.42/24", cidrs[1].GetCidr())
+
+	assert.Empty(t, getCIDRS(nil))
+}
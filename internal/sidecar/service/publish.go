@@ -0,0 +1,66 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"os"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// findPublishPath returns the path where the kubelet has published the volume
+// for a Pod on this node, or an empty string if the volume is not published.
+// Drivers that do not stage volumes only have this path. When the volume is
+// published for multiple Pods, the path of any of them is returned.
+//
+// Filesystem volumes are published in
+// <podsPath>/<pod uid>/volumes/kubernetes.io~csi/<pv name>/mount, and block
+// volumes in <stagingPath>/volumeDevices/publish/<pv name>/<pod uid>.
+func findPublishPath(podsPath, stagingPath string, pv *corev1.PersistentVolume) string {
+	var pattern string
+	if isBlockVolume(pv) {
+		pattern = filepath.Join(stagingPath, "volumeDevices", "publish", pv.Name, "*")
+	} else {
+		if podsPath == "" {
+			return ""
+		}
+		pattern = filepath.Join(podsPath, "*", "volumes", "kubernetes.io~csi", pv.Name, "mount")
+	}
+
+	// the pattern only contains the pv name, which can not contain
+	// special characters, Glob does not fail then.
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		klog.Errorf("failed to find publish path of pv %q: %v", pv.Name, err)
+
+		return ""
+	}
+
+	for _, match := range matches {
+		if _, err := os.Stat(match); err == nil {
+			return match
+		}
+	}
+
+	return ""
+}
+
+// isBlockVolume returns true if the PersistentVolume is a raw block volume.
+func isBlockVolume(pv *corev1.PersistentVolume) bool {
+	return pv.Spec.VolumeMode != nil && *pv.Spec.VolumeMode == corev1.PersistentVolumeBlock
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestFindPublishPath(t *testing.T) {
+	t.Parallel()
+	podsPath := t.TempDir()
+	stagingPath := t.TempDir()
+
+	fsPV := newCSIPersistentVolume("pv-fs", "handle-fs")
+	blockPV := newCSIPersistentVolume("pv-block", "handle-block")
+	blockMode := corev1.PersistentVolumeBlock
+	blockPV.Spec.VolumeMode = &blockMode
+	missingPV := newCSIPersistentVolume("pv-missing", "handle-missing")
+
+	fsPath := filepath.Join(podsPath, "pod-uid-1", "volumes", "kubernetes.io~csi", "pv-fs", "mount")
+	assert.NoError(t, os.MkdirAll(fsPath, 0o750))
+	blockPath := filepath.Join(stagingPath, "volumeDevices", "publish", "pv-block", "pod-uid-2")
+	assert.NoError(t, os.MkdirAll(blockPath, 0o750))
+
+	assert.Equal(t, fsPath, findPublishPath(podsPath, stagingPath, fsPV))
+	assert.Equal(t, blockPath, findPublishPath(podsPath, stagingPath, blockPV))
+	assert.Empty(t, findPublishPath(podsPath, stagingPath, missingPV))
+	// the publish path of filesystem volumes is not searched without a
+	// pods path.
+	assert.Empty(t, findPublishPath("", stagingPath, fsPV))
+}
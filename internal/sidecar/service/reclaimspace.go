@@ -17,10 +17,8 @@ package service
 
 import (
 	"context"
-	"crypto/sha256"
-	"fmt"
+	"os"
 	"path/filepath"
-	"strconv"
 
 	kube "github.com/csi-addons/kubernetes-csi-addons/internal/kubernetes"
 	"github.com/csi-addons/kubernetes-csi-addons/internal/proto"
@@ -32,8 +30,6 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 )
 
@@ -44,18 +40,22 @@ type ReclaimSpaceServer struct {
 	proto.UnimplementedReclaimSpaceServer
 	controllerClient csiReclaimSpace.ReclaimSpaceControllerClient
 	nodeClient       csiReclaimSpace.ReclaimSpaceNodeClient
-	kubeClient       *kubernetes.Clientset
+	kubeCache        *kube.Cache
 	stagingPath      string
+	podsPath         string
+	staging          *stagingLayoutDetector
 }
 
 // NewReclaimSpaceServer creates a new ReclaimSpaceServer which handles the proto.ReclaimSpace
 // Service requests.
-func NewReclaimSpaceServer(c *grpc.ClientConn, kc *kubernetes.Clientset, sp string) *ReclaimSpaceServer {
+func NewReclaimSpaceServer(c *grpc.ClientConn, kc *kube.Cache, sp, pp string) *ReclaimSpaceServer {
 	return &ReclaimSpaceServer{
 		controllerClient: csiReclaimSpace.NewReclaimSpaceControllerClient(c),
 		nodeClient:       csiReclaimSpace.NewReclaimSpaceNodeClient(c),
-		kubeClient:       kc,
+		kubeCache:        kc,
 		stagingPath:      sp,
+		podsPath:         pp,
+		staging:          &stagingLayoutDetector{stagingPath: sp},
 	}
 }
 
@@ -73,7 +73,7 @@ func (rs *ReclaimSpaceServer) ControllerReclaimSpace(
 	pvName := req.GetPvName()
 	klog.Info(pvName)
 
-	pv, err := rs.kubeClient.CoreV1().PersistentVolumes().Get(ctx, pvName, metav1.GetOptions{})
+	pv, err := rs.kubeCache.GetPersistentVolume(ctx, pvName)
 	if err != nil {
 		klog.Errorf("Failed to get pv: %v", err)
 		return nil, status.Errorf(codes.InvalidArgument, "failed to get pv %q", pvName)
@@ -91,10 +91,14 @@ func (rs *ReclaimSpaceServer) ControllerReclaimSpace(
 		Parameters: volAttributes,
 	}
 
-	// FIXME: use ControllerPublishSecret instead, but it is not set in the PV
-	if pv.Spec.CSI.NodeStageSecretRef != nil {
+	secretRef, err := rs.getControllerSecretRef(ctx, pv)
+	if err != nil {
+		klog.Errorf("Failed to get secret reference: %v", err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if secretRef != nil {
 		// Get the secrets from the k8s cluster
-		csiReq.Secrets, err = kube.GetSecret(ctx, rs.kubeClient, pv.Spec.CSI.NodeStageSecretRef.Name, pv.Spec.CSI.NodeStageSecretRef.Namespace)
+		csiReq.Secrets, err = rs.kubeCache.GetSecret(ctx, secretRef.Name, secretRef.Namespace)
 		if err != nil {
 			klog.Errorf("Failed to get secret: %v", err)
 			return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -128,7 +132,7 @@ func (rs *ReclaimSpaceServer) NodeReclaimSpace(
 	pvName := req.GetPvName()
 	klog.Info(pvName)
 
-	pv, err := rs.kubeClient.CoreV1().PersistentVolumes().Get(ctx, pvName, metav1.GetOptions{})
+	pv, err := rs.kubeCache.GetPersistentVolume(ctx, pvName)
 	if err != nil {
 		klog.Errorf("Failed to get pv: %v", err)
 		return nil, status.Errorf(codes.InvalidArgument, "failed to get pv %q", pvName)
@@ -137,45 +141,17 @@ func (rs *ReclaimSpaceServer) NodeReclaimSpace(
 	if pv.Spec.CSI == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "pv %q is not a CSI volume", pvName)
 	}
-	volID := pv.Spec.CSI.VolumeHandle
-	csiMode, err := accessmodes.ToCSIAccessMode(pv.Spec.AccessModes, true)
+	csiReq, err := rs.newNodeReclaimSpaceRequest(pv)
 	if err != nil {
 		klog.Errorf("Failed to map access mode: %v", err)
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-
-	stPath, err := rs.getStagingTargetPath(pv)
-	if err != nil {
-		klog.Errorf("Failed to get staging target path: %v", err)
-		return nil, status.Error(codes.Internal, err.Error())
-	}
-
-	accessType := csi.VolumeCapability_Mount{
-		Mount: &csi.VolumeCapability_MountVolume{},
-	}
-
-	csiReq := &csiReclaimSpace.NodeReclaimSpaceRequest{
-		VolumeId:          volID,
-		VolumePath:        "",
-		StagingTargetPath: stPath,
-		VolumeCapability: &csi.VolumeCapability{
-			AccessMode: &csi.VolumeCapability_AccessMode{
-				Mode: csiMode,
-			},
-			AccessType: &accessType,
-		},
-	}
-
-	if *pv.Spec.VolumeMode == corev1.PersistentVolumeBlock {
-		csiReq.StagingTargetPath = filepath.Join(rs.stagingPath, "volumeDevices", "staging", pvName)
-		csiReq.VolumeCapability.AccessType = &csi.VolumeCapability_Block{
-			Block: &csi.VolumeCapability_BlockVolume{},
-		}
-	}
+	klog.V(4).Infof("reclaiming space of pv %q with volume path %q and staging path %q",
+		pvName, csiReq.VolumePath, csiReq.StagingTargetPath)
 
 	if pv.Spec.CSI.NodeStageSecretRef != nil {
 		// Get the secrets from the k8s cluster
-		csiReq.Secrets, err = kube.GetSecret(ctx, rs.kubeClient, pv.Spec.CSI.NodeStageSecretRef.Name, pv.Spec.CSI.NodeStageSecretRef.Namespace)
+		csiReq.Secrets, err = rs.kubeCache.GetSecret(ctx, pv.Spec.CSI.NodeStageSecretRef.Name, pv.Spec.CSI.NodeStageSecretRef.Namespace)
 		if err != nil {
 			klog.Errorf("Failed to get secret: %v", err)
 			return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -199,35 +175,53 @@ func (rs *ReclaimSpaceServer) NodeReclaimSpace(
 	return res, nil
 }
 
-// getStagingTargetPath returns the path where the volume is expected to be
-// mounted (or the block-device is attached/mapped). Different Kubernetes
-// version use different paths.
-func (rs *ReclaimSpaceServer) getStagingTargetPath(pv *corev1.PersistentVolume) (string, error) {
-	// Kubernetes 1.24+ uses a hash of the volume-id in the path name
-	unique := sha256.Sum256([]byte(pv.Spec.CSI.VolumeHandle))
-	targetPath := filepath.Join(rs.stagingPath, pv.Spec.CSI.Driver, fmt.Sprintf("%x", unique), "globalmount")
-
-	version, err := rs.kubeClient.Discovery().ServerVersion()
+// newNodeReclaimSpaceRequest returns the NodeReclaimSpace request for the
+// volume. The staging path is sent for a staged volume, as drivers have
+// always received it.
+func (rs *ReclaimSpaceServer) newNodeReclaimSpaceRequest(
+	pv *corev1.PersistentVolume) (*csiReclaimSpace.NodeReclaimSpaceRequest, error) {
+	csiMode, err := accessmodes.ToCSIAccessMode(pv.Spec.AccessModes, true)
 	if err != nil {
-		return "", fmt.Errorf("failed to detect Kubernetes version: %w", err)
+		return nil, err
 	}
 
-	major, err := strconv.Atoi(version.Major)
-	if err != nil {
-		return "", fmt.Errorf("failed to convert Kubernetes major version %q to int: %w", version.Major, err)
+	accessType := csi.VolumeCapability_Mount{
+		Mount: &csi.VolumeCapability_MountVolume{},
 	}
 
-	minor, err := strconv.Atoi(version.Minor)
-	if err != nil {
-		return "", fmt.Errorf("failed to convert Kubernetes minor version %q to int: %w", version.Minor, err)
+	csiReq := &csiReclaimSpace.NodeReclaimSpaceRequest{
+		VolumeId: pv.Spec.CSI.VolumeHandle,
+		VolumeCapability: &csi.VolumeCapability{
+			AccessMode: &csi.VolumeCapability_AccessMode{
+				Mode: csiMode,
+			},
+			AccessType: &accessType,
+		},
+	}
+
+	var staged bool
+	if isBlockVolume(pv) {
+		csiReq.StagingTargetPath = filepath.Join(rs.stagingPath, "volumeDevices", "staging", pv.Name)
+		_, err = os.Stat(csiReq.StagingTargetPath)
+		staged = err == nil
+		csiReq.VolumeCapability.AccessType = &csi.VolumeCapability_Block{
+			Block: &csi.VolumeCapability_BlockVolume{},
+		}
+	} else {
+		csiReq.StagingTargetPath, staged = rs.staging.getStagingTargetPath(pv)
+	}
+	if staged {
+		return csiReq, nil
 	}
 
-	// 'encode' major/minor in a single integer
-	legacyVersion := 1024 // Kubernetes 1.24 => 1 * 1000 + 24
-	if ((major * 1000) + minor) < (legacyVersion) {
-		// path in Kubernetes < 1.24
-		targetPath = filepath.Join(rs.stagingPath, "pv", pv.Name, "globalmount")
+	// drivers that do not stage volumes only have the publish path of a
+	// Pod, the staging path is not sent to them. When neither path is
+	// found, the staging path is sent, as the paths may not be visible to
+	// the side-car.
+	if publishPath := findPublishPath(rs.podsPath, rs.stagingPath, pv); publishPath != "" {
+		csiReq.VolumePath = publishPath
+		csiReq.StagingTargetPath = ""
 	}
 
-	return targetPath, nil
+	return csiReq, nil
 }
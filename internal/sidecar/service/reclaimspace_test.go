@@ -0,0 +1,74 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestNewNodeReclaimSpaceRequest(t *testing.T) {
+	t.Parallel()
+	podsPath := t.TempDir()
+	rs := &ReclaimSpaceServer{
+		stagingPath: t.TempDir(),
+		podsPath:    podsPath,
+	}
+	rs.staging = &stagingLayoutDetector{stagingPath: rs.stagingPath}
+
+	newPV := func(name string) *corev1.PersistentVolume {
+		pv := newCSIPersistentVolume(name, "handle-"+name)
+		pv.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+
+		return pv
+	}
+	stagedPV := newPV("pv-staged")
+	publishedPV := newPV("pv-published")
+	missingPV := newPV("pv-missing")
+
+	// the staged volume is published for a Pod too.
+	stagingPath := rs.staging.getPath(stagingLayoutHashed, stagedPV)
+	assert.NoError(t, os.MkdirAll(stagingPath, 0o750))
+	for _, pv := range []*corev1.PersistentVolume{stagedPV, publishedPV} {
+		publishPath := filepath.Join(podsPath, "pod-uid", "volumes", "kubernetes.io~csi", pv.Name, "mount")
+		assert.NoError(t, os.MkdirAll(publishPath, 0o750))
+	}
+
+	// a staged volume only gets the staging path, as before.
+	req, err := rs.newNodeReclaimSpaceRequest(stagedPV)
+	assert.NoError(t, err)
+	assert.Equal(t, "handle-pv-staged", req.GetVolumeId())
+	assert.Equal(t, stagingPath, req.GetStagingTargetPath())
+	assert.Empty(t, req.GetVolumePath())
+
+	// a volume of a driver that does not stage volumes gets the publish
+	// path.
+	req, err = rs.newNodeReclaimSpaceRequest(publishedPV)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(podsPath, "pod-uid", "volumes", "kubernetes.io~csi", publishedPV.Name, "mount"),
+		req.GetVolumePath())
+	assert.Empty(t, req.GetStagingTargetPath())
+
+	// the staging path is sent when neither path is found.
+	req, err = rs.newNodeReclaimSpaceRequest(missingPV)
+	assert.NoError(t, err)
+	assert.Empty(t, req.GetVolumePath())
+	assert.NotEmpty(t, req.GetStagingTargetPath())
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// stagingLayout is the layout of the staging paths that the kubelet uses for
+// the volumes.
+type stagingLayout int
+
+const (
+	// stagingLayoutHashed is used by Kubernetes 1.24+, the path contains
+	// the driver and a hash of the volume handle.
+	stagingLayoutHashed stagingLayout = iota
+	// stagingLayoutLegacy is used by Kubernetes < 1.24, the path contains
+	// the name of the PersistentVolume.
+	stagingLayoutLegacy
+)
+
+func (l stagingLayout) String() string {
+	if l == stagingLayoutLegacy {
+		return "legacy"
+	}
+
+	return "hashed"
+}
+
+// stagingLayoutDetector finds the staging path of a volume by probing the
+// filesystem for the paths of both layouts. The layout that was last found is
+// probed first, so that usually a single stat is needed. Probing each volume
+// handles volumes that were staged before the kubelet was upgraded.
+type stagingLayoutDetector struct {
+	stagingPath string
+
+	mu     sync.Mutex
+	layout stagingLayout
+}
+
+// getPath returns the staging path of the volume in the layout.
+func (d *stagingLayoutDetector) getPath(layout stagingLayout, pv *corev1.PersistentVolume) string {
+	if layout == stagingLayoutLegacy {
+		return filepath.Join(d.stagingPath, "pv", pv.Name, "globalmount")
+	}
+
+	unique := sha256.Sum256([]byte(pv.Spec.CSI.VolumeHandle))
+
+	return filepath.Join(d.stagingPath, pv.Spec.CSI.Driver, fmt.Sprintf("%x", unique), "globalmount")
+}
+
+// getStagingTargetPath returns the path where the volume is expected to be
+// mounted, and whether the volume was found there. When the volume is not
+// found in either layout, the path of the layout that was last found is
+// returned. The filesystem is probed without holding the mutex, as a stat on
+// a hanging mount would block the requests for all volumes.
+func (d *stagingLayoutDetector) getStagingTargetPath(pv *corev1.PersistentVolume) (string, bool) {
+	d.mu.Lock()
+	current := d.layout
+	d.mu.Unlock()
+
+	other := stagingLayoutLegacy
+	if current == stagingLayoutLegacy {
+		other = stagingLayoutHashed
+	}
+
+	for _, layout := range []stagingLayout{current, other} {
+		path := d.getPath(layout, pv)
+		if _, err := os.Stat(path); err == nil {
+			if layout != current {
+				d.setLayout(layout, pv)
+			}
+
+			return path, true
+		}
+	}
+
+	return d.getPath(current, pv), false
+}
+
+// setLayout sets the layout that was last found.
+func (d *stagingLayoutDetector) setLayout(layout stagingLayout, pv *corev1.PersistentVolume) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.layout != layout {
+		klog.Infof("detected %s staging layout for pv %q", layout, pv.Name)
+		d.layout = layout
+	}
+}
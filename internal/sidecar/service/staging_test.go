@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newCSIPersistentVolume(name, handle string) *corev1.PersistentVolume {
+	return &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: corev1.PersistentVolumeSpec{
+			PersistentVolumeSource: corev1.PersistentVolumeSource{
+				CSI: &corev1.CSIPersistentVolumeSource{
+					Driver:       "test.csi.io",
+					VolumeHandle: handle,
+				},
+			},
+		},
+	}
+}
+
+func TestGetStagingTargetPath(t *testing.T) {
+	t.Parallel()
+	d := &stagingLayoutDetector{stagingPath: t.TempDir()}
+	legacyPV := newCSIPersistentVolume("pv-legacy", "handle-legacy")
+	hashedPV := newCSIPersistentVolume("pv-hashed", "handle-hashed")
+	missingPV := newCSIPersistentVolume("pv-missing", "handle-missing")
+
+	assert.NoError(t, os.MkdirAll(d.getPath(stagingLayoutLegacy, legacyPV), 0o750))
+	assert.NoError(t, os.MkdirAll(d.getPath(stagingLayoutHashed, hashedPV), 0o750))
+
+	// a volume that is not staged uses the default layout.
+	path, staged := d.getStagingTargetPath(missingPV)
+	assert.Equal(t, d.getPath(stagingLayoutHashed, missingPV), path)
+	assert.False(t, staged)
+
+	// the legacy layout is detected, and used for the next volumes.
+	path, staged = d.getStagingTargetPath(legacyPV)
+	assert.Equal(t, d.getPath(stagingLayoutLegacy, legacyPV), path)
+	assert.True(t, staged)
+	assert.Equal(t, stagingLayoutLegacy, d.layout)
+	path, _ = d.getStagingTargetPath(missingPV)
+	assert.Equal(t, d.getPath(stagingLayoutLegacy, missingPV), path)
+
+	// volumes staged with the hashed layout are still found.
+	path, staged = d.getStagingTargetPath(hashedPV)
+	assert.Equal(t, d.getPath(stagingLayoutHashed, hashedPV), path)
+	assert.True(t, staged)
+	assert.Equal(t, stagingLayoutHashed, d.layout)
+}
+
+func TestGetStagingTargetPathConcurrent(t *testing.T) {
+	t.Parallel()
+	d := &stagingLayoutDetector{stagingPath: t.TempDir()}
+	legacyPV := newCSIPersistentVolume("pv-legacy", "handle-legacy")
+	hashedPV := newCSIPersistentVolume("pv-hashed", "handle-hashed")
+	assert.NoError(t, os.MkdirAll(d.getPath(stagingLayoutLegacy, legacyPV), 0o750))
+	assert.NoError(t, os.MkdirAll(d.getPath(stagingLayoutHashed, hashedPV), 0o750))
+
+	// volumes of both layouts are found while the detected layout changes.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		for _, pv := range []*corev1.PersistentVolume{legacyPV, hashedPV} {
+			wg.Add(1)
+			go func(pv *corev1.PersistentVolume) {
+				defer wg.Done()
+				_, staged := d.getStagingTargetPath(pv)
+				assert.True(t, staged)
+			}(pv)
+		}
+	}
+	wg.Wait()
+}
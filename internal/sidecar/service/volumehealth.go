@@ -0,0 +1,162 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"context"
+
+	kube "github.com/csi-addons/kubernetes-csi-addons/internal/kubernetes"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/proto"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// VolumeHealthServer struct of sidecar with supported methods of proto
+// volume health server spec and also containing the CSI node client to the
+// csi driver.
+type VolumeHealthServer struct {
+	proto.UnimplementedVolumeHealthServer
+	// nodeClient is the client of the CSI Node service of the driver, on
+	// its CSI endpoint. It is nil when the endpoint is not configured.
+	nodeClient  csi.NodeClient
+	kubeCache   *kube.Cache
+	stagingPath string
+	podsPath    string
+	staging     *stagingLayoutDetector
+}
+
+// NewVolumeHealthServer creates a new VolumeHealthServer which handles the
+// proto.VolumeHealth Service requests. The connection c is to the CSI
+// endpoint of the driver, and not to its CSI-Addons endpoint, as the
+// condition of volumes is reported by the CSI Node service. The health of
+// volumes is not checked when c is nil.
+func NewVolumeHealthServer(c *grpc.ClientConn, kc *kube.Cache, sp, pp string) *VolumeHealthServer {
+	vs := &VolumeHealthServer{
+		kubeCache:   kc,
+		stagingPath: sp,
+		podsPath:    pp,
+		staging:     &stagingLayoutDetector{stagingPath: sp},
+	}
+	if c != nil {
+		vs.nodeClient = csi.NewNodeClient(c)
+	}
+
+	return vs
+}
+
+// SupportsVolumeCondition returns true if the CSI Node service of the driver
+// has the GET_VOLUME_STATS and VOLUME_CONDITION capabilities, which are
+// needed to report the condition of volumes.
+func (vs *VolumeHealthServer) SupportsVolumeCondition(ctx context.Context) (bool, error) {
+	if vs.nodeClient == nil {
+		return false, nil
+	}
+
+	res, err := vs.nodeClient.NodeGetCapabilities(ctx, &csi.NodeGetCapabilitiesRequest{})
+	if err != nil {
+		return false, err
+	}
+
+	volumeStats, volumeCondition := false, false
+	for _, cap := range res.GetCapabilities() {
+		switch cap.GetRpc().GetType() {
+		case csi.NodeServiceCapability_RPC_GET_VOLUME_STATS:
+			volumeStats = true
+		case csi.NodeServiceCapability_RPC_VOLUME_CONDITION:
+			volumeCondition = true
+		}
+	}
+
+	return volumeStats && volumeCondition, nil
+}
+
+// RegisterService registers service with the server.
+func (vs *VolumeHealthServer) RegisterService(server grpc.ServiceRegistrar) {
+	proto.RegisterVolumeHealthServer(server, vs)
+}
+
+// NodeGetVolumeHealth fetches required information from kubernetes cluster
+// and calls the CSI NodeGetVolumeStats procedure of the driver, the
+// VolumeCondition of the response is returned.
+func (vs *VolumeHealthServer) NodeGetVolumeHealth(
+	ctx context.Context,
+	req *proto.VolumeHealthRequest) (*proto.VolumeHealthResponse, error) {
+	if vs.nodeClient == nil {
+		return nil, status.Error(codes.Unimplemented, "the CSI endpoint of the driver is not configured")
+	}
+
+	pvName := req.GetPvName()
+	klog.Info(pvName)
+
+	pv, err := vs.kubeCache.GetPersistentVolume(ctx, pvName)
+	if err != nil {
+		klog.Errorf("Failed to get pv: %v", err)
+		return nil, status.Errorf(codes.InvalidArgument, "failed to get pv %q", pvName)
+	}
+
+	if pv.Spec.CSI == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "pv %q is not a CSI volume", pvName)
+	}
+
+	return vs.getVolumeHealth(ctx, pv)
+}
+
+// getVolumeHealth calls NodeGetVolumeStats for the volume. The volume path is
+// required by the procedure, so the volume needs to be published on this
+// node.
+func (vs *VolumeHealthServer) getVolumeHealth(
+	ctx context.Context,
+	pv *corev1.PersistentVolume) (*proto.VolumeHealthResponse, error) {
+	csiReq := &csi.NodeGetVolumeStatsRequest{
+		VolumeId:   pv.Spec.CSI.VolumeHandle,
+		VolumePath: findPublishPath(vs.podsPath, vs.stagingPath, pv),
+	}
+	if csiReq.VolumePath == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "pv %q is not published on this node", pv.Name)
+	}
+	if !isBlockVolume(pv) {
+		if stagingPath, staged := vs.staging.getStagingTargetPath(pv); staged {
+			csiReq.StagingTargetPath = stagingPath
+		}
+	}
+	klog.V(4).Infof("getting health of pv %q with volume path %q and staging path %q",
+		pv.Name, csiReq.VolumePath, csiReq.StagingTargetPath)
+
+	csiRes, err := vs.nodeClient.NodeGetVolumeStats(ctx, csiReq)
+	if err != nil {
+		return nil, err
+	}
+	if csiRes == nil {
+		return nil, status.Error(codes.InvalidArgument, "nil value returned as the response of NodeGetVolumeStats")
+	}
+
+	// drivers that do not have the VOLUME_CONDITION node capability do
+	// not report the condition.
+	condition := csiRes.GetVolumeCondition()
+	if condition == nil {
+		return nil, status.Errorf(codes.Unimplemented, "driver did not report the condition of pv %q", pv.Name)
+	}
+
+	return &proto.VolumeHealthResponse{
+		Abnormal: condition.GetAbnormal(),
+		Message:  condition.GetMessage(),
+	}, nil
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2023 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/csi-addons/kubernetes-csi-addons/internal/proto"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// fakeNodeServer is a CSI node service that reports the condition of the
+// volumes in conditions, volumes without a condition are not reported.
+type fakeNodeServer struct {
+	csi.UnimplementedNodeServer
+	capabilities []csi.NodeServiceCapability_RPC_Type
+	conditions   map[string]*csi.VolumeCondition
+}
+
+func (f *fakeNodeServer) NodeGetCapabilities(
+	_ context.Context,
+	_ *csi.NodeGetCapabilitiesRequest) (*csi.NodeGetCapabilitiesResponse, error) {
+	res := &csi.NodeGetCapabilitiesResponse{}
+	for _, capType := range f.capabilities {
+		res.Capabilities = append(res.Capabilities, &csi.NodeServiceCapability{
+			Type: &csi.NodeServiceCapability_Rpc{
+				Rpc: &csi.NodeServiceCapability_RPC{Type: capType},
+			},
+		})
+	}
+
+	return res, nil
+}
+
+func (f *fakeNodeServer) NodeGetVolumeStats(
+	_ context.Context,
+	req *csi.NodeGetVolumeStatsRequest) (*csi.NodeGetVolumeStatsResponse, error) {
+	if req.GetVolumeId() == "" || req.GetVolumePath() == "" {
+		return nil, status.Error(codes.InvalidArgument, "volume id and volume path are required")
+	}
+
+	return &csi.NodeGetVolumeStatsResponse{
+		VolumeCondition: f.conditions[req.GetVolumeId()],
+	}, nil
+}
+
+// startFakeDriver serves the fakeNodeServer on a unix socket, and returns a
+// connection to it. The server is stopped when the test finishes.
+func startFakeDriver(t *testing.T, node *fakeNodeServer) *grpc.ClientConn {
+	t.Helper()
+
+	socket := filepath.Join(t.TempDir(), "csi.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	csi.RegisterNodeServer(server, node)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("unix://"+socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func TestGetVolumeHealth(t *testing.T) {
+	t.Parallel()
+	conn := startFakeDriver(t, &fakeNodeServer{
+		conditions: map[string]*csi.VolumeCondition{
+			"handle-healthy":  {Abnormal: false, Message: "volume is healthy"},
+			"handle-abnormal": {Abnormal: true, Message: "volume is degraded"},
+		},
+	})
+	podsPath := t.TempDir()
+	vs := NewVolumeHealthServer(conn, nil, t.TempDir(), podsPath)
+
+	publish := func(pvName string) {
+		path := filepath.Join(podsPath, "pod-uid", "volumes", "kubernetes.io~csi", pvName, "mount")
+		require.NoError(t, os.MkdirAll(path, 0o750))
+	}
+	publish("pv-healthy")
+	publish("pv-abnormal")
+	publish("pv-unreported")
+
+	tests := []struct {
+		name         string
+		pvName       string
+		handle       string
+		wantAbnormal bool
+		wantMessage  string
+		wantCode     codes.Code
+	}{
+		{
+			name:        "healthy volume",
+			pvName:      "pv-healthy",
+			handle:      "handle-healthy",
+			wantMessage: "volume is healthy",
+		},
+		{
+			name:         "abnormal volume",
+			pvName:       "pv-abnormal",
+			handle:       "handle-abnormal",
+			wantAbnormal: true,
+			wantMessage:  "volume is degraded",
+		},
+		{
+			name:     "condition not reported",
+			pvName:   "pv-unreported",
+			handle:   "handle-unreported",
+			wantCode: codes.Unimplemented,
+		},
+		{
+			name:     "volume not published",
+			pvName:   "pv-unpublished",
+			handle:   "handle-healthy",
+			wantCode: codes.FailedPrecondition,
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			res, err := vs.getVolumeHealth(context.Background(), newCSIPersistentVolume(newtt.pvName, newtt.handle))
+			assert.Equal(t, newtt.wantCode, status.Code(err))
+			assert.Equal(t, newtt.wantAbnormal, res.GetAbnormal())
+			assert.Equal(t, newtt.wantMessage, res.GetMessage())
+		})
+	}
+}
+
+func TestSupportsVolumeCondition(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		capabilities []csi.NodeServiceCapability_RPC_Type
+		want         bool
+	}{
+		{
+			name: "volume condition",
+			capabilities: []csi.NodeServiceCapability_RPC_Type{
+				csi.NodeServiceCapability_RPC_STAGE_UNSTAGE_VOLUME,
+				csi.NodeServiceCapability_RPC_GET_VOLUME_STATS,
+				csi.NodeServiceCapability_RPC_VOLUME_CONDITION,
+			},
+			want: true,
+		},
+		{
+			name: "volume stats without condition",
+			capabilities: []csi.NodeServiceCapability_RPC_Type{
+				csi.NodeServiceCapability_RPC_GET_VOLUME_STATS,
+			},
+			want: false,
+		},
+		{
+			name:         "no capabilities",
+			capabilities: nil,
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			conn := startFakeDriver(t, &fakeNodeServer{capabilities: newtt.capabilities})
+			vs := NewVolumeHealthServer(conn, nil, t.TempDir(), t.TempDir())
+
+			supported, err := vs.SupportsVolumeCondition(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, newtt.want, supported)
+		})
+	}
+}
+
+func TestVolumeHealthWithoutCSIEndpoint(t *testing.T) {
+	t.Parallel()
+	vs := NewVolumeHealthServer(nil, nil, t.TempDir(), t.TempDir())
+
+	supported, err := vs.SupportsVolumeCondition(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, supported)
+
+	_, err = vs.NodeGetVolumeHealth(context.Background(), &proto.VolumeHealthRequest{PvName: "pv-1"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
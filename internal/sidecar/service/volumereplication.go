@@ -18,14 +18,15 @@ package service
 import (
 	"context"
 
+	extensions "github.com/csi-addons/kubernetes-csi-addons/extensions/v1alpha1"
 	kube "github.com/csi-addons/kubernetes-csi-addons/internal/kubernetes"
 	"github.com/csi-addons/kubernetes-csi-addons/internal/proto"
+	"github.com/csi-addons/spec/lib/go/identity"
 	csiReplication "github.com/csi-addons/spec/lib/go/replication"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 )
 
@@ -35,15 +36,19 @@ import (
 type ReplicationServer struct {
 	proto.UnimplementedReplicationServer
 	controllerClient csiReplication.ControllerClient
-	kubeClient       *kubernetes.Clientset
+	identityClient   identity.IdentityClient
+	progressClient   extensions.ResyncProgressControllerClient
+	kubeCache        *kube.Cache
 }
 
 // NewReplicationServer creates a new ReplicationServer which handles the proto.Replication
 // Service requests.
-func NewReplicationServer(c *grpc.ClientConn, kc *kubernetes.Clientset) *ReplicationServer {
+func NewReplicationServer(c *grpc.ClientConn, kc *kube.Cache) *ReplicationServer {
 	return &ReplicationServer{
 		controllerClient: csiReplication.NewControllerClient(c),
-		kubeClient:       kc,
+		identityClient:   identity.NewIdentityClient(c),
+		progressClient:   extensions.NewResyncProgressControllerClient(c),
+		kubeCache:        kc,
 	}
 }
 
@@ -58,7 +63,7 @@ func (rs *ReplicationServer) EnableVolumeReplication(
 	ctx context.Context,
 	req *proto.EnableVolumeReplicationRequest) (*proto.EnableVolumeReplicationResponse, error) {
 	// Get the secrets from the k8s cluster
-	data, err := kube.GetSecret(ctx, rs.kubeClient, req.GetSecretName(), req.GetSecretNamespace())
+	data, err := rs.kubeCache.GetSecret(ctx, req.GetSecretName(), req.GetSecretNamespace())
 	if err != nil {
 		klog.Errorf("Failed to get secret %s in namespace %s: %v", req.GetSecretName(), req.GetSecretNamespace(), err)
 		return nil, status.Error(codes.Internal, err.Error())
@@ -85,7 +90,7 @@ func (rs *ReplicationServer) DisableVolumeReplication(
 	ctx context.Context,
 	req *proto.DisableVolumeReplicationRequest) (*proto.DisableVolumeReplicationResponse, error) {
 	// Get the secrets from the k8s cluster
-	data, err := kube.GetSecret(ctx, rs.kubeClient, req.GetSecretName(), req.GetSecretNamespace())
+	data, err := rs.kubeCache.GetSecret(ctx, req.GetSecretName(), req.GetSecretNamespace())
 	if err != nil {
 		klog.Errorf("Failed to get secret %s in namespace %s: %v", req.GetSecretName(), req.GetSecretNamespace(), err)
 		return nil, status.Error(codes.Internal, err.Error())
@@ -112,7 +117,7 @@ func (rs *ReplicationServer) PromoteVolume(
 	ctx context.Context,
 	req *proto.PromoteVolumeRequest) (*proto.PromoteVolumeResponse, error) {
 	// Get the secrets from the k8s cluster
-	data, err := kube.GetSecret(ctx, rs.kubeClient, req.GetSecretName(), req.GetSecretNamespace())
+	data, err := rs.kubeCache.GetSecret(ctx, req.GetSecretName(), req.GetSecretNamespace())
 	if err != nil {
 		klog.Errorf("Failed to get secret %s in namespace %s: %v", req.GetSecretName(), req.GetSecretNamespace(), err)
 		return nil, status.Error(codes.Internal, err.Error())
@@ -140,7 +145,7 @@ func (rs *ReplicationServer) DemoteVolume(
 	ctx context.Context,
 	req *proto.DemoteVolumeRequest) (*proto.DemoteVolumeResponse, error) {
 	// Get the secrets from the k8s cluster
-	data, err := kube.GetSecret(ctx, rs.kubeClient, req.GetSecretName(), req.GetSecretNamespace())
+	data, err := rs.kubeCache.GetSecret(ctx, req.GetSecretName(), req.GetSecretNamespace())
 	if err != nil {
 		klog.Errorf("Failed to get secret %s in namespace %s: %v", req.GetSecretName(), req.GetSecretNamespace(), err)
 		return nil, status.Error(codes.Internal, err.Error())
@@ -168,7 +173,7 @@ func (rs *ReplicationServer) ResyncVolume(
 	ctx context.Context,
 	req *proto.ResyncVolumeRequest) (*proto.ResyncVolumeResponse, error) {
 	// Get the secrets from the k8s cluster
-	data, err := kube.GetSecret(ctx, rs.kubeClient, req.GetSecretName(), req.GetSecretNamespace())
+	data, err := rs.kubeCache.GetSecret(ctx, req.GetSecretName(), req.GetSecretNamespace())
 	if err != nil {
 		klog.Errorf("Failed to get secret %s in namespace %s: %v", req.GetSecretName(), req.GetSecretNamespace(), err)
 		return nil, status.Error(codes.Internal, err.Error())
@@ -187,9 +192,66 @@ func (rs *ReplicationServer) ResyncVolume(
 		return nil, err
 	}
 
-	return &proto.ResyncVolumeResponse{
+	res := &proto.ResyncVolumeResponse{
 		Ready: resp.Ready,
-	}, nil
+	}
+	if !resp.Ready {
+		res.Progress = rs.getResyncProgress(ctx, &extensions.ControllerGetResyncProgressRequest{
+			VolumeId:      req.VolumeId,
+			ReplicationId: req.ReplicationId,
+			Parameters:    req.Parameters,
+			Secrets:       data,
+		})
+	}
+
+	return res, nil
+}
+
+// getResyncProgress returns the progress of the resync from the
+// ResyncProgressController service of the driver, or nil if the driver does
+// not report it. The resync itself succeeded, so failing to get the progress
+// is not an error.
+func (rs *ReplicationServer) getResyncProgress(
+	ctx context.Context,
+	req *extensions.ControllerGetResyncProgressRequest) *proto.ResyncProgress {
+	caps, err := rs.identityClient.GetCapabilities(ctx, &identity.GetCapabilitiesRequest{})
+	if err != nil {
+		klog.Errorf("Failed to get the capabilities of the driver: %v", err)
+		return nil
+	}
+	if !extensions.HasVolumeReplicationCapability(caps.GetCapabilities(), extensions.Capability_VolumeReplication_RESYNC_PROGRESS) {
+		return nil
+	}
+
+	res, err := rs.progressClient.ControllerGetResyncProgress(ctx, req)
+	if err != nil {
+		klog.Errorf("Failed to get the resync progress of volume %s: %v", req.GetVolumeId(), err)
+		return nil
+	}
+
+	return newResyncProgress(res)
+}
+
+// newResyncProgress converts the progress that the driver reported, fields
+// that are not set or out of range are reported as not known. Nil is
+// returned if the driver did not report any field.
+func newResyncProgress(res *extensions.ControllerGetResyncProgressResponse) *proto.ResyncProgress {
+	if res.BytesRemaining == nil && res.PercentComplete == nil {
+		return nil
+	}
+
+	progress := &proto.ResyncProgress{
+		BytesRemaining:  -1,
+		PercentComplete: -1,
+	}
+	if res.BytesRemaining != nil && res.GetBytesRemaining() >= 0 {
+		progress.BytesRemaining = res.GetBytesRemaining()
+	}
+	if res.PercentComplete != nil && res.GetPercentComplete() >= 0 && res.GetPercentComplete() <= 100 {
+		progress.PercentComplete = res.GetPercentComplete()
+	}
+
+	return progress
 }
 
 // GetVolumeReplicationInfo fetches required information from kubernetes cluster and calls
@@ -198,7 +260,7 @@ func (rs *ReplicationServer) GetVolumeReplicationInfo(
 	ctx context.Context,
 	req *proto.GetVolumeReplicationInfoRequest) (*proto.GetVolumeReplicationInfoResponse, error) {
 	// Get the secrets from the k8s cluster
-	data, err := kube.GetSecret(ctx, rs.kubeClient, req.GetSecretName(), req.GetSecretNamespace())
+	data, err := rs.kubeCache.GetSecret(ctx, req.GetSecretName(), req.GetSecretNamespace())
 	if err != nil {
 		klog.Errorf("Failed to get secret %s in namespace %s: %v", req.GetSecretName(), req.GetSecretNamespace(), err)
 		return nil, status.Error(codes.Internal, err.Error())
@@ -0,0 +1,176 @@
+/*
+Copyright 2022 The Kubernetes-CSI-Addons Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	extensions "github.com/csi-addons/kubernetes-csi-addons/extensions/v1alpha1"
+	"github.com/csi-addons/kubernetes-csi-addons/internal/proto"
+
+	"github.com/csi-addons/spec/lib/go/identity"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeIdentityClient returns the capabilities of a driver, or fails with
+// err.
+type fakeIdentityClient struct {
+	identity.IdentityClient
+	caps []*identity.Capability
+	err  error
+}
+
+func (f *fakeIdentityClient) GetCapabilities(
+	_ context.Context,
+	_ *identity.GetCapabilitiesRequest,
+	_ ...grpc.CallOption) (*identity.GetCapabilitiesResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return &identity.GetCapabilitiesResponse{Capabilities: f.caps}, nil
+}
+
+// fakeResyncProgressClient returns the progress of a resync, and records
+// whether it was called.
+type fakeResyncProgressClient struct {
+	res    *extensions.ControllerGetResyncProgressResponse
+	err    error
+	called bool
+}
+
+func (f *fakeResyncProgressClient) ControllerGetResyncProgress(
+	_ context.Context,
+	_ *extensions.ControllerGetResyncProgressRequest,
+	_ ...grpc.CallOption) (*extensions.ControllerGetResyncProgressResponse, error) {
+	f.called = true
+
+	return f.res, f.err
+}
+
+func TestGetResyncProgress(t *testing.T) {
+	t.Parallel()
+	bytesRemaining := int64(1073741824)
+	percentComplete := int32(42)
+	reported := &extensions.ControllerGetResyncProgressResponse{
+		BytesRemaining:  &bytesRemaining,
+		PercentComplete: &percentComplete,
+	}
+	progressCap := extensions.NewVolumeReplicationCapability(extensions.Capability_VolumeReplication_RESYNC_PROGRESS)
+
+	tests := []struct {
+		name       string
+		caps       []*identity.Capability
+		capsErr    error
+		res        *extensions.ControllerGetResyncProgressResponse
+		err        error
+		wantCalled bool
+		want       *proto.ResyncProgress
+	}{
+		{
+			name:       "capability not advertised",
+			caps:       nil,
+			res:        reported,
+			wantCalled: false,
+			want:       nil,
+		},
+		{
+			name:       "capabilities not returned",
+			capsErr:    status.Error(codes.Unavailable, "connection refused"),
+			res:        reported,
+			wantCalled: false,
+			want:       nil,
+		},
+		{
+			name:       "progress reported",
+			caps:       []*identity.Capability{progressCap},
+			res:        reported,
+			wantCalled: true,
+			want:       &proto.ResyncProgress{BytesRemaining: 1073741824, PercentComplete: 42},
+		},
+		{
+			name:       "driver fails",
+			caps:       []*identity.Capability{progressCap},
+			err:        status.Error(codes.Internal, "failed"),
+			wantCalled: true,
+			want:       nil,
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			progressClient := &fakeResyncProgressClient{res: newtt.res, err: newtt.err}
+			rs := &ReplicationServer{
+				identityClient: &fakeIdentityClient{caps: newtt.caps, err: newtt.capsErr},
+				progressClient: progressClient,
+			}
+
+			got := rs.getResyncProgress(context.TODO(), &extensions.ControllerGetResyncProgressRequest{VolumeId: "volume-1"})
+			assert.Equal(t, newtt.want, got)
+			assert.Equal(t, newtt.wantCalled, progressClient.called)
+		})
+	}
+}
+
+func TestNewResyncProgress(t *testing.T) {
+	t.Parallel()
+	i64 := func(v int64) *int64 { return &v }
+	i32 := func(v int32) *int32 { return &v }
+
+	tests := []struct {
+		name string
+		res  *extensions.ControllerGetResyncProgressResponse
+		want *proto.ResyncProgress
+	}{
+		{
+			name: "not reported",
+			res:  &extensions.ControllerGetResyncProgressResponse{},
+			want: nil,
+		},
+		{
+			name: "both reported",
+			res:  &extensions.ControllerGetResyncProgressResponse{BytesRemaining: i64(1073741824), PercentComplete: i32(42)},
+			want: &proto.ResyncProgress{BytesRemaining: 1073741824, PercentComplete: 42},
+		},
+		{
+			name: "bytes remaining only",
+			res:  &extensions.ControllerGetResyncProgressResponse{BytesRemaining: i64(0)},
+			want: &proto.ResyncProgress{BytesRemaining: 0, PercentComplete: -1},
+		},
+		{
+			name: "percent complete only",
+			res:  &extensions.ControllerGetResyncProgressResponse{PercentComplete: i32(100)},
+			want: &proto.ResyncProgress{BytesRemaining: -1, PercentComplete: 100},
+		},
+		{
+			name: "out of range",
+			res:  &extensions.ControllerGetResyncProgressResponse{BytesRemaining: i64(-5), PercentComplete: i32(101)},
+			want: &proto.ResyncProgress{BytesRemaining: -1, PercentComplete: -1},
+		},
+	}
+	for _, tt := range tests {
+		newtt := tt
+		t.Run(newtt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, newtt.want, newResyncProgress(newtt.res))
+		})
+	}
+}
@@ -30,26 +30,66 @@ import (
 // Config holds the configuration options that
 // can be overrrided via a config file.
 type Config struct {
-	Namespace               string
-	ReclaimSpaceTimeout     time.Duration
-	MaxConcurrentReconciles int
+	Namespace                    string
+	ReclaimSpaceTimeout          time.Duration
+	NetworkFenceTimeout          time.Duration
+	EncryptionKeyRotationTimeout time.Duration
+	FilesystemMaintenanceTimeout time.Duration
+	MaxConcurrentReconciles      int
+
+	// intervals at which the state of a VolumeReplication is polled.
+	ReplicationDemoteRequeueInterval time.Duration
+	ReplicationResyncRequeueInterval time.Duration
+	ReplicationInfoRequeueInterval   time.Duration
+	ReplicationMaxRequeueInterval    time.Duration
+
+	// interval at which the health of the attached volumes is checked.
+	VolumeHealthInterval time.Duration
 }
 
 const (
-	csiAddonsConfigMapName         = "csi-addons-config"
-	ReclaimSpaceTimeoutKey         = "reclaim-space-timeout"
-	MaxConcurrentReconcilesKey     = "max-concurrent-reconciles"
-	defaultNamespace               = "csi-addons-system"
-	defaultMaxConcurrentReconciles = 100
-	defaultReclaimSpaceTimeout     = time.Minute * 3
+	csiAddonsConfigMapName              = "csi-addons-config"
+	ReclaimSpaceTimeoutKey              = "reclaim-space-timeout"
+	NetworkFenceTimeoutKey              = "network-fence-timeout"
+	EncryptionKeyRotationTimeoutKey     = "encryption-key-rotation-timeout"
+	FilesystemMaintenanceTimeoutKey     = "filesystem-maintenance-timeout"
+	MaxConcurrentReconcilesKey          = "max-concurrent-reconciles"
+	defaultNamespace                    = "csi-addons-system"
+	defaultMaxConcurrentReconciles      = 100
+	defaultReclaimSpaceTimeout          = time.Minute * 3
+	defaultNetworkFenceTimeout          = time.Minute * 3
+	defaultEncryptionKeyRotationTimeout = time.Minute * 3
+	defaultFilesystemMaintenanceTimeout = time.Minute * 10
+
+	ReplicationDemoteRequeueIntervalKey     = "replication-demote-requeue-interval"
+	ReplicationResyncRequeueIntervalKey     = "replication-resync-requeue-interval"
+	ReplicationInfoRequeueIntervalKey       = "replication-info-requeue-interval"
+	ReplicationMaxRequeueIntervalKey        = "replication-max-requeue-interval"
+	defaultReplicationDemoteRequeueInterval = time.Second * 15
+	defaultReplicationResyncRequeueInterval = time.Second * 30
+	defaultReplicationInfoRequeueInterval   = time.Hour
+	defaultReplicationMaxRequeueInterval    = time.Minute * 5
+
+	VolumeHealthIntervalKey     = "volume-health-interval"
+	defaultVolumeHealthInterval = time.Minute * 5
 )
 
 // NewConfig returns a new Config object with default values.
 func NewConfig() Config {
 	return Config{
-		Namespace:               defaultNamespace,
-		ReclaimSpaceTimeout:     defaultReclaimSpaceTimeout,
-		MaxConcurrentReconciles: defaultMaxConcurrentReconciles,
+		Namespace:                    defaultNamespace,
+		ReclaimSpaceTimeout:          defaultReclaimSpaceTimeout,
+		NetworkFenceTimeout:          defaultNetworkFenceTimeout,
+		EncryptionKeyRotationTimeout: defaultEncryptionKeyRotationTimeout,
+		FilesystemMaintenanceTimeout: defaultFilesystemMaintenanceTimeout,
+		MaxConcurrentReconciles:      defaultMaxConcurrentReconciles,
+
+		ReplicationDemoteRequeueInterval: defaultReplicationDemoteRequeueInterval,
+		ReplicationResyncRequeueInterval: defaultReplicationResyncRequeueInterval,
+		ReplicationInfoRequeueInterval:   defaultReplicationInfoRequeueInterval,
+		ReplicationMaxRequeueInterval:    defaultReplicationMaxRequeueInterval,
+
+		VolumeHealthInterval: defaultVolumeHealthInterval,
 	}
 }
 
@@ -81,6 +121,30 @@ func (cfg *Config) readConfig(dataMap map[string]string) error {
 			}
 			cfg.ReclaimSpaceTimeout = timeout
 
+		case NetworkFenceTimeoutKey:
+			timeout, err := time.ParseDuration(val)
+			if err != nil {
+				return fmt.Errorf("failed to parse key %q value %q as duration: %w",
+					NetworkFenceTimeoutKey, val, err)
+			}
+			cfg.NetworkFenceTimeout = timeout
+
+		case EncryptionKeyRotationTimeoutKey:
+			timeout, err := time.ParseDuration(val)
+			if err != nil {
+				return fmt.Errorf("failed to parse key %q value %q as duration: %w",
+					EncryptionKeyRotationTimeoutKey, val, err)
+			}
+			cfg.EncryptionKeyRotationTimeout = timeout
+
+		case FilesystemMaintenanceTimeoutKey:
+			timeout, err := time.ParseDuration(val)
+			if err != nil {
+				return fmt.Errorf("failed to parse key %q value %q as duration: %w",
+					FilesystemMaintenanceTimeoutKey, val, err)
+			}
+			cfg.FilesystemMaintenanceTimeout = timeout
+
 		case MaxConcurrentReconcilesKey:
 			maxConcurrentReconciles, err := strconv.Atoi(val)
 			if err != nil {
@@ -89,6 +153,46 @@ func (cfg *Config) readConfig(dataMap map[string]string) error {
 			}
 			cfg.MaxConcurrentReconciles = maxConcurrentReconciles
 
+		case ReplicationDemoteRequeueIntervalKey:
+			interval, err := time.ParseDuration(val)
+			if err != nil {
+				return fmt.Errorf("failed to parse key %q value %q as duration: %w",
+					ReplicationDemoteRequeueIntervalKey, val, err)
+			}
+			cfg.ReplicationDemoteRequeueInterval = interval
+
+		case ReplicationResyncRequeueIntervalKey:
+			interval, err := time.ParseDuration(val)
+			if err != nil {
+				return fmt.Errorf("failed to parse key %q value %q as duration: %w",
+					ReplicationResyncRequeueIntervalKey, val, err)
+			}
+			cfg.ReplicationResyncRequeueInterval = interval
+
+		case ReplicationInfoRequeueIntervalKey:
+			interval, err := time.ParseDuration(val)
+			if err != nil {
+				return fmt.Errorf("failed to parse key %q value %q as duration: %w",
+					ReplicationInfoRequeueIntervalKey, val, err)
+			}
+			cfg.ReplicationInfoRequeueInterval = interval
+
+		case ReplicationMaxRequeueIntervalKey:
+			interval, err := time.ParseDuration(val)
+			if err != nil {
+				return fmt.Errorf("failed to parse key %q value %q as duration: %w",
+					ReplicationMaxRequeueIntervalKey, val, err)
+			}
+			cfg.ReplicationMaxRequeueInterval = interval
+
+		case VolumeHealthIntervalKey:
+			interval, err := time.ParseDuration(val)
+			if err != nil {
+				return fmt.Errorf("failed to parse key %q value %q as duration: %w",
+					VolumeHealthIntervalKey, val, err)
+			}
+			cfg.VolumeHealthInterval = interval
+
 		default:
 			return fmt.Errorf("unknown config key %q", key)
 		}
@@ -34,9 +34,19 @@ func TestConfigReadConfigFile(t *testing.T) {
 			name:    "config file does not exist",
 			dataMap: nil,
 			newConfig: Config{
-				Namespace:               defaultNamespace,
-				ReclaimSpaceTimeout:     defaultReclaimSpaceTimeout,
-				MaxConcurrentReconciles: defaultMaxConcurrentReconciles,
+				Namespace:                    defaultNamespace,
+				ReclaimSpaceTimeout:          defaultReclaimSpaceTimeout,
+				NetworkFenceTimeout:          defaultNetworkFenceTimeout,
+				EncryptionKeyRotationTimeout: defaultEncryptionKeyRotationTimeout,
+				FilesystemMaintenanceTimeout: defaultFilesystemMaintenanceTimeout,
+				MaxConcurrentReconciles:      defaultMaxConcurrentReconciles,
+
+				ReplicationDemoteRequeueInterval: defaultReplicationDemoteRequeueInterval,
+				ReplicationResyncRequeueInterval: defaultReplicationResyncRequeueInterval,
+				ReplicationInfoRequeueInterval:   defaultReplicationInfoRequeueInterval,
+				ReplicationMaxRequeueInterval:    defaultReplicationMaxRequeueInterval,
+
+				VolumeHealthInterval: defaultVolumeHealthInterval,
 			},
 			wantErr: false,
 		},
@@ -44,9 +54,19 @@ func TestConfigReadConfigFile(t *testing.T) {
 			name:    "config file does exist but empty configuration",
 			dataMap: make(map[string]string),
 			newConfig: Config{
-				Namespace:               defaultNamespace,
-				ReclaimSpaceTimeout:     defaultReclaimSpaceTimeout,
-				MaxConcurrentReconciles: defaultMaxConcurrentReconciles,
+				Namespace:                    defaultNamespace,
+				ReclaimSpaceTimeout:          defaultReclaimSpaceTimeout,
+				NetworkFenceTimeout:          defaultNetworkFenceTimeout,
+				EncryptionKeyRotationTimeout: defaultEncryptionKeyRotationTimeout,
+				FilesystemMaintenanceTimeout: defaultFilesystemMaintenanceTimeout,
+				MaxConcurrentReconciles:      defaultMaxConcurrentReconciles,
+
+				ReplicationDemoteRequeueInterval: defaultReplicationDemoteRequeueInterval,
+				ReplicationResyncRequeueInterval: defaultReplicationResyncRequeueInterval,
+				ReplicationInfoRequeueInterval:   defaultReplicationInfoRequeueInterval,
+				ReplicationMaxRequeueInterval:    defaultReplicationMaxRequeueInterval,
+
+				VolumeHealthInterval: defaultVolumeHealthInterval,
 			},
 			wantErr: false,
 		},
@@ -56,9 +76,63 @@ func TestConfigReadConfigFile(t *testing.T) {
 				"reclaim-space-timeout": "10m",
 			},
 			newConfig: Config{
-				Namespace:               defaultNamespace,
-				ReclaimSpaceTimeout:     time.Minute * 10,
-				MaxConcurrentReconciles: defaultMaxConcurrentReconciles,
+				Namespace:                    defaultNamespace,
+				ReclaimSpaceTimeout:          time.Minute * 10,
+				NetworkFenceTimeout:          defaultNetworkFenceTimeout,
+				EncryptionKeyRotationTimeout: defaultEncryptionKeyRotationTimeout,
+				FilesystemMaintenanceTimeout: defaultFilesystemMaintenanceTimeout,
+				MaxConcurrentReconciles:      defaultMaxConcurrentReconciles,
+
+				ReplicationDemoteRequeueInterval: defaultReplicationDemoteRequeueInterval,
+				ReplicationResyncRequeueInterval: defaultReplicationResyncRequeueInterval,
+				ReplicationInfoRequeueInterval:   defaultReplicationInfoRequeueInterval,
+				ReplicationMaxRequeueInterval:    defaultReplicationMaxRequeueInterval,
+
+				VolumeHealthInterval: defaultVolumeHealthInterval,
+			},
+			wantErr: false,
+		},
+		{
+			name: "config file modifies encryption-key-rotation-timeout",
+			dataMap: map[string]string{
+				"encryption-key-rotation-timeout": "10m",
+			},
+			newConfig: Config{
+				Namespace:                    defaultNamespace,
+				ReclaimSpaceTimeout:          defaultReclaimSpaceTimeout,
+				NetworkFenceTimeout:          defaultNetworkFenceTimeout,
+				EncryptionKeyRotationTimeout: time.Minute * 10,
+				FilesystemMaintenanceTimeout: defaultFilesystemMaintenanceTimeout,
+				MaxConcurrentReconciles:      defaultMaxConcurrentReconciles,
+
+				ReplicationDemoteRequeueInterval: defaultReplicationDemoteRequeueInterval,
+				ReplicationResyncRequeueInterval: defaultReplicationResyncRequeueInterval,
+				ReplicationInfoRequeueInterval:   defaultReplicationInfoRequeueInterval,
+				ReplicationMaxRequeueInterval:    defaultReplicationMaxRequeueInterval,
+
+				VolumeHealthInterval: defaultVolumeHealthInterval,
+			},
+			wantErr: false,
+		},
+		{
+			name: "config file modifies filesystem-maintenance-timeout",
+			dataMap: map[string]string{
+				"filesystem-maintenance-timeout": "1h",
+			},
+			newConfig: Config{
+				Namespace:                    defaultNamespace,
+				ReclaimSpaceTimeout:          defaultReclaimSpaceTimeout,
+				NetworkFenceTimeout:          defaultNetworkFenceTimeout,
+				EncryptionKeyRotationTimeout: defaultEncryptionKeyRotationTimeout,
+				FilesystemMaintenanceTimeout: time.Hour,
+				MaxConcurrentReconciles:      defaultMaxConcurrentReconciles,
+
+				ReplicationDemoteRequeueInterval: defaultReplicationDemoteRequeueInterval,
+				ReplicationResyncRequeueInterval: defaultReplicationResyncRequeueInterval,
+				ReplicationInfoRequeueInterval:   defaultReplicationInfoRequeueInterval,
+				ReplicationMaxRequeueInterval:    defaultReplicationMaxRequeueInterval,
+
+				VolumeHealthInterval: defaultVolumeHealthInterval,
 			},
 			wantErr: false,
 		},
@@ -68,9 +142,19 @@ func TestConfigReadConfigFile(t *testing.T) {
 				"reclaim-space-timeout": "hours",
 			},
 			newConfig: Config{
-				Namespace:               defaultNamespace,
-				ReclaimSpaceTimeout:     defaultReclaimSpaceTimeout,
-				MaxConcurrentReconciles: defaultMaxConcurrentReconciles,
+				Namespace:                    defaultNamespace,
+				ReclaimSpaceTimeout:          defaultReclaimSpaceTimeout,
+				NetworkFenceTimeout:          defaultNetworkFenceTimeout,
+				EncryptionKeyRotationTimeout: defaultEncryptionKeyRotationTimeout,
+				FilesystemMaintenanceTimeout: defaultFilesystemMaintenanceTimeout,
+				MaxConcurrentReconciles:      defaultMaxConcurrentReconciles,
+
+				ReplicationDemoteRequeueInterval: defaultReplicationDemoteRequeueInterval,
+				ReplicationResyncRequeueInterval: defaultReplicationResyncRequeueInterval,
+				ReplicationInfoRequeueInterval:   defaultReplicationInfoRequeueInterval,
+				ReplicationMaxRequeueInterval:    defaultReplicationMaxRequeueInterval,
+
+				VolumeHealthInterval: defaultVolumeHealthInterval,
 			},
 			wantErr: true,
 		},
@@ -80,9 +164,19 @@ func TestConfigReadConfigFile(t *testing.T) {
 				"max-concurrent-reconciles": "1",
 			},
 			newConfig: Config{
-				Namespace:               defaultNamespace,
-				ReclaimSpaceTimeout:     defaultReclaimSpaceTimeout,
-				MaxConcurrentReconciles: 1,
+				Namespace:                    defaultNamespace,
+				ReclaimSpaceTimeout:          defaultReclaimSpaceTimeout,
+				NetworkFenceTimeout:          defaultNetworkFenceTimeout,
+				EncryptionKeyRotationTimeout: defaultEncryptionKeyRotationTimeout,
+				FilesystemMaintenanceTimeout: defaultFilesystemMaintenanceTimeout,
+				MaxConcurrentReconciles:      1,
+
+				ReplicationDemoteRequeueInterval: defaultReplicationDemoteRequeueInterval,
+				ReplicationResyncRequeueInterval: defaultReplicationResyncRequeueInterval,
+				ReplicationInfoRequeueInterval:   defaultReplicationInfoRequeueInterval,
+				ReplicationMaxRequeueInterval:    defaultReplicationMaxRequeueInterval,
+
+				VolumeHealthInterval: defaultVolumeHealthInterval,
 			},
 			wantErr: false,
 		},
@@ -92,9 +186,19 @@ func TestConfigReadConfigFile(t *testing.T) {
 				"max-concurrent-reconciles": "invalid",
 			},
 			newConfig: Config{
-				Namespace:               defaultNamespace,
-				ReclaimSpaceTimeout:     defaultReclaimSpaceTimeout,
-				MaxConcurrentReconciles: defaultMaxConcurrentReconciles,
+				Namespace:                    defaultNamespace,
+				ReclaimSpaceTimeout:          defaultReclaimSpaceTimeout,
+				NetworkFenceTimeout:          defaultNetworkFenceTimeout,
+				EncryptionKeyRotationTimeout: defaultEncryptionKeyRotationTimeout,
+				FilesystemMaintenanceTimeout: defaultFilesystemMaintenanceTimeout,
+				MaxConcurrentReconciles:      defaultMaxConcurrentReconciles,
+
+				ReplicationDemoteRequeueInterval: defaultReplicationDemoteRequeueInterval,
+				ReplicationResyncRequeueInterval: defaultReplicationResyncRequeueInterval,
+				ReplicationInfoRequeueInterval:   defaultReplicationInfoRequeueInterval,
+				ReplicationMaxRequeueInterval:    defaultReplicationMaxRequeueInterval,
+
+				VolumeHealthInterval: defaultVolumeHealthInterval,
 			},
 			wantErr: true,
 		},
@@ -105,9 +209,132 @@ func TestConfigReadConfigFile(t *testing.T) {
 				"max-concurrent-reconciles": "5",
 			},
 			newConfig: Config{
-				Namespace:               defaultNamespace,
-				ReclaimSpaceTimeout:     time.Minute * 10,
-				MaxConcurrentReconciles: 5,
+				Namespace:                    defaultNamespace,
+				ReclaimSpaceTimeout:          time.Minute * 10,
+				NetworkFenceTimeout:          defaultNetworkFenceTimeout,
+				EncryptionKeyRotationTimeout: defaultEncryptionKeyRotationTimeout,
+				FilesystemMaintenanceTimeout: defaultFilesystemMaintenanceTimeout,
+				MaxConcurrentReconciles:      5,
+
+				ReplicationDemoteRequeueInterval: defaultReplicationDemoteRequeueInterval,
+				ReplicationResyncRequeueInterval: defaultReplicationResyncRequeueInterval,
+				ReplicationInfoRequeueInterval:   defaultReplicationInfoRequeueInterval,
+				ReplicationMaxRequeueInterval:    defaultReplicationMaxRequeueInterval,
+
+				VolumeHealthInterval: defaultVolumeHealthInterval,
+			},
+			wantErr: false,
+		},
+		{
+			name: "config file modifies network-fence-timeout",
+			dataMap: map[string]string{
+				"network-fence-timeout": "5m",
+			},
+			newConfig: Config{
+				Namespace:                    defaultNamespace,
+				ReclaimSpaceTimeout:          defaultReclaimSpaceTimeout,
+				NetworkFenceTimeout:          time.Minute * 5,
+				EncryptionKeyRotationTimeout: defaultEncryptionKeyRotationTimeout,
+				FilesystemMaintenanceTimeout: defaultFilesystemMaintenanceTimeout,
+				MaxConcurrentReconciles:      defaultMaxConcurrentReconciles,
+
+				ReplicationDemoteRequeueInterval: defaultReplicationDemoteRequeueInterval,
+				ReplicationResyncRequeueInterval: defaultReplicationResyncRequeueInterval,
+				ReplicationInfoRequeueInterval:   defaultReplicationInfoRequeueInterval,
+				ReplicationMaxRequeueInterval:    defaultReplicationMaxRequeueInterval,
+
+				VolumeHealthInterval: defaultVolumeHealthInterval,
+			},
+			wantErr: false,
+		},
+		{
+			name: "config file modifies network-fence-timeout but invalid",
+			dataMap: map[string]string{
+				"network-fence-timeout": "minutes",
+			},
+			newConfig: Config{
+				Namespace:                    defaultNamespace,
+				ReclaimSpaceTimeout:          defaultReclaimSpaceTimeout,
+				NetworkFenceTimeout:          defaultNetworkFenceTimeout,
+				EncryptionKeyRotationTimeout: defaultEncryptionKeyRotationTimeout,
+				FilesystemMaintenanceTimeout: defaultFilesystemMaintenanceTimeout,
+				MaxConcurrentReconciles:      defaultMaxConcurrentReconciles,
+
+				ReplicationDemoteRequeueInterval: defaultReplicationDemoteRequeueInterval,
+				ReplicationResyncRequeueInterval: defaultReplicationResyncRequeueInterval,
+				ReplicationInfoRequeueInterval:   defaultReplicationInfoRequeueInterval,
+				ReplicationMaxRequeueInterval:    defaultReplicationMaxRequeueInterval,
+
+				VolumeHealthInterval: defaultVolumeHealthInterval,
+			},
+			wantErr: true,
+		},
+		{
+			name: "config file modifies replication requeue intervals",
+			dataMap: map[string]string{
+				"replication-demote-requeue-interval": "30s",
+				"replication-resync-requeue-interval": "1m",
+				"replication-info-requeue-interval":   "30m",
+				"replication-max-requeue-interval":    "10m",
+			},
+			newConfig: Config{
+				Namespace:                    defaultNamespace,
+				ReclaimSpaceTimeout:          defaultReclaimSpaceTimeout,
+				NetworkFenceTimeout:          defaultNetworkFenceTimeout,
+				EncryptionKeyRotationTimeout: defaultEncryptionKeyRotationTimeout,
+				FilesystemMaintenanceTimeout: defaultFilesystemMaintenanceTimeout,
+				MaxConcurrentReconciles:      defaultMaxConcurrentReconciles,
+
+				ReplicationDemoteRequeueInterval: time.Second * 30,
+				ReplicationResyncRequeueInterval: time.Minute,
+				ReplicationInfoRequeueInterval:   time.Minute * 30,
+				ReplicationMaxRequeueInterval:    time.Minute * 10,
+
+				VolumeHealthInterval: defaultVolumeHealthInterval,
+			},
+			wantErr: false,
+		},
+		{
+			name: "config file modifies replication-resync-requeue-interval but invalid",
+			dataMap: map[string]string{
+				"replication-resync-requeue-interval": "seconds",
+			},
+			newConfig: Config{
+				Namespace:                    defaultNamespace,
+				ReclaimSpaceTimeout:          defaultReclaimSpaceTimeout,
+				NetworkFenceTimeout:          defaultNetworkFenceTimeout,
+				EncryptionKeyRotationTimeout: defaultEncryptionKeyRotationTimeout,
+				FilesystemMaintenanceTimeout: defaultFilesystemMaintenanceTimeout,
+				MaxConcurrentReconciles:      defaultMaxConcurrentReconciles,
+
+				ReplicationDemoteRequeueInterval: defaultReplicationDemoteRequeueInterval,
+				ReplicationResyncRequeueInterval: defaultReplicationResyncRequeueInterval,
+				ReplicationInfoRequeueInterval:   defaultReplicationInfoRequeueInterval,
+				ReplicationMaxRequeueInterval:    defaultReplicationMaxRequeueInterval,
+
+				VolumeHealthInterval: defaultVolumeHealthInterval,
+			},
+			wantErr: true,
+		},
+		{
+			name: "config file modifies volume-health-interval",
+			dataMap: map[string]string{
+				"volume-health-interval": "1m",
+			},
+			newConfig: Config{
+				Namespace:                    defaultNamespace,
+				ReclaimSpaceTimeout:          defaultReclaimSpaceTimeout,
+				NetworkFenceTimeout:          defaultNetworkFenceTimeout,
+				EncryptionKeyRotationTimeout: defaultEncryptionKeyRotationTimeout,
+				FilesystemMaintenanceTimeout: defaultFilesystemMaintenanceTimeout,
+				MaxConcurrentReconciles:      defaultMaxConcurrentReconciles,
+
+				ReplicationDemoteRequeueInterval: defaultReplicationDemoteRequeueInterval,
+				ReplicationResyncRequeueInterval: defaultReplicationResyncRequeueInterval,
+				ReplicationInfoRequeueInterval:   defaultReplicationInfoRequeueInterval,
+				ReplicationMaxRequeueInterval:    defaultReplicationMaxRequeueInterval,
+
+				VolumeHealthInterval: time.Minute,
 			},
 			wantErr: false,
 		},
@@ -117,9 +344,19 @@ func TestConfigReadConfigFile(t *testing.T) {
 				"network-fence-duration": "3m",
 			},
 			newConfig: Config{
-				Namespace:               defaultNamespace,
-				ReclaimSpaceTimeout:     defaultReclaimSpaceTimeout,
-				MaxConcurrentReconciles: defaultMaxConcurrentReconciles,
+				Namespace:                    defaultNamespace,
+				ReclaimSpaceTimeout:          defaultReclaimSpaceTimeout,
+				NetworkFenceTimeout:          defaultNetworkFenceTimeout,
+				EncryptionKeyRotationTimeout: defaultEncryptionKeyRotationTimeout,
+				FilesystemMaintenanceTimeout: defaultFilesystemMaintenanceTimeout,
+				MaxConcurrentReconciles:      defaultMaxConcurrentReconciles,
+
+				ReplicationDemoteRequeueInterval: defaultReplicationDemoteRequeueInterval,
+				ReplicationResyncRequeueInterval: defaultReplicationResyncRequeueInterval,
+				ReplicationInfoRequeueInterval:   defaultReplicationInfoRequeueInterval,
+				ReplicationMaxRequeueInterval:    defaultReplicationMaxRequeueInterval,
+
+				VolumeHealthInterval: defaultVolumeHealthInterval,
 			},
 			wantErr: true,
 		},
@@ -17,9 +17,12 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"time"
 
+	extensions "github.com/csi-addons/kubernetes-csi-addons/extensions/v1alpha1"
+	kube "github.com/csi-addons/kubernetes-csi-addons/internal/kubernetes"
 	"github.com/csi-addons/kubernetes-csi-addons/internal/sidecar/service"
 	"github.com/csi-addons/kubernetes-csi-addons/internal/version"
 	"github.com/csi-addons/kubernetes-csi-addons/sidecar/internal/client"
@@ -27,6 +30,9 @@ import (
 	"github.com/csi-addons/kubernetes-csi-addons/sidecar/internal/server"
 	"github.com/csi-addons/kubernetes-csi-addons/sidecar/internal/util"
 
+	"github.com/csi-addons/spec/lib/go/identity"
+	"google.golang.org/grpc"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
@@ -36,10 +42,13 @@ func main() {
 	var (
 		defaultTimeout     = time.Minute * 3
 		defaultStagingPath = "/var/lib/kubelet/plugins/kubernetes.io/csi/"
+		defaultPodsPath    = "/var/lib/kubelet/pods/"
 		timeout            = flag.Duration("timeout", defaultTimeout, "Timeout for waiting for response")
 		csiAddonsAddress   = flag.String("csi-addons-address", "/run/csi-addons/socket", "CSI Addons endopoint")
+		csiAddress         = flag.String("csi-address", "", "CSI endpoint of the driver, to report the health of volumes (example: `/csi/csi.sock`)")
 		nodeID             = flag.String("node-id", "", "NodeID")
 		stagingPath        = flag.String("stagingpath", defaultStagingPath, "stagingpath")
+		podsPath           = flag.String("podspath", defaultPodsPath, "path of the kubelet pods directory, to find the volumes of drivers that do not stage volumes")
 		controllerPort     = flag.String("controller-port", "",
 			"The TCP network port where the gRPC server for controller request, will listen (example: `8080`)")
 		controllerIP = flag.String("controller-ip", "",
@@ -101,11 +110,41 @@ func main() {
 		klog.Fatalf("Failed to create csiaddonsnode: %v", err)
 	}
 
+	// the secrets and storageclasses are served from informers, instead of
+	// fetching them for every request.
+	kubeCache := kube.NewCache(kubeClient, wait.NeverStop)
+
+	// the condition of volumes is reported by the CSI Node service of the
+	// driver, which is served on the CSI endpoint.
+	var csiConn *grpc.ClientConn
+	if *csiAddress != "" {
+		csiNodeClient, err := client.New(*csiAddress, *timeout)
+		if err != nil {
+			klog.Fatalf("Failed to connect to %q : %v", *csiAddress, err)
+		}
+		csiConn = csiNodeClient.GetGRPCClient()
+	}
+	volumeHealthServer := service.NewVolumeHealthServer(csiConn, kubeCache, *stagingPath, *podsPath)
+
+	var sidecarCaps []*identity.Capability
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	supported, err := volumeHealthServer.SupportsVolumeCondition(ctx)
+	cancel()
+	if err != nil {
+		klog.Fatalf("Failed to get the node capabilities of the driver: %v", err)
+	}
+	if supported {
+		sidecarCaps = append(sidecarCaps, extensions.NewServiceCapability(extensions.Capability_Service_VOLUME_HEALTH))
+	}
+
 	sidecarServer := server.NewSidecarServer(*controllerIP, *controllerPort)
-	sidecarServer.RegisterService(service.NewIdentityServer(csiClient.GetGRPCClient()))
-	sidecarServer.RegisterService(service.NewReclaimSpaceServer(csiClient.GetGRPCClient(), kubeClient, *stagingPath))
-	sidecarServer.RegisterService(service.NewNetworkFenceServer(csiClient.GetGRPCClient(), kubeClient))
-	sidecarServer.RegisterService(service.NewReplicationServer(csiClient.GetGRPCClient(), kubeClient))
+	sidecarServer.RegisterService(service.NewIdentityServer(csiClient.GetGRPCClient(), sidecarCaps...))
+	sidecarServer.RegisterService(service.NewReclaimSpaceServer(csiClient.GetGRPCClient(), kubeCache, *stagingPath, *podsPath))
+	sidecarServer.RegisterService(service.NewNetworkFenceServer(csiClient.GetGRPCClient(), kubeCache))
+	sidecarServer.RegisterService(service.NewReplicationServer(csiClient.GetGRPCClient(), kubeCache))
+	sidecarServer.RegisterService(volumeHealthServer)
+	sidecarServer.RegisterService(service.NewEncryptionKeyRotationServer(csiClient.GetGRPCClient(), kubeCache, *stagingPath, *podsPath))
+	sidecarServer.RegisterService(service.NewFilesystemMaintenanceServer(csiClient.GetGRPCClient(), kubeCache, *stagingPath, *podsPath))
 
 	sidecarServer.Start()
 }